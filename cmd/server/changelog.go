@@ -0,0 +1,194 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Alan-TheGentleman/gentleman-book-mcp/internal/book"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ChangelogEntry is one detected content change, generated by diffing a
+// chapter's current section list against the last snapshot taken of it.
+type ChangelogEntry struct {
+	SchemaVersion int       `json:"schemaVersion"`
+	At            time.Time `json:"at"`
+	ChapterID     string    `json:"chapterId"`
+	ChapterName   string    `json:"chapterName"`
+	Locale        string    `json:"locale"`
+	Summary       string    `json:"summary"`
+}
+
+// changelogMaxEntries bounds the in-memory changelog so a long-running
+// server watching frequent upstream changes doesn't grow this unbounded.
+const changelogMaxEntries = 200
+
+// changelogStore holds detected change entries and the last-seen section
+// list per chapter, so the next refresh can diff against it. It's purely
+// in-memory -- like coverageTracker, it resets on restart -- there's no
+// durable changelog persistence here.
+type changelogStore struct {
+	mu        sync.Mutex
+	entries   []ChangelogEntry
+	snapshots map[string][]string // locale|chapterId -> section names, in order
+}
+
+var changelog = &changelogStore{snapshots: make(map[string][]string)}
+
+func snapshotKey(locale, chapterID string) string {
+	return locale + "|" + chapterID
+}
+
+// sectionNames extracts a chapter's section names, in document order, for
+// diffing against the previous snapshot.
+func sectionNames(ch book.Chapter) []string {
+	names := make([]string, len(ch.TitleList))
+	for i, s := range ch.TitleList {
+		names[i] = s.Name
+	}
+	return names
+}
+
+// diffSections compares before/after section name lists and summarizes what
+// changed, in the "Chapter X added section on Y" shape the request asked
+// for. Returns "" if nothing changed.
+func diffSections(chapterName string, before, after []string) string {
+	beforeSet := make(map[string]bool, len(before))
+	for _, n := range before {
+		beforeSet[n] = true
+	}
+	afterSet := make(map[string]bool, len(after))
+	for _, n := range after {
+		afterSet[n] = true
+	}
+
+	var added, removed []string
+	for _, n := range after {
+		if !beforeSet[n] {
+			added = append(added, n)
+		}
+	}
+	for _, n := range before {
+		if !afterSet[n] {
+			removed = append(removed, n)
+		}
+	}
+
+	if len(added) == 0 && len(removed) == 0 {
+		return ""
+	}
+
+	var parts []string
+	for _, n := range added {
+		parts = append(parts, fmt.Sprintf("added section on %s", n))
+	}
+	for _, n := range removed {
+		parts = append(parts, fmt.Sprintf("removed section on %s", n))
+	}
+	return fmt.Sprintf("%s: %s", chapterName, joinWithAnd(parts))
+}
+
+func joinWithAnd(parts []string) string {
+	switch len(parts) {
+	case 0:
+		return ""
+	case 1:
+		return parts[0]
+	default:
+		last := parts[len(parts)-1]
+		return fmt.Sprintf("%s and %s", joinCommaSeparated(parts[:len(parts)-1]), last)
+	}
+}
+
+func joinCommaSeparated(parts []string) string {
+	out := parts[0]
+	for _, p := range parts[1:] {
+		out += ", " + p
+	}
+	return out
+}
+
+// detectChapterChanges diffs every chapter in locale against its last
+// snapshot (if any), recording a ChangelogEntry for each that changed and
+// updating the snapshot regardless, so the next call diffs against what's
+// current now.
+func detectChapterChanges(p *book.Parser, locale string, now time.Time) ([]ChangelogEntry, error) {
+	chapters, err := p.ListChapters(locale)
+	if err != nil {
+		return nil, err
+	}
+
+	changelog.mu.Lock()
+	defer changelog.mu.Unlock()
+
+	var detected []ChangelogEntry
+	for _, ch := range chapters {
+		key := snapshotKey(locale, ch.ID)
+		current := sectionNames(ch)
+
+		if previous, ok := changelog.snapshots[key]; ok {
+			if summary := diffSections(ch.Name, previous, current); summary != "" {
+				detected = append(detected, ChangelogEntry{
+					SchemaVersion: book.SchemaVersion,
+					At:            now,
+					ChapterID:     ch.ID,
+					ChapterName:   ch.Name,
+					Locale:        locale,
+					Summary:       summary,
+				})
+			}
+		}
+		changelog.snapshots[key] = current
+	}
+
+	changelog.entries = append(changelog.entries, detected...)
+	if len(changelog.entries) > changelogMaxEntries {
+		changelog.entries = changelog.entries[len(changelog.entries)-changelogMaxEntries:]
+	}
+
+	return detected, nil
+}
+
+// recordChapterChanges runs detectChapterChanges and, if anything changed,
+// notifies connected clients that book://changelog has new content -- the
+// "optionally pushed as a notification" half of the request. Notification
+// delivery is best-effort: a nil mcpServer (not yet constructed, or a test)
+// just skips it.
+func recordChapterChanges(p *book.Parser, locale string) ([]ChangelogEntry, error) {
+	detected, err := detectChapterChanges(p, locale, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if len(detected) > 0 && mcpServer != nil {
+		mcpServer.SendNotificationToAllClients(mcp.MethodNotificationResourceUpdated, map[string]any{
+			"uri": "book://changelog",
+		})
+	}
+	return detected, nil
+}
+
+// snapshot returns the changelog entries, most recent first.
+func (c *changelogStore) snapshot() []ChangelogEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make([]ChangelogEntry, len(c.entries))
+	copy(out, c.entries)
+	sort.SliceStable(out, func(i, j int) bool { return out[i].At.After(out[j].At) })
+	return out
+}
+
+func handleChangelogResource(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	entries := changelog.snapshot()
+	data, _ := json.MarshalIndent(entries, "", "  ")
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      req.Params.URI,
+			MIMEType: "application/json",
+			Text:     string(data),
+		},
+	}, nil
+}
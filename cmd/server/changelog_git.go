@@ -0,0 +1,191 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Alan-TheGentleman/gentleman-book-mcp/internal/book"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// GitChangeEntry is one commit's effect on one chapter file, as recorded in
+// the book repo's git history -- independent of changelog.go's live,
+// in-memory section diffing, this is built fresh from `git log` every call
+// so it survives restarts and covers history from before this process
+// started.
+type GitChangeEntry struct {
+	SchemaVersion int       `json:"schemaVersion"`
+	CommitHash    string    `json:"commitHash"`
+	Date          time.Time `json:"date"`
+	ChapterID     string    `json:"chapterId"`
+	ChapterName   string    `json:"chapterName"`
+	Locale        string    `json:"locale"`
+	Summary       string    `json:"summary"`
+}
+
+const gitLogRecordSep = "\x01"
+const gitLogFieldSep = "\x1f"
+
+// gitChangesSince runs `git log --numstat` over locale's chapter directory
+// and maps each touched file back to a chapter, returning one GitChangeEntry
+// per (commit, chapter) pair with commits at or after since. An empty since
+// returns full history.
+func gitChangesSince(ctx context.Context, p *book.Parser, bookPath, locale string, since time.Time) ([]GitChangeEntry, error) {
+	chapters, err := p.ListChapters(locale)
+	if err != nil {
+		return nil, err
+	}
+
+	byRelPath := make(map[string]book.Chapter, len(chapters))
+	for _, ch := range chapters {
+		rel, err := filepath.Rel(bookPath, ch.FilePath)
+		if err != nil {
+			continue
+		}
+		byRelPath[filepath.ToSlash(rel)] = ch
+	}
+
+	args := []string{
+		"-C", bookPath, "log",
+		"--date=iso-strict",
+		"--pretty=format:" + gitLogRecordSep + "%H" + gitLogFieldSep + "%ad",
+		"--numstat",
+	}
+	if !since.IsZero() {
+		args = append(args, "--since="+since.Format(time.RFC3339))
+	}
+	args = append(args, "--", locale)
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git log failed: %w", err)
+	}
+
+	return parseGitLogNumstat(string(out), byRelPath, locale), nil
+}
+
+// parseGitLogNumstat parses output shaped by gitChangesSince's --pretty and
+// --numstat flags into one GitChangeEntry per chapter file touched by each
+// commit.
+func parseGitLogNumstat(out string, byRelPath map[string]book.Chapter, locale string) []GitChangeEntry {
+	var entries []GitChangeEntry
+
+	records := strings.Split(out, gitLogRecordSep)
+	for _, record := range records {
+		record = strings.TrimSpace(record)
+		if record == "" {
+			continue
+		}
+
+		lines := strings.Split(record, "\n")
+		header := strings.SplitN(lines[0], gitLogFieldSep, 2)
+		if len(header) != 2 {
+			continue
+		}
+		hash := header[0]
+		date, err := time.Parse(time.RFC3339, header[1])
+		if err != nil {
+			continue
+		}
+
+		for _, line := range lines[1:] {
+			line = strings.TrimSpace(line)
+			if line == "" {
+				continue
+			}
+			fields := strings.SplitN(line, "\t", 3)
+			if len(fields) != 3 {
+				continue
+			}
+			chapter, ok := byRelPath[fields[2]]
+			if !ok {
+				continue
+			}
+
+			entries = append(entries, GitChangeEntry{
+				SchemaVersion: book.SchemaVersion,
+				CommitHash:    hash,
+				Date:          date,
+				ChapterID:     chapter.ID,
+				ChapterName:   chapter.Name,
+				Locale:        locale,
+				Summary:       numstatSummary(fields[0], fields[1]),
+			})
+		}
+	}
+
+	return entries
+}
+
+// numstatSummary renders git's numstat insertions/deletions counts ("-" for
+// a binary file, which can't happen for .mdx content but is handled anyway)
+// as "+N/-M lines".
+func numstatSummary(insertions, deletions string) string {
+	ins, insErr := strconv.Atoi(insertions)
+	del, delErr := strconv.Atoi(deletions)
+	if insErr != nil || delErr != nil {
+		return "binary change"
+	}
+	return fmt.Sprintf("+%d/-%d lines", ins, del)
+}
+
+func handleGetRecentChanges(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if res := requireNotDegraded(); res != nil {
+		return res, nil
+	}
+
+	locale := req.GetString("locale", "es")
+	sinceParam := req.GetString("since", "")
+	if res := validateArgLength(locale, "locale", maxShortArgLength); res != nil {
+		return res, nil
+	}
+	if res := validateArgLength(sinceParam, "since", maxShortArgLength); res != nil {
+		return res, nil
+	}
+
+	var since time.Time
+	if sinceParam != "" {
+		parsed, err := time.Parse("2006-01-02", sinceParam)
+		if err != nil {
+			parsed, err = time.Parse(time.RFC3339, sinceParam)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("since must be YYYY-MM-DD or RFC3339, got %q", sinceParam)), nil
+			}
+		}
+		since = parsed
+	}
+
+	entries, err := gitChangesSince(ctx, parser, bookPath, locale, since)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error reading git history: %v", err)), nil
+	}
+
+	result, _ := json.MarshalIndent(entries, "", "  ")
+	return mcp.NewToolResultText(string(result)), nil
+}
+
+func handleGitChangelogResource(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	uri := req.Params.URI
+	locale := strings.TrimPrefix(uri, "book://changelog/")
+
+	entries, err := gitChangesSince(ctx, parser, bookPath, locale, time.Time{})
+	if err != nil {
+		return nil, fmt.Errorf("error reading git history: %w", err)
+	}
+
+	data, _ := json.MarshalIndent(entries, "", "  ")
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      uri,
+			MIMEType: "application/json",
+			Text:     string(data),
+		},
+	}, nil
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"log"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// defaultChunkSkipPatterns matches the MDX/JSX boilerplate that pollutes the
+// semantic index if indexed as if it were prose: import statements and the
+// shared nav/footer components every chapter pulls in.
+var defaultChunkSkipPatterns = []string{
+	`^\s*import\s+.+\s+from\s+['"].+['"];?\s*$`,
+	`^\s*export\s+default\s+.+;?\s*$`,
+	`^\s*</?(Nav|NavBar|Footer|SiteFooter)\b[^>]*>\s*$`,
+}
+
+// CHUNK_SKIP_PATTERNS lets an operator add extra regexes (comma-separated)
+// on top of the defaults, for boilerplate specific to their own book fork.
+func loadChunkSkipPatterns() []*regexp.Regexp {
+	patterns := append([]string{}, defaultChunkSkipPatterns...)
+	if extra := os.Getenv("CHUNK_SKIP_PATTERNS"); extra != "" {
+		patterns = append(patterns, strings.Split(extra, ",")...)
+	}
+
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			log.Printf("chunker: ignoring invalid skip pattern %q: %v", p, err)
+			continue
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled
+}
+
+var chunkSkipPatterns = loadChunkSkipPatterns()
+
+// BoilerplateFilterStats reports how much of a chapter's raw content was
+// dropped as boilerplate before chunking, so an operator can tell whether
+// the skip patterns are actually doing anything for their book.
+type BoilerplateFilterStats struct {
+	ChapterID     string `json:"chapterId"`
+	Locale        string `json:"locale"`
+	OriginalChars int    `json:"originalChars"`
+	FilteredChars int    `json:"filteredChars"`
+	SkippedLines  int    `json:"skippedLines"`
+}
+
+// filterBoilerplate drops lines matching chunkSkipPatterns from content
+// before it's handed to splitIntoChunks, returning the cleaned text plus
+// stats about what was removed.
+func filterBoilerplate(content, chapterID, locale string) (string, BoilerplateFilterStats) {
+	stats := BoilerplateFilterStats{ChapterID: chapterID, Locale: locale, OriginalChars: len(content)}
+	if len(chunkSkipPatterns) == 0 {
+		stats.FilteredChars = len(content)
+		return content, stats
+	}
+
+	lines := strings.Split(content, "\n")
+	kept := make([]string, 0, len(lines))
+	for _, line := range lines {
+		skip := false
+		for _, re := range chunkSkipPatterns {
+			if re.MatchString(line) {
+				skip = true
+				break
+			}
+		}
+		if skip {
+			stats.SkippedLines++
+			continue
+		}
+		kept = append(kept, line)
+	}
+
+	filtered := strings.Join(kept, "\n")
+	stats.FilteredChars = len(filtered)
+	return filtered, stats
+}
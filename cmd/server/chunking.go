@@ -0,0 +1,43 @@
+package main
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/Alan-TheGentleman/gentleman-book-mcp/internal/book"
+)
+
+// defaultChunkSizeTokens preserves splitIntoChunks' historical behavior
+// (1000-character chunks) expressed in tokens via book.CharsForTokens'
+// chars-per-token ratio.
+const defaultChunkSizeTokens = 250
+
+// defaultChunkOverlapTokens is 0: no overlap, matching the chunker's
+// original (pre-overlap) behavior unless an operator opts in.
+const defaultChunkOverlapTokens = 0
+
+// CHUNK_SIZE_TOKENS overrides defaultChunkSizeTokens, letting an operator
+// trade denser, more precise citations (smaller chunks) against fewer
+// embedding calls and more context per hit (larger chunks).
+func chunkSizeChars() int {
+	if v := os.Getenv("CHUNK_SIZE_TOKENS"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			return book.CharsForTokens(n)
+		}
+	}
+	return book.CharsForTokens(defaultChunkSizeTokens)
+}
+
+// CHUNK_OVERLAP_TOKENS overrides defaultChunkOverlapTokens. A positive value
+// carries that many tokens' worth of trailing context from each chunk
+// forward into the next one, so a passage that straddles a chunk boundary
+// isn't invisible to whichever chunk a semantic search happens to rank
+// higher.
+func chunkOverlapChars() int {
+	if v := os.Getenv("CHUNK_OVERLAP_TOKENS"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			return book.CharsForTokens(n)
+		}
+	}
+	return book.CharsForTokens(defaultChunkOverlapTokens)
+}
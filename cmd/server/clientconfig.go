@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// supportedClients lists the MCP client identifiers accepted by the
+// get_client_config tool and the `config` CLI subcommand.
+var supportedClients = []string{"claude-desktop", "cursor", "zed", "vscode"}
+
+// mcpClientConfig renders the JSON snippet a user pastes into their MCP
+// client's config file to run this server. Claude Desktop and Cursor share the
+// same "mcpServers" shape; Zed and VS Code each use their own.
+func mcpClientConfig(client, execPath, bookPath string) (string, error) {
+	env := map[string]string{"BOOK_PATH": bookPath}
+
+	var cfg interface{}
+	switch client {
+	case "claude-desktop", "cursor":
+		cfg = map[string]interface{}{
+			"mcpServers": map[string]interface{}{
+				"gentleman-book": map[string]interface{}{
+					"command": execPath,
+					"args":    []string{},
+					"env":     env,
+				},
+			},
+		}
+	case "zed":
+		cfg = map[string]interface{}{
+			"context_servers": map[string]interface{}{
+				"gentleman-book": map[string]interface{}{
+					"source":  "custom",
+					"command": execPath,
+					"args":    []string{},
+					"env":     env,
+				},
+			},
+		}
+	case "vscode":
+		cfg = map[string]interface{}{
+			"servers": map[string]interface{}{
+				"gentleman-book": map[string]interface{}{
+					"type":    "stdio",
+					"command": execPath,
+					"args":    []string{},
+					"env":     env,
+				},
+			},
+		}
+	default:
+		return "", fmt.Errorf("unknown client %q (supported: %v)", client, supportedClients)
+	}
+
+	out, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("error rendering client config: %w", err)
+	}
+	return string(out), nil
+}
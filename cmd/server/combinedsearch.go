@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Alan-TheGentleman/gentleman-book-mcp/internal/book"
+	"github.com/Alan-TheGentleman/gentleman-book-mcp/internal/embeddings"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// searchResponse is the JSON shape returned by the search tool: whichever
+// results it found, plus which backend actually produced them, so a caller
+// doesn't have to guess why two queries against the same server came back
+// differently ranked.
+type searchResponse struct {
+	Mode    string      `json:"mode"` // "semantic", "fts5", "bleve", or "memory"
+	Results interface{} `json:"results"`
+}
+
+// handleSearch implements the search tool: it picks the best backend
+// available for this book right now -- semantic search if an index has
+// been built, otherwise whichever keyword backend SEARCH_BACKEND selects
+// (or the plain scan if it selects none) -- and reports which one it used,
+// so agents don't have to track search_book vs semantic_search themselves.
+func handleSearch(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if res := requireNotDegraded(); res != nil {
+		return res, nil
+	}
+
+	query := req.GetString("query", "")
+	locale := req.GetString("locale", "es")
+	explain := req.GetBool("explain", false)
+	tone := req.GetString("tone", "")
+	topK := clampInt(req.GetInt("top_k", 5), 1, maxTopK)
+
+	if query == "" {
+		return mcp.NewToolResultError("query is required"), nil
+	}
+	if res := validateArgLength(query, "query", maxQueryLength); res != nil {
+		return res, nil
+	}
+	if res := validateArgLength(locale, "locale", maxShortArgLength); res != nil {
+		return res, nil
+	}
+
+	bookID := resolveBookID(req)
+
+	if engine, err := bookSemanticEngine(bookID); err == nil && engine != nil && engine.IsIndexed() {
+		results, err := engine.SearchExplain(ctx, query, locale, topK, explain)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Search error: %v", err)), nil
+		}
+		results = applySemanticResultURLs(results)
+		for _, r := range results {
+			coverageTrackers.get(bookID).recordSection(r.ChapterID, r.ChapterName, r.Section)
+		}
+		return searchModeResult("semantic", results, query)
+	}
+
+	t, err := tenants.get(bookID)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	results, err := t.parser.SearchExplain(query, locale, explain)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error searching: %v", err)), nil
+	}
+
+	results = applyResultPostProcessors(applyRankingBoosters(query, results))
+	results = filterByTone(results, tone)
+	results = applySearchResultURLs(results)
+
+	if faq := matchFAQ(query, locale); faq != nil {
+		results = append([]book.SearchResult{faqSearchResult(faq, locale)}, results...)
+	}
+
+	for _, r := range results {
+		coverageTrackers.get(bookID).recordSection(r.ChapterID, r.ChapterName, r.Section)
+	}
+
+	return searchModeResult(t.parser.ActiveSearchBackend(), results, query)
+}
+
+// searchModeResult wraps results (whatever concrete slice type the backend
+// that produced them returns) in a searchResponse reporting mode, or a
+// plain "no results" text result if results is empty -- same
+// no-results-found message search_book/semantic_search already use.
+func searchModeResult(mode string, results interface{}, query string) (*mcp.CallToolResult, error) {
+	empty := false
+	switch r := results.(type) {
+	case []book.SearchResult:
+		empty = len(r) == 0
+	case []embeddings.SemanticResult:
+		empty = len(r) == 0
+	}
+	if empty {
+		return mcp.NewToolResultText(fmt.Sprintf("No results found for: %s (mode: %s)", query, mode)), nil
+	}
+
+	resultJSON, _ := json.MarshalIndent(searchResponse{Mode: mode, Results: results}, "", "  ")
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
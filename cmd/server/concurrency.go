@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// toolConcurrencyClasses groups tool names that share a resource worth
+// bounding (embedding-provider quota, memory for an index rebuild) under
+// one class name, so e.g. semantic_search and the combined search tool
+// compete for the same slots instead of each getting their own. Tools not
+// listed here aren't limited at all.
+var toolConcurrencyClasses = map[string]string{
+	"build_semantic_index":  "index_build",
+	"update_semantic_index": "index_build",
+	"semantic_search":       "semantic_search",
+	"hybrid_search":         "semantic_search",
+	"search":                "semantic_search",
+}
+
+// defaultMaxConcurrentIndexBuild/defaultMaxConcurrentSemanticSearch are
+// conservative defaults: one index build/update at a time (they already
+// run as background jobs, so there's no reason to let agents pile up many
+// at once), and a handful of concurrent semantic searches so a single
+// agent swarm can't exhaust an embedding provider's rate limit.
+const (
+	defaultMaxConcurrentIndexBuild      = 1
+	defaultMaxConcurrentSemanticSearch  = 4
+	defaultMaxQueuedPerConcurrencyClass = 20
+)
+
+// classConcurrencyLimits maps each class to (maxConcurrent, maxQueued),
+// letting MAX_CONCURRENT_<CLASS> and MAX_QUEUED_<CLASS> override the
+// defaults above per deployment.
+func classConcurrencyLimits() map[string][2]int {
+	return map[string][2]int{
+		"index_build":     {envInt("MAX_CONCURRENT_INDEX_BUILD", defaultMaxConcurrentIndexBuild), envInt("MAX_QUEUED_INDEX_BUILD", defaultMaxQueuedPerConcurrencyClass)},
+		"semantic_search": {envInt("MAX_CONCURRENT_SEMANTIC_SEARCH", defaultMaxConcurrentSemanticSearch), envInt("MAX_QUEUED_SEMANTIC_SEARCH", defaultMaxQueuedPerConcurrencyClass)},
+	}
+}
+
+func envInt(key string, fallback int) int {
+	if v := os.Getenv(key); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			return n
+		}
+	}
+	return fallback
+}
+
+// concurrencyLimiter bounds how many callers can be running at once
+// (capacity maxConcurrent), and how many more can be waiting for a slot
+// (maxQueued) -- a caller that shows up once the queue is already full
+// gets an immediate busy error instead of blocking indefinitely.
+type concurrencyLimiter struct {
+	class     string
+	slots     chan struct{}
+	maxQueued int32
+	waiting   int32 // atomic
+}
+
+func newConcurrencyLimiter(class string, maxConcurrent, maxQueued int) *concurrencyLimiter {
+	return &concurrencyLimiter{
+		class:     class,
+		slots:     make(chan struct{}, maxConcurrent),
+		maxQueued: int32(maxQueued),
+	}
+}
+
+// acquire blocks until a slot is free, unless the queue is already at
+// maxQueued callers deep or ctx is canceled first, in which case it
+// returns an error describing why instead of blocking.
+func (l *concurrencyLimiter) acquire(ctx context.Context) error {
+	if atomic.AddInt32(&l.waiting, 1) > l.maxQueued {
+		atomic.AddInt32(&l.waiting, -1)
+		return fmt.Errorf("%s is busy: too many concurrent calls already queued (max %d); try again shortly", l.class, l.maxQueued)
+	}
+	defer atomic.AddInt32(&l.waiting, -1)
+
+	select {
+	case l.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (l *concurrencyLimiter) release() {
+	<-l.slots
+}
+
+// toolConcurrencyMiddleware returns a server.ToolHandlerMiddleware that
+// gates each class in toolConcurrencyClasses through its own
+// concurrencyLimiter (built lazily, one per class, the first time that
+// class is called), so concurrent build_semantic_index calls or a burst of
+// parallel semantic searches from an agent swarm queue up to a bound
+// instead of exhausting memory or an embedding provider's rate limit.
+func toolConcurrencyMiddleware() server.ToolHandlerMiddleware {
+	limits := classConcurrencyLimits()
+	limiters := make(map[string]*concurrencyLimiter, len(limits))
+	var mu sync.Mutex
+
+	limiterFor := func(class string) *concurrencyLimiter {
+		mu.Lock()
+		defer mu.Unlock()
+		if l, ok := limiters[class]; ok {
+			return l
+		}
+		bounds := limits[class]
+		l := newConcurrencyLimiter(class, bounds[0], bounds[1])
+		limiters[class] = l
+		return l
+	}
+
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			class, limited := toolConcurrencyClasses[req.Params.Name]
+			if !limited {
+				return next(ctx, req)
+			}
+
+			limiter := limiterFor(class)
+			if err := limiter.acquire(ctx); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			defer limiter.release()
+
+			return next(ctx, req)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+func TestConcurrencyLimiterBoundsConcurrentSlots(t *testing.T) {
+	l := newConcurrencyLimiter("test", 1, 5)
+
+	if err := l.acquire(context.Background()); err != nil {
+		t.Fatalf("first acquire: %v", err)
+	}
+
+	// A second acquire should block until the first is released: confirm
+	// it doesn't return immediately, then release and confirm it does.
+	done := make(chan error, 1)
+	go func() { done <- l.acquire(context.Background()) }()
+
+	select {
+	case err := <-done:
+		t.Fatalf("expected second acquire to block while the slot is held, got err=%v", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	l.release()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("expected second acquire to succeed after release, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("second acquire never unblocked after release")
+	}
+	l.release()
+}
+
+func TestConcurrencyLimiterRejectsWhenQueueFull(t *testing.T) {
+	l := newConcurrencyLimiter("test", 1, 1)
+
+	if err := l.acquire(context.Background()); err != nil {
+		t.Fatalf("first acquire: %v", err)
+	}
+	defer l.release()
+
+	// One caller queued behind the held slot is allowed...
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		l.acquire(context.Background())
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	// ...but a second one arriving while the queue is already full should
+	// be rejected immediately rather than queueing indefinitely.
+	if err := l.acquire(context.Background()); err == nil {
+		t.Fatal("expected an error when the queue is already at maxQueued")
+	}
+}
+
+func TestConcurrencyLimiterRespectsContextCancellation(t *testing.T) {
+	l := newConcurrencyLimiter("test", 1, 5)
+	if err := l.acquire(context.Background()); err != nil {
+		t.Fatalf("first acquire: %v", err)
+	}
+	defer l.release()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := l.acquire(ctx); err == nil {
+		t.Fatal("expected acquire to return an error for an already-canceled context")
+	}
+}
+
+func TestToolConcurrencyMiddlewareOnlyGatesListedTools(t *testing.T) {
+	var calls int32
+	var mu sync.Mutex
+	next := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+		return mcp.NewToolResultText("ok"), nil
+	}
+
+	wrapped := toolConcurrencyMiddleware()(server.ToolHandlerFunc(next))
+
+	// A tool with no concurrency class set should pass straight through.
+	res, err := wrapped(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "search_book"}})
+	if err != nil || res == nil || res.IsError {
+		t.Fatalf("expected an unlimited tool to pass through, got res=%+v err=%v", res, err)
+	}
+
+	// A tool with a concurrency class should still succeed under its limit.
+	res, err = wrapped(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "semantic_search"}})
+	if err != nil || res == nil || res.IsError {
+		t.Fatalf("expected a gated tool within its limit to succeed, got res=%+v err=%v", res, err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if calls != 2 {
+		t.Fatalf("expected both calls to reach next, got %d", calls)
+	}
+}
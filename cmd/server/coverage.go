@@ -0,0 +1,248 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+
+	"github.com/Alan-TheGentleman/gentleman-book-mcp/internal/book"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// coverageTracker counts how many times each chapter/section has been
+// surfaced to a client, via either a direct read_chapter call or a
+// search_book/semantic_search hit. It's purely in-memory and resets on
+// restart — there's no persistence story here yet, just a way for an agent
+// (or its operator) to see which parts of the book it actually leaned on.
+// One tracker exists per tenant/book id (see coverageRegistry below), so
+// tenants don't see each other's usage.
+type coverageTracker struct {
+	mu          sync.Mutex
+	hits        map[string]int // chapterID -> hit count
+	names       map[string]string
+	sectionHits map[string]*sectionHit // chapterID|section -> hit info, see sectionHitKey
+}
+
+// sectionHit tracks one chapter/section pair's usage count, for the
+// book://stats/usage resource.
+type sectionHit struct {
+	chapterID   string
+	chapterName string
+	section     string
+	hits        int
+}
+
+func newCoverageTracker() *coverageTracker {
+	return &coverageTracker{
+		hits:        make(map[string]int),
+		names:       make(map[string]string),
+		sectionHits: make(map[string]*sectionHit),
+	}
+}
+
+// coverageRegistry keeps one coverageTracker per book/tenant id, the same
+// partitioning tenantRegistry uses for parsers and semantic engines --
+// otherwise every tenant (and every concurrent client sharing the process in
+// the sse/http transport) would read and pollute the same usage counters.
+type coverageRegistry struct {
+	mu       sync.Mutex
+	trackers map[string]*coverageTracker
+}
+
+// get returns bookID's tracker, creating it on first use. An empty bookID is
+// normalized to defaultTenantID, same as tenantRegistry.get.
+func (r *coverageRegistry) get(bookID string) *coverageTracker {
+	if bookID == "" {
+		bookID = defaultTenantID
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if t, ok := r.trackers[bookID]; ok {
+		return t
+	}
+	t := newCoverageTracker()
+	r.trackers[bookID] = t
+	return t
+}
+
+var coverageTrackers = &coverageRegistry{trackers: make(map[string]*coverageTracker)}
+
+func sectionHitKey(chapterID, section string) string {
+	return chapterID + "|" + section
+}
+
+func (c *coverageTracker) record(chapterID, chapterName string) {
+	if chapterID == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.hits[chapterID]++
+	if chapterName != "" {
+		c.names[chapterID] = chapterName
+	}
+}
+
+// recordSection records a hit against chapterID (same as record) and, if
+// section is non-empty, also against that specific chapter/section pair, so
+// book://stats/usage can report which sections -- not just which chapters --
+// get read or searched most.
+func (c *coverageTracker) recordSection(chapterID, chapterName, section string) {
+	c.record(chapterID, chapterName)
+	if chapterID == "" || section == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := sectionHitKey(chapterID, section)
+	entry, ok := c.sectionHits[key]
+	if !ok {
+		entry = &sectionHit{chapterID: chapterID, section: section}
+		c.sectionHits[key] = entry
+	}
+	entry.hits++
+	if chapterName != "" {
+		entry.chapterName = chapterName
+	}
+}
+
+// hasHits reports whether chapterID has ever been read or searched this
+// session, for check_readiness to judge whether a prerequisite was actually
+// covered rather than just declared.
+func (c *coverageTracker) hasHits(chapterID string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hits[chapterID] > 0
+}
+
+// coveredChapterIDs returns the set of chapter IDs with at least one hit, for
+// callers (like pickDigestChapter) that want to pick among untouched chapters
+// without reaching into the tracker's lock/map directly.
+func (c *coverageTracker) coveredChapterIDs() map[string]bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	covered := make(map[string]bool, len(c.hits))
+	for id := range c.hits {
+		covered[id] = true
+	}
+	return covered
+}
+
+// ChapterCoverage is one chapter's usage count in a CoverageReport.
+type ChapterCoverage struct {
+	ChapterID   string `json:"chapterId"`
+	ChapterName string `json:"chapterName"`
+	Hits        int    `json:"hits"`
+}
+
+// CoverageReport summarizes which chapters an agent has actually used this
+// session, and which ones in the full index it never touched.
+type CoverageReport struct {
+	SchemaVersion int               `json:"schemaVersion"`
+	Covered       []ChapterCoverage `json:"covered"`
+	Untouched     []string          `json:"untouched"`
+}
+
+// report builds a CoverageReport against the full set of known chapter IDs,
+// so callers can see coverage gaps as well as hits.
+func (c *coverageTracker) report(allChapterIDs map[string]string) CoverageReport {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var covered []ChapterCoverage
+	for id, hits := range c.hits {
+		covered = append(covered, ChapterCoverage{ChapterID: id, ChapterName: c.names[id], Hits: hits})
+	}
+	// Canonical order: hits descending, tie-broken by chapter ID so the
+	// report is reproducible across runs despite map iteration order.
+	sort.Slice(covered, func(i, j int) bool {
+		if covered[i].Hits != covered[j].Hits {
+			return covered[i].Hits > covered[j].Hits
+		}
+		return covered[i].ChapterID < covered[j].ChapterID
+	})
+
+	var untouched []string
+	for id := range allChapterIDs {
+		if _, ok := c.hits[id]; !ok {
+			untouched = append(untouched, id)
+		}
+	}
+	sort.Strings(untouched)
+
+	return CoverageReport{SchemaVersion: book.SchemaVersion, Covered: covered, Untouched: untouched}
+}
+
+// SectionCoverage is one chapter/section pair's usage count in a
+// UsageReport.
+type SectionCoverage struct {
+	ChapterID   string `json:"chapterId"`
+	ChapterName string `json:"chapterName"`
+	Section     string `json:"section"`
+	Hits        int    `json:"hits"`
+}
+
+// UsageReport summarizes which chapters and sections have actually been
+// read or searched, most-used first, for the book author to weigh against
+// content priorities.
+type UsageReport struct {
+	SchemaVersion int               `json:"schemaVersion"`
+	Chapters      []ChapterCoverage `json:"chapters"`
+	Sections      []SectionCoverage `json:"sections"`
+}
+
+// usageReport builds a UsageReport from every chapter and section hit
+// recorded so far, sorted by hits descending (ties broken by ID/section so
+// the report is reproducible across runs despite map iteration order).
+func (c *coverageTracker) usageReport() UsageReport {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	chapters := make([]ChapterCoverage, 0, len(c.hits))
+	for id, hits := range c.hits {
+		chapters = append(chapters, ChapterCoverage{ChapterID: id, ChapterName: c.names[id], Hits: hits})
+	}
+	sort.Slice(chapters, func(i, j int) bool {
+		if chapters[i].Hits != chapters[j].Hits {
+			return chapters[i].Hits > chapters[j].Hits
+		}
+		return chapters[i].ChapterID < chapters[j].ChapterID
+	})
+
+	sections := make([]SectionCoverage, 0, len(c.sectionHits))
+	for _, entry := range c.sectionHits {
+		sections = append(sections, SectionCoverage{
+			ChapterID:   entry.chapterID,
+			ChapterName: entry.chapterName,
+			Section:     entry.section,
+			Hits:        entry.hits,
+		})
+	}
+	sort.Slice(sections, func(i, j int) bool {
+		if sections[i].Hits != sections[j].Hits {
+			return sections[i].Hits > sections[j].Hits
+		}
+		if sections[i].ChapterID != sections[j].ChapterID {
+			return sections[i].ChapterID < sections[j].ChapterID
+		}
+		return sections[i].Section < sections[j].Section
+	})
+
+	return UsageReport{SchemaVersion: book.SchemaVersion, Chapters: chapters, Sections: sections}
+}
+
+// handleUsageStatsResource serves book://stats/usage: a live snapshot of
+// which chapters and sections have actually been read or searched in this
+// process's lifetime. Resource URIs don't carry a tenant_id/book_id, so this
+// always reports the default tenant's tracker.
+func handleUsageStatsResource(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	data, _ := json.MarshalIndent(coverageTrackers.get(defaultTenantID).usageReport(), "", "  ")
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      req.Params.URI,
+			MIMEType: "application/json",
+			Text:     string(data),
+		},
+	}, nil
+}
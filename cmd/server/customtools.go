@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/Alan-TheGentleman/gentleman-book-mcp/internal/book"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// customToolSpec declares one operator-defined tool: what to retrieve from
+// the book and how to format it, without touching Go code. This is how
+// domain-specific tools like `get_scrum_ceremonies` get added on top of the
+// generic search/read tools.
+type customToolSpec struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Locale      string   `json:"locale"`      // default locale if the caller doesn't pass one
+	SearchTerms []string `json:"searchTerms"` // keyword searches to run and feed into the template
+	ChapterIDs  []string `json:"chapterIds"`  // whole chapters to fetch and feed into the template
+	Template    string   `json:"template"`    // text/template rendered against the retrieval results
+}
+
+// customToolTermResult is the per-term data available to a custom tool's
+// template.
+type customToolTermResult struct {
+	Term    string
+	Results []book.SearchResult
+}
+
+// customToolData is what a custom tool's template renders against.
+type customToolData struct {
+	Locale   string
+	Terms    []customToolTermResult
+	Chapters []*book.Chapter
+}
+
+// loadCustomToolSpecs reads CUSTOM_TOOLS_CONFIG (a JSON array of
+// customToolSpec) if set. Without it, no custom tools are registered.
+func loadCustomToolSpecs() ([]customToolSpec, error) {
+	path := os.Getenv("CUSTOM_TOOLS_CONFIG")
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading CUSTOM_TOOLS_CONFIG %s: %w", path, err)
+	}
+
+	var specs []customToolSpec
+	if err := json.Unmarshal(data, &specs); err != nil {
+		return nil, fmt.Errorf("error parsing CUSTOM_TOOLS_CONFIG %s: %w", path, err)
+	}
+	return specs, nil
+}
+
+// registerCustomTools loads CUSTOM_TOOLS_CONFIG, if any, and registers each
+// declared tool on s. A bad config disables custom tools but never prevents
+// the server from starting, matching the rest of the server's degraded-mode
+// philosophy for optional features.
+func registerCustomTools(s *server.MCPServer) {
+	specs, err := loadCustomToolSpecs()
+	if err != nil {
+		log.Printf("Custom tools disabled: %v", err)
+		return
+	}
+
+	for _, spec := range specs {
+		spec := spec
+		tmpl, err := template.New(spec.Name).Funcs(promptFuncs).Parse(spec.Template)
+		if err != nil {
+			log.Printf("Custom tool %q disabled: invalid template: %v", spec.Name, err)
+			continue
+		}
+
+		defaultLocale := spec.Locale
+		if defaultLocale == "" {
+			defaultLocale = "es"
+		}
+
+		s.AddTool(
+			mcp.NewTool(spec.Name,
+				mcp.WithDescription(spec.Description),
+				mcp.WithString("locale",
+					mcp.Description("Language locale: 'es' for Spanish, 'en' for English"),
+					mcp.DefaultString(defaultLocale),
+				),
+			),
+			newCustomToolHandler(spec, tmpl),
+		)
+		log.Printf("Registered custom tool %q from CUSTOM_TOOLS_CONFIG", spec.Name)
+	}
+}
+
+// newCustomToolHandler builds the handler for a single custom tool: it runs
+// the spec's retrieval pipeline (search terms + whole chapters) and renders
+// the result through tmpl.
+func newCustomToolHandler(spec customToolSpec, tmpl *template.Template) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if resp := requireNotDegraded(); resp != nil {
+			return resp, nil
+		}
+
+		locale := req.GetString("locale", spec.Locale)
+		if locale == "" {
+			locale = "es"
+		}
+		if res := validateArgLength(locale, "locale", maxShortArgLength); res != nil {
+			return res, nil
+		}
+
+		data := customToolData{Locale: locale}
+
+		for _, term := range spec.SearchTerms {
+			results, err := parser.Search(term, locale)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("error searching for %q: %v", term, err)), nil
+			}
+			data.Terms = append(data.Terms, customToolTermResult{Term: term, Results: results})
+		}
+
+		for _, chapterID := range spec.ChapterIDs {
+			chapter, err := parser.GetChapter(chapterID, locale)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("error reading chapter %q: %v", chapterID, err)), nil
+			}
+			data.Chapters = append(data.Chapters, chapter)
+		}
+
+		var buf strings.Builder
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("error rendering custom tool %q: %v", spec.Name, err)), nil
+		}
+		return mcp.NewToolResultText(buf.String()), nil
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/Alan-TheGentleman/gentleman-book-mcp/internal/book"
+)
+
+func TestLoadCustomToolSpecsUnset(t *testing.T) {
+	os.Unsetenv("CUSTOM_TOOLS_CONFIG")
+	specs, err := loadCustomToolSpecs()
+	if err != nil || specs != nil {
+		t.Fatalf("expected (nil, nil) when unset, got (%+v, %v)", specs, err)
+	}
+}
+
+func TestLoadCustomToolSpecsMissingFile(t *testing.T) {
+	t.Setenv("CUSTOM_TOOLS_CONFIG", filepath.Join(t.TempDir(), "missing.json"))
+	if _, err := loadCustomToolSpecs(); err == nil {
+		t.Fatal("expected an error for a nonexistent config file")
+	}
+}
+
+func TestLoadCustomToolSpecsBadJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "custom.json")
+	os.WriteFile(path, []byte("{not json"), 0644)
+	t.Setenv("CUSTOM_TOOLS_CONFIG", path)
+	if _, err := loadCustomToolSpecs(); err == nil {
+		t.Fatal("expected an error for malformed JSON")
+	}
+}
+
+func TestLoadCustomToolSpecsValid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "custom.json")
+	data, _ := json.Marshal([]customToolSpec{
+		{Name: "get_scrum_ceremonies", Description: "d", Locale: "en", SearchTerms: []string{"sprint"}, Template: "{{.Locale}}"},
+	})
+	os.WriteFile(path, data, 0644)
+	t.Setenv("CUSTOM_TOOLS_CONFIG", path)
+
+	specs, err := loadCustomToolSpecs()
+	if err != nil {
+		t.Fatalf("loadCustomToolSpecs: %v", err)
+	}
+	if len(specs) != 1 || specs[0].Name != "get_scrum_ceremonies" {
+		t.Fatalf("unexpected specs: %+v", specs)
+	}
+}
+
+// parseCustomToolTemplate mirrors the parsing registerCustomTools does for
+// each spec's template, so a bad template is caught the same way here.
+func parseCustomToolTemplate(t *testing.T, name, body string) (*template.Template, error) {
+	t.Helper()
+	return template.New(name).Funcs(promptFuncs).Parse(body)
+}
+
+func TestCustomToolTemplateRejectsInvalidSyntax(t *testing.T) {
+	if _, err := parseCustomToolTemplate(t, "bad", "{{.Locale"); err == nil {
+		t.Fatal("expected a parse error for unclosed template syntax")
+	}
+}
+
+func TestCustomToolTemplateRendersAgainstRetrievalData(t *testing.T) {
+	tmpl, err := parseCustomToolTemplate(t, "ok", "Locale={{.Locale}} Terms={{range .Terms}}{{.Term}}:{{len .Results}} {{end}}Chapters={{range .Chapters}}{{.ID}} {{end}}")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	data := customToolData{
+		Locale: "en",
+		Terms: []customToolTermResult{
+			{Term: "sprint", Results: []book.SearchResult{{ChapterID: "ch1"}, {ChapterID: "ch2"}}},
+		},
+		Chapters: []*book.Chapter{{ID: "ch3"}},
+	}
+
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, data); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	got := buf.String()
+	if !strings.Contains(got, "Locale=en") || !strings.Contains(got, "sprint:2") || !strings.Contains(got, "Chapters=ch3") {
+		t.Fatalf("unexpected rendered output: %q", got)
+	}
+}
+
+func TestCustomToolTemplateHasNoAccessToHostEnvironment(t *testing.T) {
+	// text/template (unlike a shell or an eval-based engine) only exposes
+	// what's explicitly passed in plus promptFuncs; a template referencing
+	// anything else is a parse/execute error, not a host escape.
+	tmpl, err := parseCustomToolTemplate(t, "probe", "{{.Env}}")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, customToolData{Locale: "en"}); err == nil {
+		t.Fatal("expected an execute error for a field that doesn't exist on customToolData")
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Alan-TheGentleman/gentleman-book-mcp/internal/book"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// digestHeadingPattern matches a markdown heading, used to skip past headings
+// when picking a representative line to quote.
+var digestHeadingPattern = regexp.MustCompile(`^#{1,6}\s+`)
+
+// Digest is a short, bot-postable summary drawn from one chapter the caller
+// hasn't covered yet: one concept explained, one quoted line, and one
+// exercise. There's no dedicated notes/progress store in this server
+// (coverageTracker is purely in-memory, per process lifetime), so "unread"
+// here means "not yet hit via read_chapter/search_book/semantic_search this
+// session" rather than a durable per-reader history.
+type Digest struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Period        string `json:"period"`
+	ChapterID     string `json:"chapterId"`
+	ChapterName   string `json:"chapterName"`
+	Concept       string `json:"concept"`
+	Quote         string `json:"quote"`
+	Exercise      string `json:"exercise,omitempty"`
+}
+
+// pickDigestChapter picks the chapter for the next digest: the untouched
+// chapter with the lowest ID if any exist (so a digest series works through
+// the whole book before repeating), falling back to the first chapter
+// overall once everything has been covered.
+func pickDigestChapter(chapters []book.Chapter) book.Chapter {
+	// publish_digest is a scheduled task against the default tenant's book,
+	// so it reads the default tenant's coverage tracker.
+	covered := coverageTrackers.get(defaultTenantID).coveredChapterIDs()
+
+	sorted := make([]book.Chapter, len(chapters))
+	copy(sorted, chapters)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	for _, ch := range sorted {
+		if !covered[ch.ID] {
+			return ch
+		}
+	}
+	return sorted[0]
+}
+
+// digestQuoteLine returns the first substantive (non-heading, non-empty)
+// line of content, as a stand-in for a "memorable quote" -- the book has no
+// curated quote bank, so this picks the chapter's own opening statement
+// rather than fabricating one.
+func digestQuoteLine(content string) string {
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || digestHeadingPattern.MatchString(line) {
+			continue
+		}
+		return line
+	}
+	return ""
+}
+
+// buildDigest composes a Digest for chapter: its opening statement as the
+// "concept", its first substantive line as the "quote", and one checklist
+// item (if the chapter has any) as the "exercise".
+func buildDigest(p *book.Parser, chapter book.Chapter, locale, period string) Digest {
+	digest := Digest{
+		SchemaVersion: book.SchemaVersion,
+		Period:        period,
+		ChapterID:     chapter.ID,
+		ChapterName:   chapter.Name,
+		Concept:       budgetedChapterSummary(chapter.Content, 400),
+		Quote:         digestQuoteLine(chapter.Content),
+	}
+
+	if items, err := p.GetChecklists(chapter.ID, locale); err == nil && len(items) > 0 {
+		digest.Exercise = items[0].Text
+	}
+
+	return digest
+}
+
+func handleGenerateDigest(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if res := requireNotDegraded(); res != nil {
+		return res, nil
+	}
+
+	locale := req.GetString("locale", "es")
+	period := req.GetString("period", "daily")
+	if res := validateArgLength(locale, "locale", maxShortArgLength); res != nil {
+		return res, nil
+	}
+	if period != "daily" && period != "weekly" {
+		return mcp.NewToolResultError("period must be 'daily' or 'weekly'"), nil
+	}
+
+	chapters, err := parser.ListChapters(locale)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error listing chapters: %v", err)), nil
+	}
+	if len(chapters) == 0 {
+		return mcp.NewToolResultError("no chapters available to build a digest from"), nil
+	}
+
+	chapter := pickDigestChapter(chapters)
+	digest := buildDigest(parser, chapter, locale, period)
+	coverageTrackers.get(defaultTenantID).record(chapter.ID, chapter.Name)
+
+	result, _ := json.MarshalIndent(digest, "", "  ")
+	return mcp.NewToolResultText(string(result)), nil
+}
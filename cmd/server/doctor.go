@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Alan-TheGentleman/gentleman-book-mcp/internal/book"
+	"github.com/Alan-TheGentleman/gentleman-book-mcp/internal/embeddings"
+)
+
+// runDoctor runs a startup self-test against bookPath and prints a readiness
+// report. It never calls log.Fatal so a broken setup is always fully diagnosed.
+func runDoctor(bookPath string) {
+	fmt.Println("Gentleman Book MCP — doctor")
+	fmt.Println("============================")
+
+	ok := true
+
+	fmt.Printf("Book path: %s\n", bookPath)
+	info, err := os.Stat(bookPath)
+	if err != nil {
+		fmt.Printf("  [FAIL] path does not exist or is unreadable: %v\n", err)
+		ok = false
+	} else if !info.IsDir() {
+		fmt.Println("  [FAIL] path is not a directory")
+		ok = false
+	} else {
+		fmt.Println("  [OK] path exists")
+	}
+
+	if ok {
+		p := book.NewParser(bookPath)
+
+		locales, err := p.GetAvailableLocales()
+		if err != nil || len(locales) == 0 {
+			fmt.Println("  [FAIL] no locale directories found (expected 'es' and/or 'en')")
+			ok = false
+		} else {
+			fmt.Printf("  [OK] locales found: %v\n", locales)
+		}
+
+		for _, locale := range locales {
+			chapters, err := p.ListChapters(locale)
+			if err != nil {
+				fmt.Printf("  [FAIL] could not list chapters for locale %s: %v\n", locale, err)
+				ok = false
+				continue
+			}
+			if len(chapters) == 0 {
+				fmt.Printf("  [WARN] no parseable chapters found for locale %s\n", locale)
+				continue
+			}
+			fmt.Printf("  [OK] locale %s: %d chapters parsed\n", locale, len(chapters))
+		}
+	}
+
+	fmt.Println("Embedding providers:")
+	if os.Getenv("OPENAI_API_KEY") != "" {
+		if _, err := embeddings.NewSemanticEngine(embeddings.ProviderOpenAI); err != nil {
+			fmt.Printf("  [FAIL] OpenAI client: %v\n", err)
+		} else {
+			fmt.Println("  [OK] OPENAI_API_KEY is set")
+		}
+	} else {
+		fmt.Println("  [SKIP] OPENAI_API_KEY not set")
+	}
+
+	for _, p := range []struct {
+		envVar   string
+		provider embeddings.Provider
+		label    string
+	}{
+		{"COHERE_API_KEY", embeddings.ProviderCohere, "Cohere"},
+		{"VOYAGE_API_KEY", embeddings.ProviderVoyage, "Voyage AI"},
+		{"GEMINI_API_KEY", embeddings.ProviderGemini, "Gemini"},
+	} {
+		if os.Getenv(p.envVar) == "" {
+			fmt.Printf("  [SKIP] %s not set\n", p.envVar)
+			continue
+		}
+		if _, err := embeddings.NewSemanticEngine(p.provider); err != nil {
+			fmt.Printf("  [FAIL] %s client: %v\n", p.label, err)
+		} else {
+			fmt.Printf("  [OK] %s is set\n", p.envVar)
+		}
+	}
+
+	ollama, err := embeddings.NewSemanticEngine(embeddings.ProviderOllama)
+	if err == nil && ollama.IsAvailable() {
+		fmt.Println("  [OK] Ollama reachable")
+	} else {
+		fmt.Println("  [SKIP] Ollama not reachable")
+	}
+
+	fmt.Println("Index persistence:")
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		fmt.Printf("  [WARN] could not resolve user cache dir: %v\n", err)
+	} else {
+		target := filepath.Join(cacheDir, "gentleman-book-mcp")
+		if err := os.MkdirAll(target, 0o755); err != nil {
+			fmt.Printf("  [FAIL] %s is not writable: %v\n", target, err)
+			ok = false
+		} else {
+			fmt.Printf("  [OK] %s is writable\n", target)
+		}
+	}
+
+	fmt.Println("============================")
+	if ok {
+		fmt.Println("Result: ready")
+	} else {
+		fmt.Println("Result: NOT ready — fix the [FAIL] items above")
+		os.Exit(1)
+	}
+}
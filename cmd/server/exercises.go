@@ -0,0 +1,44 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Alan-TheGentleman/gentleman-book-mcp/internal/book"
+)
+
+// exerciseProgressTracker records which exercise IDs a reader has marked
+// done via complete_exercise. It's purely in-memory and process-local, same
+// as coverageTracker -- there's no per-reader identity threaded through yet,
+// just a way for a single session to see its own progress reflected back in
+// list_exercises/get_exercise.
+type exerciseProgressTracker struct {
+	mu        sync.Mutex
+	completed map[string]time.Time // exerciseID -> completion time
+}
+
+var exerciseProgress = &exerciseProgressTracker{completed: make(map[string]time.Time)}
+
+func (t *exerciseProgressTracker) complete(exerciseID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.completed[exerciseID] = time.Now()
+}
+
+func (t *exerciseProgressTracker) isCompleted(exerciseID string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, ok := t.completed[exerciseID]
+	return ok
+}
+
+// exerciseWithProgress is an Exercise plus whether it's been marked complete
+// this process's lifetime, the shape list_exercises/get_exercise return.
+type exerciseWithProgress struct {
+	book.Exercise
+	Completed bool `json:"completed"`
+}
+
+func withProgress(e book.Exercise) exerciseWithProgress {
+	return exerciseWithProgress{Exercise: e, Completed: exerciseProgress.isCompleted(e.ID)}
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Alan-TheGentleman/gentleman-book-mcp/internal/book"
+)
+
+// faqEntry maps one recurring query to a canonical answer, curated by an
+// operator (typically after spotting it in get_zero_result_queries) rather
+// than generated on the fly.
+type faqEntry struct {
+	Question    string `json:"question"`
+	Answer      string `json:"answer"`
+	ChapterID   string `json:"chapterId,omitempty"`
+	ChapterName string `json:"chapterName,omitempty"`
+	Section     string `json:"section,omitempty"`
+	// Locale scopes the entry to one locale. Empty matches any locale.
+	Locale string `json:"locale,omitempty"`
+}
+
+// faqConfigEnvVar points at a JSON file holding an array of faqEntry,
+// mirroring PREREQUISITES_CONFIG/TENANT_CONFIG's "path to a JSON config"
+// convention.
+const faqConfigEnvVar = "FAQ_CONFIG"
+
+// faqByQuestion maps a normalizeFAQQuestion'd question to its entry, for
+// O(1) lookup from search_book. Keyed additionally by locale so two
+// entries can answer the same question differently per language; a
+// locale-less entry is stored under every locale it's looked up against.
+var faqByQuestion map[string]faqEntry
+
+// loadFAQConfig reads FAQ_CONFIG (a JSON array of faqEntry) if set.
+func loadFAQConfig() (map[string]faqEntry, error) {
+	path := os.Getenv(faqConfigEnvVar)
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading FAQ_CONFIG %s: %w", path, err)
+	}
+
+	var entries []faqEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("error parsing FAQ_CONFIG %s: %w", path, err)
+	}
+
+	byQuestion := make(map[string]faqEntry, len(entries))
+	for _, e := range entries {
+		byQuestion[faqKey(e.Locale, e.Question)] = e
+	}
+	return byQuestion, nil
+}
+
+var faqNormalizePattern = regexp.MustCompile(`[^\p{L}\p{N}\s]`)
+
+// normalizeFAQQuestion folds a question down to lowercase alphanumeric
+// words, so "What is TDD?", "what is tdd", and "What is TDD" all match the
+// same curated entry.
+func normalizeFAQQuestion(q string) string {
+	q = strings.ToLower(q)
+	q = faqNormalizePattern.ReplaceAllString(q, "")
+	return strings.Join(strings.Fields(q), " ")
+}
+
+func faqKey(locale, question string) string {
+	return locale + "|" + normalizeFAQQuestion(question)
+}
+
+// matchFAQ looks up query against the curated FAQ, preferring a
+// locale-specific entry and falling back to a locale-less one.
+func matchFAQ(query, locale string) *faqEntry {
+	if len(faqByQuestion) == 0 {
+		return nil
+	}
+	if e, ok := faqByQuestion[faqKey(locale, query)]; ok {
+		return &e
+	}
+	if e, ok := faqByQuestion[faqKey("", query)]; ok {
+		return &e
+	}
+	return nil
+}
+
+// faqSearchResult turns a matched FAQ entry into a SearchResult so it can
+// be prepended to search_book's ordinary results without changing the
+// tool's response shape -- FAQ: true is the only tell.
+func faqSearchResult(e *faqEntry, locale string) book.SearchResult {
+	return book.SearchResult{
+		ChapterID:   e.ChapterID,
+		ChapterName: e.ChapterName,
+		Section:     e.Section,
+		Snippet:     e.Answer,
+		Relevance:   1.0,
+		Locale:      locale,
+		FAQ:         true,
+	}
+}
+
+// zeroResultTracker counts how often each query returns no search_book
+// hits, so an operator can see which recurring questions are worth curating
+// into FAQ_CONFIG.
+type zeroResultTracker struct {
+	mu     sync.Mutex
+	counts map[string]int    // normalized query -> count
+	latest map[string]string // normalized query -> most recently seen verbatim form
+}
+
+var zeroResultQueries = &zeroResultTracker{
+	counts: make(map[string]int),
+	latest: make(map[string]string),
+}
+
+func (z *zeroResultTracker) record(query string) {
+	key := normalizeFAQQuestion(query)
+	if key == "" {
+		return
+	}
+	z.mu.Lock()
+	defer z.mu.Unlock()
+	z.counts[key]++
+	z.latest[key] = query
+}
+
+// ZeroResultQuery is one normalized query's zero-result count in a
+// ZeroResultReport.
+type ZeroResultQuery struct {
+	Query string `json:"query"`
+	Count int    `json:"count"`
+}
+
+// report returns the tracked queries, most frequent first.
+func (z *zeroResultTracker) report() []ZeroResultQuery {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+
+	out := make([]ZeroResultQuery, 0, len(z.counts))
+	for key, count := range z.counts {
+		out = append(out, ZeroResultQuery{Query: z.latest[key], Count: count})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Count != out[j].Count {
+			return out[i].Count > out[j].Count
+		}
+		return out[i].Query < out[j].Query
+	})
+	return out
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"encoding/xml"
+	"log"
+	"net/http"
+	"time"
+)
+
+// atomFeed and atomEntry mirror the subset of the Atom 1.0 schema
+// (RFC 4287) that the changelog needs: a feed title/id/updated plus one
+// entry per detected change.
+type atomFeed struct {
+	XMLName xml.Name    `xml:"feed"`
+	Xmlns   string      `xml:"xmlns,attr"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	Title   string    `xml:"title"`
+	ID      string    `xml:"id"`
+	Updated string    `xml:"updated"`
+	Link    *atomLink `xml:"link,omitempty"`
+	Summary string    `xml:"summary"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+}
+
+func handleFeedXML(w http.ResponseWriter, r *http.Request) {
+	entries := changelog.snapshot()
+
+	updated := time.Now().UTC()
+	if len(entries) > 0 {
+		updated = entries[0].At.UTC()
+	}
+
+	feed := atomFeed{
+		Xmlns:   "http://www.w3.org/2005/Atom",
+		Title:   "Gentleman Programming Book - Changes",
+		ID:      "urn:gentleman-book-mcp:changelog",
+		Updated: updated.Format(time.RFC3339),
+	}
+
+	for _, e := range entries {
+		entry := atomEntry{
+			Title:   e.ChapterName,
+			ID:      "urn:gentleman-book-mcp:changelog:" + e.Locale + ":" + e.ChapterID + ":" + e.At.UTC().Format(time.RFC3339),
+			Updated: e.At.UTC().Format(time.RFC3339),
+			Summary: e.Summary,
+		}
+		if url := siteURLConfig.ChapterURL(e.ChapterID, e.Locale); url != "" {
+			entry.Link = &atomLink{Href: url}
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+
+	w.Header().Set("Content-Type", "application/atom+xml; charset=utf-8")
+	w.Write([]byte(xml.Header))
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(feed); err != nil {
+		log.Printf("Error encoding feed: %v", err)
+	}
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/graphql-go/graphql"
+)
+
+// graphqlSchema exposes the same read-only queries as the list_chapters,
+// read_chapter, search_book, and semantic_search tools, as a GraphQL schema
+// for website integrations that would rather query this service directly
+// than speak MCP.
+var graphqlSchema graphql.Schema
+
+var sectionType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Section",
+	Fields: graphql.Fields{
+		"name":  &graphql.Field{Type: graphql.String},
+		"tagId": &graphql.Field{Type: graphql.String},
+	},
+})
+
+var chapterType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Chapter",
+	Fields: graphql.Fields{
+		"id":        &graphql.Field{Type: graphql.String},
+		"order":     &graphql.Field{Type: graphql.Int},
+		"name":      &graphql.Field{Type: graphql.String},
+		"locale":    &graphql.Field{Type: graphql.String},
+		"titleList": &graphql.Field{Type: graphql.NewList(sectionType)},
+		"content":   &graphql.Field{Type: graphql.String},
+		"url":       &graphql.Field{Type: graphql.String},
+	},
+})
+
+var searchResultType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "SearchResult",
+	Fields: graphql.Fields{
+		"chapterId":       &graphql.Field{Type: graphql.String},
+		"chapterName":     &graphql.Field{Type: graphql.String},
+		"section":         &graphql.Field{Type: graphql.String},
+		"snippet":         &graphql.Field{Type: graphql.String},
+		"lineNumber":      &graphql.Field{Type: graphql.Int},
+		"relevance":       &graphql.Field{Type: graphql.Float},
+		"locale":          &graphql.Field{Type: graphql.String},
+		"estimatedTokens": &graphql.Field{Type: graphql.Int},
+		"url":             &graphql.Field{Type: graphql.String},
+	},
+})
+
+var semanticResultType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "SemanticResult",
+	Fields: graphql.Fields{
+		"chapterId":       &graphql.Field{Type: graphql.String},
+		"chapterName":     &graphql.Field{Type: graphql.String},
+		"section":         &graphql.Field{Type: graphql.String},
+		"content":         &graphql.Field{Type: graphql.String},
+		"score":           &graphql.Field{Type: graphql.Float},
+		"locale":          &graphql.Field{Type: graphql.String},
+		"estimatedTokens": &graphql.Field{Type: graphql.Int},
+		"url":             &graphql.Field{Type: graphql.String},
+	},
+})
+
+// localeArg mirrors every tool's "locale" argument: default "es".
+func localeArg(p graphql.ResolveParams) string {
+	if locale, ok := p.Args["locale"].(string); ok && locale != "" {
+		return locale
+	}
+	return "es"
+}
+
+func buildGraphQLSchema() (graphql.Schema, error) {
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"chapters": &graphql.Field{
+				Type: graphql.NewList(chapterType),
+				Args: graphql.FieldConfigArgument{
+					"locale": &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					chapters, err := parser.ListChapters(localeArg(p))
+					if err != nil {
+						return nil, err
+					}
+					for i := range chapters {
+						applyChapterURL(&chapters[i])
+					}
+					return chapters, nil
+				},
+			},
+			"chapter": &graphql.Field{
+				Type: chapterType,
+				Args: graphql.FieldConfigArgument{
+					"id":     &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"locale": &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					chapter, err := parser.GetChapter(p.Args["id"].(string), localeArg(p))
+					if err != nil {
+						return nil, err
+					}
+					applyChapterURL(chapter)
+					return chapter, nil
+				},
+			},
+			"search": &graphql.Field{
+				Type: graphql.NewList(searchResultType),
+				Args: graphql.FieldConfigArgument{
+					"query":  &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"locale": &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					results, err := parser.Search(p.Args["query"].(string), localeArg(p))
+					if err != nil {
+						return nil, err
+					}
+					return applySearchResultURLs(results), nil
+				},
+			},
+			"semanticSearch": &graphql.Field{
+				Type: graphql.NewList(semanticResultType),
+				Args: graphql.FieldConfigArgument{
+					"query":  &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"locale": &graphql.ArgumentConfig{Type: graphql.String},
+					"topK":   &graphql.ArgumentConfig{Type: graphql.Int},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if semanticEngine == nil {
+						return nil, errSemanticSearchUnavailable
+					}
+					topK := 5
+					if v, ok := p.Args["topK"].(int); ok && v > 0 {
+						topK = v
+					}
+					results, err := semanticEngine.Search(p.Context, p.Args["query"].(string), localeArg(p), topK)
+					if err != nil {
+						return nil, err
+					}
+					return applySemanticResultURLs(results), nil
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: queryType})
+}
+
+var errSemanticSearchUnavailable = &graphqlError{"semantic search not available: set OPENAI_API_KEY or ensure Ollama is running"}
+
+type graphqlError struct{ message string }
+
+func (e *graphqlError) Error() string { return e.message }
+
+// graphqlRequestBody is the standard GraphQL-over-HTTP request shape: a
+// query string plus optional variables and operation name.
+type graphqlRequestBody struct {
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables"`
+	OperationName string                 `json:"operationName"`
+}
+
+func handleGraphQL(w http.ResponseWriter, r *http.Request) {
+	var body graphqlRequestBody
+
+	if r.Method == http.MethodGet {
+		// The standard GraphQL-over-GET convention: query (and optionally
+		// a JSON-encoded variables) as URL query params. This is what lets
+		// read-only queries be cached by cachingMiddleware like any other
+		// GET route -- a POST body isn't safe to key a cache by URL alone.
+		body.Query = r.URL.Query().Get("query")
+		body.OperationName = r.URL.Query().Get("operationName")
+		if raw := r.URL.Query().Get("variables"); raw != "" {
+			if err := json.Unmarshal([]byte(raw), &body.Variables); err != nil {
+				http.Error(w, "invalid variables: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+	} else if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "invalid GraphQL request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if body.Query == "" {
+		http.Error(w, "query is required", http.StatusBadRequest)
+		return
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:         graphqlSchema,
+		RequestString:  body.Query,
+		VariableValues: body.Variables,
+		OperationName:  body.OperationName,
+		Context:        r.Context(),
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
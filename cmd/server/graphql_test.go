@@ -0,0 +1,157 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Alan-TheGentleman/gentleman-book-mcp/internal/book"
+)
+
+func withTestParser(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	localeDir := filepath.Join(dir, "en")
+	os.Mkdir(localeDir, 0o755)
+	writeChapterFixture(t, localeDir, "ch1", "Chapter One", 1, "# Intro\nHello world, this is a test chapter.")
+
+	origParser := parser
+	t.Cleanup(func() { parser = origParser })
+	parser = book.NewParser(dir)
+}
+
+func TestHTTPGatewayAddrDisabledByDefault(t *testing.T) {
+	os.Unsetenv(httpGatewayAddrEnvVar)
+	if _, enabled := httpGatewayAddr(); enabled {
+		t.Fatal("expected the HTTP gateway to be disabled when HTTP_GATEWAY_ADDR is unset")
+	}
+}
+
+func TestHTTPGatewayAddrEnabled(t *testing.T) {
+	t.Setenv(httpGatewayAddrEnvVar, ":1234")
+	addr, enabled := httpGatewayAddr()
+	if !enabled || addr != ":1234" {
+		t.Fatalf("expected (%q, true), got (%q, %v)", ":1234", addr, enabled)
+	}
+}
+
+func doGraphQL(t *testing.T, query string) map[string]interface{} {
+	t.Helper()
+	body, _ := json.Marshal(graphqlRequestBody{Query: query})
+	req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	handleGraphQL(rec, req)
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &decoded); err != nil {
+		t.Fatalf("decoding response %q: %v", rec.Body.String(), err)
+	}
+	return decoded
+}
+
+func TestHandleGraphQLQueriesChapters(t *testing.T) {
+	withTestParser(t)
+	schema, err := buildGraphQLSchema()
+	if err != nil {
+		t.Fatalf("buildGraphQLSchema: %v", err)
+	}
+	origSchema := graphqlSchema
+	t.Cleanup(func() { graphqlSchema = origSchema })
+	graphqlSchema = schema
+
+	decoded := doGraphQL(t, `{ chapters(locale: "en") { id name } }`)
+	if _, hasErrors := decoded["errors"]; hasErrors {
+		t.Fatalf("expected no GraphQL errors, got %+v", decoded)
+	}
+	data, _ := json.Marshal(decoded["data"])
+	if !strings.Contains(string(data), `"ch1"`) || !strings.Contains(string(data), "Chapter One") {
+		t.Fatalf("expected ch1/Chapter One in the response, got %s", data)
+	}
+}
+
+func TestHandleGraphQLSearch(t *testing.T) {
+	withTestParser(t)
+	schema, err := buildGraphQLSchema()
+	if err != nil {
+		t.Fatalf("buildGraphQLSchema: %v", err)
+	}
+	origSchema := graphqlSchema
+	t.Cleanup(func() { graphqlSchema = origSchema })
+	graphqlSchema = schema
+
+	decoded := doGraphQL(t, `{ search(query: "test chapter", locale: "en") { chapterId } }`)
+	if _, hasErrors := decoded["errors"]; hasErrors {
+		t.Fatalf("expected no GraphQL errors, got %+v", decoded)
+	}
+	data, _ := json.Marshal(decoded["data"])
+	if !strings.Contains(string(data), `"ch1"`) {
+		t.Fatalf("expected a search result for ch1, got %s", data)
+	}
+}
+
+func TestHandleGraphQLRejectsEmptyQuery(t *testing.T) {
+	schema, err := buildGraphQLSchema()
+	if err != nil {
+		t.Fatalf("buildGraphQLSchema: %v", err)
+	}
+	origSchema := graphqlSchema
+	t.Cleanup(func() { graphqlSchema = origSchema })
+	graphqlSchema = schema
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader([]byte(`{}`)))
+	rec := httptest.NewRecorder()
+	handleGraphQL(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an empty query, got %d", rec.Code)
+	}
+}
+
+func TestHandleGraphQLGetUsesQueryParams(t *testing.T) {
+	withTestParser(t)
+	schema, err := buildGraphQLSchema()
+	if err != nil {
+		t.Fatalf("buildGraphQLSchema: %v", err)
+	}
+	origSchema := graphqlSchema
+	t.Cleanup(func() { graphqlSchema = origSchema })
+	graphqlSchema = schema
+
+	req := httptest.NewRequest(http.MethodGet, `/graphql?query={chapters(locale:"en"){id}}`, nil)
+	rec := httptest.NewRecorder()
+	handleGraphQL(rec, req)
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &decoded); err != nil {
+		t.Fatalf("decoding response %q: %v", rec.Body.String(), err)
+	}
+	if _, hasErrors := decoded["errors"]; hasErrors {
+		t.Fatalf("expected no GraphQL errors, got %+v", decoded)
+	}
+}
+
+func TestSemanticSearchResolverErrorsWithoutAnEngine(t *testing.T) {
+	withTestParser(t)
+	origEngine := semanticEngine
+	t.Cleanup(func() { semanticEngine = origEngine })
+	semanticEngine = nil
+
+	schema, err := buildGraphQLSchema()
+	if err != nil {
+		t.Fatalf("buildGraphQLSchema: %v", err)
+	}
+	origSchema := graphqlSchema
+	t.Cleanup(func() { graphqlSchema = origSchema })
+	graphqlSchema = schema
+
+	decoded := doGraphQL(t, `{ semanticSearch(query: "test", locale: "en") { chapterId } }`)
+	if _, hasErrors := decoded["errors"]; !hasErrors {
+		t.Fatalf("expected a GraphQL error when semanticEngine is nil, got %+v", decoded)
+	}
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// defaultHTTPCacheTTL is how long a cached GET response stays warm. The
+// HTTP_CACHE_TTL_SECONDS env var overrides it, e.g. for content that
+// changes more or less often than the default assumes.
+const defaultHTTPCacheTTL = 30 * time.Second
+
+// defaultHTTPCacheSize is the maximum number of distinct GET requests
+// (method+URL, since these routes take no other cache-relevant input) kept
+// warm at once.
+const defaultHTTPCacheSize = 200
+
+func httpCacheTTL() time.Duration {
+	if v := os.Getenv("HTTP_CACHE_TTL_SECONDS"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			return time.Duration(n) * time.Second
+		}
+	}
+	return defaultHTTPCacheTTL
+}
+
+// httpCacheEntry holds one cached GET response along with its expiration.
+type httpCacheEntry struct {
+	body        []byte
+	contentType string
+	etag        string
+	expiresAt   time.Time
+}
+
+// httpResponseCache is a small LRU cache with TTL for GET responses served
+// by the HTTP gateway, mirroring EmbeddingCache's shape: high-traffic
+// website usage shouldn't re-run a search or re-serialize the index on
+// every request for the same URL.
+type httpResponseCache struct {
+	mu      sync.Mutex
+	entries map[string]*httpCacheEntry
+	order   []string // least-recently-used first
+	maxSize int
+	ttl     time.Duration
+}
+
+func newHTTPResponseCache(maxSize int, ttl time.Duration) *httpResponseCache {
+	if maxSize <= 0 {
+		maxSize = defaultHTTPCacheSize
+	}
+	return &httpResponseCache{
+		entries: make(map[string]*httpCacheEntry),
+		maxSize: maxSize,
+		ttl:     ttl,
+	}
+}
+
+func (c *httpResponseCache) get(key string) (*httpCacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(c.entries, key)
+		c.removeFromOrder(key)
+		return nil, false
+	}
+	c.touch(key)
+	return entry, true
+}
+
+func (c *httpResponseCache) set(key string, entry *httpCacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, exists := c.entries[key]; !exists {
+		if len(c.order) >= c.maxSize {
+			oldest := c.order[0]
+			c.order = c.order[1:]
+			delete(c.entries, oldest)
+		}
+		c.order = append(c.order, key)
+	} else {
+		c.touch(key)
+	}
+	c.entries[key] = entry
+}
+
+func (c *httpResponseCache) touch(key string) {
+	c.removeFromOrder(key)
+	c.order = append(c.order, key)
+}
+
+func (c *httpResponseCache) removeFromOrder(key string) {
+	for i, k := range c.order {
+		if k == key {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			return
+		}
+	}
+}
+
+var gatewayResponseCache = newHTTPResponseCache(defaultHTTPCacheSize, httpCacheTTL())
+
+// cachingMiddleware wraps a GET handler with Cache-Control/ETag headers
+// backed by gatewayResponseCache. Non-GET requests pass straight through,
+// since POST /graphql queries aren't safe to cache by URL alone.
+func cachingMiddleware(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			next(w, r)
+			return
+		}
+
+		key := r.URL.String()
+		ttl := gatewayResponseCache.ttl
+
+		if entry, ok := gatewayResponseCache.get(key); ok {
+			if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" && ifNoneMatch == entry.etag {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			writeCachedResponse(w, entry, ttl)
+			return
+		}
+
+		recorder := httptest.NewRecorder()
+		next(recorder, r)
+
+		body := recorder.Body.Bytes()
+		if recorder.Code != http.StatusOK {
+			for k, vs := range recorder.Header() {
+				for _, v := range vs {
+					w.Header().Add(k, v)
+				}
+			}
+			w.WriteHeader(recorder.Code)
+			w.Write(body)
+			return
+		}
+
+		entry := &httpCacheEntry{
+			body:        body,
+			contentType: recorder.Header().Get("Content-Type"),
+			etag:        `"` + sha256Hex(body) + `"`,
+			expiresAt:   time.Now().Add(ttl),
+		}
+		gatewayResponseCache.set(key, entry)
+		writeCachedResponse(w, entry, ttl)
+	}
+}
+
+func writeCachedResponse(w http.ResponseWriter, entry *httpCacheEntry, ttl time.Duration) {
+	if entry.contentType != "" {
+		w.Header().Set("Content-Type", entry.contentType)
+	}
+	w.Header().Set("ETag", entry.etag)
+	w.Header().Set("Cache-Control", "public, max-age="+strconv.Itoa(int(ttl.Seconds())))
+	w.Write(entry.body)
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"os"
+)
+
+// httpGatewayAddrEnvVar enables an auxiliary HTTP gateway -- currently the
+// changelog Atom feed (/feed.xml) and a read-only GraphQL endpoint
+// (/graphql) -- alongside the server's MCP transport (stdio, sse, or http).
+// It's a separate listener because these are plain HTTP/JSON/XML routes for
+// non-MCP clients (the book's website, feed readers), not part of the MCP
+// protocol itself.
+const httpGatewayAddrEnvVar = "HTTP_GATEWAY_ADDR"
+
+func httpGatewayAddr() (string, bool) {
+	addr := os.Getenv(httpGatewayAddrEnvVar)
+	return addr, addr != ""
+}
+
+// startHTTPGateway starts the gateway listener in the background if
+// HTTP_GATEWAY_ADDR is set. It's best-effort: a listener failure is logged,
+// not fatal, since the MCP server doesn't depend on it.
+func startHTTPGateway() {
+	addr, enabled := httpGatewayAddr()
+	if !enabled {
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/feed.xml", cachingMiddleware(handleFeedXML))
+
+	schema, err := buildGraphQLSchema()
+	if err != nil {
+		log.Printf("GraphQL endpoint disabled: %v", err)
+	} else {
+		graphqlSchema = schema
+		mux.HandleFunc("/graphql", cachingMiddleware(handleGraphQL))
+	}
+
+	go func() {
+		log.Printf("Serving HTTP gateway on %s (/feed.xml, /graphql)", addr)
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			log.Printf("HTTP gateway error: %v", err)
+		}
+	}()
+}
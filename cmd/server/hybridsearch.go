@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/Alan-TheGentleman/gentleman-book-mcp/internal/book"
+	"github.com/Alan-TheGentleman/gentleman-book-mcp/internal/embeddings"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// rrfConstant is the "k" in reciprocal rank fusion's 1/(k+rank) term. 60 is
+// the value the RRF literature (and most hybrid search implementations)
+// settles on: large enough that a single ranker's #1 result doesn't
+// completely dominate the fused score, small enough that rank still matters
+// more than noise far down either list.
+const rrfConstant = 60
+
+// HybridSearchResult is one chapter/section fused from keyword and semantic
+// search rankings via reciprocal rank fusion.
+type HybridSearchResult struct {
+	ChapterID    string  `json:"chapterId"`
+	ChapterName  string  `json:"chapterName"`
+	Section      string  `json:"section"`
+	Snippet      string  `json:"snippet"`
+	Locale       string  `json:"locale"`
+	FusedScore   float64 `json:"fusedScore"`
+	KeywordRank  int     `json:"keywordRank,omitempty"`  // 1-based; 0 means not in the keyword results
+	SemanticRank int     `json:"semanticRank,omitempty"` // 1-based; 0 means not in the semantic results
+	URL          string  `json:"url,omitempty"`
+}
+
+// hybridMergeKey identifies the "same" result across both rankers. Keyword
+// and semantic search chunk content differently, so chapter+section is the
+// coarsest granularity both agree on.
+func hybridMergeKey(chapterID, section string) string {
+	return chapterID + "|" + section
+}
+
+// fuseRankings combines keyword and semantic rankings with reciprocal rank
+// fusion: each ranker contributes weight/(rrfConstant+rank) to a result's
+// fused score (rank is 1-based), and a result appearing in both rankers
+// simply accumulates both contributions. Results are returned sorted by
+// fused score descending.
+func fuseRankings(keywordResults []book.SearchResult, semanticResults []embeddings.SemanticResult, keywordWeight, semanticWeight float64) []HybridSearchResult {
+	fused := make(map[string]*HybridSearchResult)
+
+	order := make([]string, 0, len(keywordResults)+len(semanticResults))
+	get := func(key string) *HybridSearchResult {
+		if r, ok := fused[key]; ok {
+			return r
+		}
+		r := &HybridSearchResult{}
+		fused[key] = r
+		order = append(order, key)
+		return r
+	}
+
+	for i, r := range keywordResults {
+		key := hybridMergeKey(r.ChapterID, r.Section)
+		entry := get(key)
+		entry.ChapterID = r.ChapterID
+		entry.ChapterName = r.ChapterName
+		entry.Section = r.Section
+		entry.Locale = r.Locale
+		entry.Snippet = r.Snippet
+		entry.URL = r.URL
+		entry.KeywordRank = i + 1
+		entry.FusedScore += keywordWeight / float64(rrfConstant+i+1)
+	}
+
+	for i, r := range semanticResults {
+		key := hybridMergeKey(r.ChapterID, r.Section)
+		entry := get(key)
+		entry.ChapterID = r.ChapterID
+		entry.ChapterName = r.ChapterName
+		entry.Section = r.Section
+		entry.Locale = r.Locale
+		if entry.Snippet == "" {
+			entry.Snippet = book.Truncate(r.Content, 200)
+		}
+		if entry.URL == "" {
+			entry.URL = r.URL
+		}
+		entry.SemanticRank = i + 1
+		entry.FusedScore += semanticWeight / float64(rrfConstant+i+1)
+	}
+
+	results := make([]HybridSearchResult, 0, len(order))
+	for _, key := range order {
+		results = append(results, *fused[key])
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].FusedScore > results[j].FusedScore
+	})
+	return results
+}
+
+func handleHybridSearch(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if res := requireNotDegraded(); res != nil {
+		return res, nil
+	}
+
+	query := req.GetString("query", "")
+	locale := req.GetString("locale", "es")
+	topK := req.GetInt("top_k", 5)
+	keywordWeight := req.GetFloat("keyword_weight", 1.0)
+	semanticWeight := req.GetFloat("semantic_weight", 1.0)
+
+	if query == "" {
+		return mcp.NewToolResultError("query is required"), nil
+	}
+	if res := validateArgLength(query, "query", maxQueryLength); res != nil {
+		return res, nil
+	}
+	if res := validateArgLength(locale, "locale", maxShortArgLength); res != nil {
+		return res, nil
+	}
+	topK = clampInt(topK, 1, maxTopK)
+
+	bookID := resolveBookID(req)
+	t, err := tenants.get(bookID)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	keywordResults, err := t.parser.SearchExplain(query, locale, false)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error searching: %v", err)), nil
+	}
+	keywordResults = applySearchResultURLs(keywordResults)
+
+	// Semantic search is best-effort here: a book without a built index
+	// still gets a usable hybrid_search, it just degrades to keyword-only
+	// ranking instead of erroring out.
+	var semanticResults []embeddings.SemanticResult
+	if engine, engErr := bookSemanticEngine(bookID); engErr == nil && engine != nil && engine.IsIndexed() {
+		semanticResults, _ = engine.SearchExplain(ctx, query, locale, topK, false)
+		semanticResults = applySemanticResultURLs(semanticResults)
+	}
+
+	fused := fuseRankings(keywordResults, semanticResults, keywordWeight, semanticWeight)
+	if len(fused) > topK {
+		fused = fused[:topK]
+	}
+
+	for _, r := range fused {
+		coverageTrackers.get(bookID).recordSection(r.ChapterID, r.ChapterName, r.Section)
+	}
+
+	if len(fused) == 0 {
+		return mcp.NewToolResultText("No results found for: " + query), nil
+	}
+
+	resultJSON, _ := json.MarshalIndent(fused, "", "  ")
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
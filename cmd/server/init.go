@@ -0,0 +1,157 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// setupConfig is what `init` writes to disk and what the server could, in
+// principle, read back on startup to avoid re-prompting (not wired into the
+// server's own startup path yet — BOOK_PATH/env still wins there).
+type setupConfig struct {
+	BookPath string `json:"bookPath"`
+	Provider string `json:"provider"`
+}
+
+func configFilePath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gentleman-book-mcp", "config.json"), nil
+}
+
+// runInit walks the user through a first-run setup: locating the book
+// content, picking an embeddings provider, writing a config file, and
+// printing ready-to-paste MCP client config JSON.
+func runInit(args []string) {
+	fs := flag.NewFlagSet("init", flag.ExitOnError)
+	bookPathFlag := fs.String("book-path", "", "Path to the book content directory")
+	providerFlag := fs.String("provider", "", "Embeddings provider: openai, ollama, or none")
+	yes := fs.Bool("yes", false, "Non-interactive: accept flags/defaults without prompting")
+	fs.Parse(args)
+
+	reader := bufio.NewReader(os.Stdin)
+
+	bookPath := *bookPathFlag
+	if bookPath == "" && !*yes {
+		bookPath = prompt(reader, "Book content path", defaultBookPath())
+	}
+	if bookPath == "" {
+		bookPath = defaultBookPath()
+	}
+	bookPath = expandPath(bookPath)
+
+	if _, err := os.Stat(bookPath); err != nil {
+		fmt.Printf("Warning: %s does not look like a valid book path yet (%v). You can fix this later with BOOK_PATH or re-run init.\n", bookPath, err)
+	}
+
+	provider := strings.ToLower(*providerFlag)
+	if provider == "" && !*yes {
+		provider = strings.ToLower(prompt(reader, "Embeddings provider (openai/ollama/none)", "none"))
+	}
+	if provider == "" {
+		provider = "none"
+	}
+
+	cfg := setupConfig{BookPath: bookPath, Provider: provider}
+	path, err := configFilePath()
+	if err != nil {
+		fmt.Printf("Warning: could not resolve config directory: %v\n", err)
+	} else if err := writeSetupConfig(path, cfg); err != nil {
+		fmt.Printf("Warning: could not write config file %s: %v\n", path, err)
+	} else {
+		fmt.Printf("Wrote config to %s\n", path)
+	}
+
+	switch provider {
+	case "openai":
+		fmt.Println("Set OPENAI_API_KEY in your environment, then run the `build_semantic_index` tool once the server is running.")
+	case "ollama":
+		fmt.Println("Make sure Ollama is running locally, then run the `build_semantic_index` tool once the server is running.")
+	}
+
+	execPath, err := os.Executable()
+	if err != nil {
+		execPath = "gentleman-book-mcp"
+	}
+
+	snippet, err := mcpClientConfig("claude-desktop", execPath, bookPath)
+	if err != nil {
+		fmt.Printf("Warning: could not render client config: %v\n", err)
+		return
+	}
+
+	fmt.Println()
+	fmt.Println("Paste this into your Claude Desktop / Cursor MCP config:")
+	fmt.Println(snippet)
+	fmt.Println()
+	fmt.Printf("For Zed or VS Code, run: gentleman-book-mcp config --client=zed|vscode\n")
+}
+
+// runConfig implements the `config` CLI subcommand, printing the MCP client
+// config snippet for the requested client without going through the
+// interactive wizard.
+func runConfig(args []string) {
+	fs := flag.NewFlagSet("config", flag.ExitOnError)
+	client := fs.String("client", "claude-desktop", "MCP client: claude-desktop, cursor, zed, or vscode")
+	bookPathFlag := fs.String("book-path", "", "Path to the book content directory")
+	fs.Parse(args)
+
+	bookPath := *bookPathFlag
+	if bookPath == "" {
+		bookPath = os.Getenv("BOOK_PATH")
+	}
+	if bookPath == "" {
+		bookPath = defaultBookPath()
+	}
+	bookPath = expandPath(bookPath)
+
+	execPath, err := os.Executable()
+	if err != nil {
+		execPath = "gentleman-book-mcp"
+	}
+
+	snippet, err := mcpClientConfig(*client, execPath, bookPath)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	fmt.Println(snippet)
+}
+
+func writeSetupConfig(path string, cfg setupConfig) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+func prompt(reader *bufio.Reader, label, defaultValue string) string {
+	if defaultValue != "" {
+		fmt.Printf("%s [%s]: ", label, defaultValue)
+	} else {
+		fmt.Printf("%s: ", label)
+	}
+
+	line, _ := reader.ReadString('\n')
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return defaultValue
+	}
+	return line
+}
+
+func defaultBookPath() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, "work", "gentleman-programming-book", "src", "data", "book")
+}
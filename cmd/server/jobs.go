@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Alan-TheGentleman/gentleman-book-mcp/internal/book"
+)
+
+// JobUpdate is free-form progress data reported by a running job. Every job
+// kind (index build, export, link check, summarize) reports what's
+// meaningful to it without a shared progress schema getting in the way.
+type JobUpdate map[string]interface{}
+
+// job is one submitted unit of long-running work, trackable via
+// list_jobs/job_status/cancel_job and, once done, retrievable as a resource
+// at job://{id}/result instead of having to fit its result into a tool
+// response.
+type job struct {
+	mu sync.Mutex
+
+	ID         string
+	Kind       string
+	Status     string // "running", "done", "error", "canceled"
+	Progress   JobUpdate
+	Result     interface{}
+	Errors     []string
+	StartedAt  time.Time
+	FinishedAt time.Time
+	cancel     context.CancelFunc
+}
+
+func (j *job) updateProgress(u JobUpdate) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.Progress = u
+}
+
+func (j *job) finish(result interface{}, err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.Status == "canceled" {
+		return
+	}
+	j.FinishedAt = time.Now()
+	if err != nil {
+		j.Status = "error"
+		j.Errors = append(j.Errors, err.Error())
+		return
+	}
+	j.Result = result
+	j.Status = "done"
+}
+
+// jobSummary is one entry in list_jobs' output.
+type jobSummary struct {
+	ID        string    `json:"id"`
+	Kind      string    `json:"kind"`
+	Status    string    `json:"status"`
+	StartedAt time.Time `json:"startedAt"`
+}
+
+// jobStatusReport is the JSON shape returned by job_status.
+type jobStatusReport struct {
+	SchemaVersion int        `json:"schemaVersion"`
+	ID            string     `json:"id"`
+	Kind          string     `json:"kind"`
+	Status        string     `json:"status"`
+	Progress      JobUpdate  `json:"progress,omitempty"`
+	Errors        []string   `json:"errors,omitempty"`
+	StartedAt     time.Time  `json:"startedAt"`
+	FinishedAt    *time.Time `json:"finishedAt,omitempty"`
+	ResultURI     string     `json:"resultUri,omitempty"`
+}
+
+func (j *job) status() jobStatusReport {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	report := jobStatusReport{
+		SchemaVersion: book.SchemaVersion,
+		ID:            j.ID,
+		Kind:          j.Kind,
+		Status:        j.Status,
+		Progress:      j.Progress,
+		Errors:        j.Errors,
+		StartedAt:     j.StartedAt,
+	}
+	if !j.FinishedAt.IsZero() {
+		report.FinishedAt = &j.FinishedAt
+	}
+	if j.Status == "done" {
+		report.ResultURI = fmt.Sprintf("job://%s/result", j.ID)
+	}
+	return report
+}
+
+// jobQueue is the in-memory registry of submitted jobs, used by every
+// long-running tool (index builds today; exports, link checking, and
+// summarization are expected to submit through it too).
+type jobQueue struct {
+	mu   sync.Mutex
+	jobs map[string]*job
+}
+
+var jobs = &jobQueue{jobs: make(map[string]*job)}
+
+// submit starts run in a goroutine and tracks it as a new job of kind kind.
+// run should watch ctx.Done() to support cancel_job, and call the progress
+// callback it's given to support job_status polling.
+func (q *jobQueue) submit(kind string, run func(ctx context.Context, progress func(JobUpdate)) (interface{}, error)) *job {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	j := &job{
+		ID:        newSessionID(),
+		Kind:      kind,
+		Status:    "running",
+		StartedAt: time.Now(),
+		cancel:    cancel,
+	}
+
+	q.mu.Lock()
+	q.jobs[j.ID] = j
+	q.mu.Unlock()
+
+	go func() {
+		result, err := run(ctx, j.updateProgress)
+		if err != nil && ctx.Err() == context.Canceled {
+			j.mu.Lock()
+			j.Status = "canceled"
+			j.FinishedAt = time.Now()
+			j.mu.Unlock()
+			return
+		}
+		j.finish(result, err)
+	}()
+
+	return j
+}
+
+func (q *jobQueue) get(id string) (*job, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	j, ok := q.jobs[id]
+	return j, ok
+}
+
+func (q *jobQueue) list() []jobSummary {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	summaries := make([]jobSummary, 0, len(q.jobs))
+	for _, j := range q.jobs {
+		j.mu.Lock()
+		summaries = append(summaries, jobSummary{ID: j.ID, Kind: j.Kind, Status: j.Status, StartedAt: j.StartedAt})
+		j.mu.Unlock()
+	}
+	// Canonical order: start time ascending, tie-broken by job ID so the
+	// list is reproducible across runs despite map iteration order.
+	sort.Slice(summaries, func(i, k int) bool {
+		if !summaries[i].StartedAt.Equal(summaries[k].StartedAt) {
+			return summaries[i].StartedAt.Before(summaries[k].StartedAt)
+		}
+		return summaries[i].ID < summaries[k].ID
+	})
+	return summaries
+}
+
+func (q *jobQueue) cancel(id string) error {
+	q.mu.Lock()
+	j, ok := q.jobs[id]
+	q.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("no job found with id %q", id)
+	}
+
+	j.mu.Lock()
+	if j.Status != "running" {
+		status := j.Status
+		j.mu.Unlock()
+		return fmt.Errorf("job %q is already %s", id, status)
+	}
+	j.mu.Unlock()
+
+	j.cancel()
+	return nil
+}
@@ -7,36 +7,139 @@ import (
 	"log"
 	"os"
 	"regexp"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/Alan-TheGentleman/gentleman-book-mcp/internal/book"
 	"github.com/Alan-TheGentleman/gentleman-book-mcp/internal/embeddings"
+	"github.com/Alan-TheGentleman/gentleman-book-mcp/internal/i18n"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
 var parser *book.Parser
 var semanticEngine *embeddings.SemanticEngine
+var prefetchEnabled = true
+var bookPath string
+
+// autoIndexJobID is the job ID of the startup semantic index build kicked
+// off when AUTO_INDEX is set, so semantic_status can surface its progress
+// without the caller having to know a job_id ahead of time. Empty if
+// AUTO_INDEX wasn't set or the build failed to start. Written once from
+// main before the server starts serving requests.
+var autoIndexJobID string
+
+// mcpServer is set once the MCP server is constructed in main, so other
+// modules (e.g. the changelog notifier) can push notifications to connected
+// clients without main having to thread it through every function.
+var mcpServer *server.MCPServer
+
+// degraded tracks whether the server started without a valid book path. In
+// this mode content tools return a clear error instead of the server failing
+// to spawn, and setup_status/set_book_path let a client fix things at runtime.
+var degraded bool
+var degradedReason string
 
 func main() {
 	// Get book path from environment variable or use default
-	bookPath := os.Getenv("BOOK_PATH")
+	bookPath = os.Getenv("BOOK_PATH")
 	if bookPath == "" {
 		// Default path relative to gentleman-programming-book project
-		homeDir, _ := os.UserHomeDir()
-		bookPath = homeDir + "/work/gentleman-programming-book/src/data/book"
+		bookPath = defaultBookPath()
+	}
+	bookPath = expandPath(bookPath)
+
+	transport := "stdio"
+	httpAddr := defaultHTTPAddr
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "doctor":
+			runDoctor(bookPath)
+			return
+		case "init":
+			runInit(os.Args[2:])
+			return
+		case "config":
+			runConfig(os.Args[2:])
+			return
+		case "outline_diff":
+			runOutlineDiff(os.Args[2:])
+			return
+		default:
+			transport, httpAddr = parseServerFlags(os.Args[1:])
+		}
 	}
 
-	// Verify path exists
+	// Verify path exists. A missing path no longer kills the process: an
+	// opaque spawn failure is worse UX than a running server that can explain
+	// what's wrong and be pointed at the right content via set_book_path.
 	if _, err := os.Stat(bookPath); os.IsNotExist(err) {
-		log.Fatalf("Book path does not exist: %s", bookPath)
+		degraded = true
+		degradedReason = fmt.Sprintf(i18n.T("error.book_path_not_exist", "Book path does not exist: %s"), bookPath)
+		log.Printf("Starting in degraded mode: %s", degradedReason)
 	}
 
 	parser = book.NewParser(bookPath)
+	configureSearchAcceleration(parser)
+	loadSlugMode()
+	loadSiteURLConfig()
+	if byQuestion, err := loadFAQConfig(); err != nil {
+		log.Printf("FAQ_CONFIG not loaded: %v", err)
+	} else {
+		faqByQuestion = byQuestion
+	}
+	initPromptTemplates()
+	loadPlugins()
+	stateBackend = loadStateBackend()
+
+	if err := loadTenantConfig(); err != nil {
+		log.Printf("Multi-tenant mode disabled: %v", err)
+	}
+	if err := loadBookManifest(); err != nil {
+		log.Printf("Multi-book manifest disabled: %v", err)
+	}
+
+	if overrides, err := loadPrerequisiteOverrides(); err != nil {
+		log.Printf("Prerequisite overrides disabled: %v", err)
+	} else {
+		prerequisiteOverrides = overrides
+	}
+
+	if overrides, err := loadReviewQuestionOverrides(); err != nil {
+		log.Printf("Review question overrides disabled: %v", err)
+	} else {
+		reviewQuestionOverrides = overrides
+	}
+
+	if v := os.Getenv("PREFETCH_ADJACENT_SECTIONS"); v == "false" || v == "0" {
+		prefetchEnabled = false
+	}
+	if embeddings.LowMemoryModeEnabled() {
+		// LOW_MEMORY_PROFILE: stay lazy, don't warm the section cache ahead
+		// of what was actually requested.
+		prefetchEnabled = false
+	}
 
 	// Initialize semantic engine if OpenAI API key or Ollama is available
 	initSemanticEngine()
 
+	if v := os.Getenv("AUTO_INDEX"); semanticEngine != nil && (v == "true" || v == "1") {
+		defaultTenant, _ := tenants.get(defaultTenantID)
+		j, chunkCount, err := submitIndexBuildJob(defaultTenant, "all")
+		if err != nil {
+			log.Printf("AUTO_INDEX: failed to start startup index build: %v", err)
+		} else {
+			autoIndexJobID = j.ID
+			log.Printf("AUTO_INDEX: building semantic index at startup (%d chunks, job %s)", chunkCount, j.ID)
+		}
+	}
+
+	if err := loadScheduleConfig(); err != nil {
+		log.Printf("Scheduled tasks disabled: %v", err)
+	}
+	startScheduler(context.Background())
+
 	// Create MCP server
 	s := server.NewMCPServer(
 		"Gentleman Programming Book",
@@ -44,7 +147,10 @@ func main() {
 		server.WithToolCapabilities(true),
 		server.WithResourceCapabilities(true, true),
 		server.WithPromptCapabilities(true),
+		server.WithHooks(registerTelemetryHook()),
+		server.WithToolHandlerMiddleware(toolConcurrencyMiddleware()),
 	)
+	mcpServer = s
 
 	// ============================================
 	// LEVEL 1: BASIC TOOLS
@@ -53,11 +159,17 @@ func main() {
 	// Tool: list_chapters
 	s.AddTool(
 		mcp.NewTool("list_chapters",
-			mcp.WithDescription("List all chapters in the Gentleman Programming Book. Returns chapter metadata including ID, name, order, and sections."),
+			mcp.WithDescription(i18n.T("tool.list_chapters.description", "List all chapters in the Gentleman Programming Book. Returns chapter metadata including ID, name, order, and sections.")),
 			mcp.WithString("locale",
 				mcp.Description("Language locale: 'es' for Spanish, 'en' for English"),
 				mcp.DefaultString("es"),
 			),
+			mcp.WithString("tenant_id",
+				mcp.Description("In multi-tenant deployments, which tenant's book root to use (see TENANT_CONFIG)"),
+			),
+			mcp.WithString("book_id",
+				mcp.Description("In multi-book deployments, which book to use (see BOOK_MANIFEST). Alias for tenant_id."),
+			),
 		),
 		handleListChapters,
 	)
@@ -65,7 +177,7 @@ func main() {
 	// Tool: read_chapter
 	s.AddTool(
 		mcp.NewTool("read_chapter",
-			mcp.WithDescription("Read a specific chapter from the book. Can read the entire chapter or a specific section."),
+			mcp.WithDescription(i18n.T("tool.read_chapter.description", "Read a specific chapter from the book. Can read the entire chapter or a specific section.")),
 			mcp.WithString("chapter_id",
 				mcp.Required(),
 				mcp.Description("The chapter ID (e.g., 'clean-agile', 'hexagonal-architecture')"),
@@ -73,18 +185,64 @@ func main() {
 			mcp.WithString("section_id",
 				mcp.Description("Optional section tag ID to read only that section"),
 			),
+			mcp.WithNumber("start_line",
+				mcp.Description("Optional 1-based line number to start from (full chapter only, e.g. to pull the lines around a search_book hit)"),
+			),
+			mcp.WithNumber("end_line",
+				mcp.Description("Optional 1-based line number (inclusive) to stop at (full chapter only)"),
+			),
 			mcp.WithString("locale",
 				mcp.Description("Language locale: 'es' for Spanish, 'en' for English"),
 				mcp.DefaultString("es"),
 			),
+			mcp.WithString("tenant_id",
+				mcp.Description("In multi-tenant deployments, which tenant's book root to use (see TENANT_CONFIG)"),
+			),
+			mcp.WithString("book_id",
+				mcp.Description("In multi-book deployments, which book to use (see BOOK_MANIFEST). Alias for tenant_id."),
+			),
 		),
 		handleReadChapter,
 	)
 
+	// Tool: search
+	s.AddTool(
+		mcp.NewTool("search",
+			mcp.WithDescription("Search the book, automatically picking the best backend available: semantic search if an index has been built, otherwise whichever keyword backend SEARCH_BACKEND selects (fts5/bleve), or a plain keyword scan otherwise. Reports which mode it used. Prefer this over search_book/semantic_search unless a caller specifically needs one backend."),
+			mcp.WithString("query",
+				mcp.Required(),
+				mcp.Description("Search query"),
+			),
+			mcp.WithString("locale",
+				mcp.Description("Language locale: 'es' for Spanish, 'en' for English"),
+				mcp.DefaultString("es"),
+			),
+			mcp.WithNumber("top_k",
+				mcp.Description("Max results when the semantic backend is used (default: 5)"),
+			),
+			mcp.WithBoolean("explain",
+				mcp.Description("If true, annotate each result with why it matched"),
+				mcp.DefaultBool(false),
+			),
+			mcp.WithString("tenant_id",
+				mcp.Description("In multi-tenant deployments, which tenant's book to search (see TENANT_CONFIG)"),
+			),
+			mcp.WithString("book_id",
+				mcp.Description("In multi-book deployments, which book to search (see BOOK_MANIFEST). Alias for tenant_id."),
+			),
+			mcp.WithString("tone",
+				mcp.Description("Comma-separated tone labels to keep (advice, anecdote, warning, checklist). Only applied when a keyword backend is used."),
+			),
+		),
+		handleSearch,
+	)
+
 	// Tool: search_book
+	// Deprecated: prefer "search", which picks this or semantic_search
+	// automatically and reports which one it used.
 	s.AddTool(
 		mcp.NewTool("search_book",
-			mcp.WithDescription("Search for content in the book using keywords. Returns relevant snippets with chapter and section information."),
+			mcp.WithDescription(i18n.T("tool.search_book.description", "Search for content in the book using keywords. Returns relevant snippets with chapter and section information.")+" Deprecated: prefer the \"search\" tool, which picks this or semantic_search automatically."),
 			mcp.WithString("query",
 				mcp.Required(),
 				mcp.Description("Search query (keywords to find in the book)"),
@@ -93,10 +251,111 @@ func main() {
 				mcp.Description("Language locale: 'es' for Spanish, 'en' for English"),
 				mcp.DefaultString("es"),
 			),
+			mcp.WithBoolean("explain",
+				mcp.Description("If true, annotate each result with the matched terms that produced its score"),
+				mcp.DefaultBool(false),
+			),
+			mcp.WithString("tenant_id",
+				mcp.Description("In multi-tenant deployments, which tenant's book root to use (see TENANT_CONFIG)"),
+			),
+			mcp.WithString("book_id",
+				mcp.Description("In multi-book deployments, which book to use (see BOOK_MANIFEST). Alias for tenant_id."),
+			),
+			mcp.WithString("tone",
+				mcp.Description("Comma-separated tone labels to keep (advice, anecdote, warning, checklist), e.g. 'checklist' to get only actionable checklists"),
+			),
 		),
 		handleSearchBook,
 	)
 
+	// Tool: setup_status
+	s.AddTool(
+		mcp.NewTool("setup_status",
+			mcp.WithDescription(i18n.T("tool.setup_status.description", "Report whether the server is running in degraded mode (e.g. BOOK_PATH missing) and what's needed to fix it.")),
+		),
+		handleSetupStatus,
+	)
+
+	// Tool: set_book_path
+	s.AddTool(
+		mcp.NewTool("set_book_path",
+			mcp.WithDescription("Point the running server at a book content directory without restarting it, exiting degraded mode if the path is valid."),
+			mcp.WithString("book_path",
+				mcp.Required(),
+				mcp.Description("Absolute path to the book content directory (containing 'es'/'en' locale subfolders)"),
+			),
+		),
+		handleSetBookPath,
+	)
+
+	// Tool: server_info
+	s.AddTool(
+		mcp.NewTool("server_info",
+			mcp.WithDescription(i18n.T("tool.server_info.description", "Report server version, git commit, book content commit, configured embedding providers, and enabled features. Useful environment data for bug reports.")),
+		),
+		handleServerInfo,
+	)
+
+	// Tool: get_client_config
+	s.AddTool(
+		mcp.NewTool("get_client_config",
+			mcp.WithDescription("Get a ready-to-paste MCP client configuration snippet (command, args, env) for this server, for Claude Desktop, Cursor, Zed, or VS Code."),
+			mcp.WithString("client",
+				mcp.Description("Target MCP client: 'claude-desktop', 'cursor', 'zed', or 'vscode'"),
+				mcp.DefaultString("claude-desktop"),
+			),
+		),
+		handleGetClientConfig,
+	)
+
+	// Tool: health
+	s.AddTool(
+		mcp.NewTool("health",
+			mcp.WithDescription("Report server health: degraded-mode status and the last-run outcome of every scheduled background task (SCHEDULE_CONFIG)."),
+		),
+		handleHealth,
+	)
+
+	// Tool: generate_digest
+	s.AddTool(
+		mcp.NewTool("generate_digest",
+			mcp.WithDescription("Compose a short daily/weekly digest (one concept, one quote, one exercise) from a chapter the caller hasn't covered yet this session. Suitable for posting to a Discord/Slack bot."),
+			mcp.WithString("locale",
+				mcp.Description("Language locale: 'es' for Spanish, 'en' for English"),
+				mcp.DefaultString("es"),
+			),
+			mcp.WithString("period",
+				mcp.Description("'daily' or 'weekly'"),
+				mcp.DefaultString("daily"),
+			),
+		),
+		handleGenerateDigest,
+	)
+
+	// Tool: publish_digest
+	s.AddTool(
+		mcp.NewTool("publish_digest",
+			mcp.WithDescription("Generate a digest and post it to the configured Slack or Discord webhook (PUBLISH_WEBHOOK_URL, PUBLISH_WEBHOOK_KIND). Call on demand, or from a scheduled job/cron outside the server for a recurring digest."),
+			mcp.WithString("locale",
+				mcp.Description("Language locale: 'es' for Spanish, 'en' for English"),
+				mcp.DefaultString("es"),
+			),
+			mcp.WithString("period",
+				mcp.Description("'daily' or 'weekly'"),
+				mcp.DefaultString("daily"),
+			),
+		),
+		handlePublishDigest,
+	)
+
+	// Tool: telemetry_status
+	s.AddTool(
+		mcp.NewTool("telemetry_status",
+			mcp.WithDescription("Show exactly what anonymous usage telemetry would be sent: tool call counts, embedding provider type, and index size bucket. Telemetry is opt-in (ENABLE_TELEMETRY=true) and never includes book content, file paths, or tool arguments."),
+		),
+		handleTelemetryStatus,
+	)
+
 	// Tool: get_book_index
 	s.AddTool(
 		mcp.NewTool("get_book_index",
@@ -109,641 +368,2897 @@ func main() {
 		handleGetBookIndex,
 	)
 
-	// ============================================
-	// LEVEL 3: SEMANTIC SEARCH
-	// ============================================
+	// Tool: get_prerequisites
+	s.AddTool(
+		mcp.NewTool("get_prerequisites",
+			mcp.WithDescription("Get the chapter IDs that should be read before a given chapter, declared via frontmatter and/or PREREQUISITES_CONFIG."),
+			mcp.WithString("chapter_id",
+				mcp.Required(),
+				mcp.Description("The chapter ID to look up prerequisites for"),
+			),
+			mcp.WithString("locale",
+				mcp.Description("Language locale: 'es' for Spanish, 'en' for English"),
+				mcp.DefaultString("es"),
+			),
+		),
+		handleGetPrerequisites,
+	)
 
-	// Tool: semantic_search (only available if embeddings are configured)
+	// Tool: get_learning_order
 	s.AddTool(
-		mcp.NewTool("semantic_search",
-			mcp.WithDescription("Search the book using semantic similarity (AI-powered). More accurate than keyword search. Requires OPENAI_API_KEY or Ollama running locally."),
-			mcp.WithString("query",
+		mcp.NewTool("get_learning_order",
+			mcp.WithDescription("Get all chapters in a locale topologically sorted so no chapter appears before its prerequisites."),
+			mcp.WithString("locale",
+				mcp.Description("Language locale: 'es' for Spanish, 'en' for English"),
+				mcp.DefaultString("es"),
+			),
+		),
+		handleGetLearningOrder,
+	)
+
+	// Tool: check_readiness
+	s.AddTool(
+		mcp.NewTool("check_readiness",
+			mcp.WithDescription("Check whether a reader has actually covered a chapter's prerequisites, using this session's coverage data (read_chapter/search_book/semantic_search hits) rather than just the prerequisite declaration."),
+			mcp.WithString("chapter_id",
 				mcp.Required(),
-				mcp.Description("Natural language query to search for"),
+				mcp.Description("The chapter ID to check readiness for"),
 			),
 			mcp.WithString("locale",
 				mcp.Description("Language locale: 'es' for Spanish, 'en' for English"),
 				mcp.DefaultString("es"),
 			),
-			mcp.WithNumber("top_k",
-				mcp.Description("Number of results to return (default: 5)"),
+			mcp.WithString("book_id",
+				mcp.Description("In multi-book deployments, which book's coverage data to check against (see BOOK_MANIFEST). Alias for tenant_id."),
 			),
 		),
-		handleSemanticSearch,
+		handleCheckReadiness,
 	)
 
-	// Tool: build_semantic_index
+	// Tool: get_checklists
 	s.AddTool(
-		mcp.NewTool("build_semantic_index",
-			mcp.WithDescription("Build or rebuild the semantic search index. Required before using semantic_search. Takes a few minutes."),
+		mcp.NewTool("get_checklists",
+			mcp.WithDescription("Extract actionable checklist items (e.g. code review checklist, definition of done) from the book, with their chapter/section context, ready to turn into a task list."),
+			mcp.WithString("chapter_id",
+				mcp.Description("Restrict extraction to a single chapter ID; omit to scan the whole book"),
+			),
 			mcp.WithString("locale",
-				mcp.Description("Language locale to index: 'es', 'en', or 'all'"),
-				mcp.DefaultString("all"),
+				mcp.Description("Language locale: 'es' for Spanish, 'en' for English"),
+				mcp.DefaultString("es"),
 			),
 		),
-		handleBuildSemanticIndex,
+		handleGetChecklists,
 	)
 
-	// Tool: semantic_status
+	// Tool: list_exercises
 	s.AddTool(
-		mcp.NewTool("semantic_status",
-			mcp.WithDescription("Check the status of the semantic search engine (availability, index status, chunk count)."),
+		mcp.NewTool("list_exercises",
+			mcp.WithDescription("List practice exercises, extracted from frontmatter and exercise-like sections in the book, with each one's completion status from complete_exercise."),
+			mcp.WithString("chapter_id",
+				mcp.Description("Restrict extraction to a single chapter ID; omit to scan the whole book"),
+			),
+			mcp.WithString("locale",
+				mcp.Description("Language locale: 'es' for Spanish, 'en' for English"),
+				mcp.DefaultString("es"),
+			),
 		),
-		handleSemanticStatus,
+		handleListExercises,
 	)
 
-	// ============================================
-	// LEVEL 2: DYNAMIC RESOURCES
-	// ============================================
+	// Tool: get_exercise
+	s.AddTool(
+		mcp.NewTool("get_exercise",
+			mcp.WithDescription("Get a single exercise by ID (as returned by list_exercises), with its completion status."),
+			mcp.WithString("exercise_id",
+				mcp.Required(),
+				mcp.Description("The exercise ID, as returned by list_exercises"),
+			),
+			mcp.WithString("locale",
+				mcp.Description("Language locale: 'es' for Spanish, 'en' for English"),
+				mcp.DefaultString("es"),
+			),
+		),
+		handleGetExercise,
+	)
 
-	// Resource: Book index
-	s.AddResource(
-		mcp.NewResource(
-			"book://index/es",
-			"Book Index (Spanish)",
-			mcp.WithResourceDescription("Complete table of contents for the Spanish version"),
-			mcp.WithMIMEType("application/json"),
+	// Tool: complete_exercise
+	s.AddTool(
+		mcp.NewTool("complete_exercise",
+			mcp.WithDescription("Mark an exercise as completed. Progress is in-memory and process-local; it does not survive a server restart."),
+			mcp.WithString("exercise_id",
+				mcp.Required(),
+				mcp.Description("The exercise ID, as returned by list_exercises"),
+			),
 		),
-		handleBookIndexResource,
+		handleCompleteExercise,
 	)
 
-	s.AddResource(
-		mcp.NewResource(
-			"book://index/en",
-			"Book Index (English)",
-			mcp.WithResourceDescription("Complete table of contents for the English version"),
-			mcp.WithMIMEType("application/json"),
+	// Tool: tutorial_next
+	s.AddTool(
+		mcp.NewTool("tutorial_next",
+			mcp.WithDescription("Advance a tutorial (started via the tutorial prompt) to its next step."),
+			mcp.WithString("tutorial_id",
+				mcp.Required(),
+				mcp.Description("The tutorial ID returned by the tutorial prompt"),
+			),
 		),
-		handleBookIndexResource,
+		handleTutorialNext,
 	)
 
-	// ============================================
-	// LEVEL 2: PREDEFINED PROMPTS
-	// ============================================
+	// Tool: resume_session
+	s.AddTool(
+		mcp.NewTool("resume_session",
+			mcp.WithDescription("Resume a previous session by ID (carrying over its default locale/tenant), or start a new one. Lets a client that reconnects after a dropped network connection pick up where it left off instead of re-sending its preferences. Session state is in-memory and process-local; it does not survive a server restart."),
+			mcp.WithString("session_id",
+				mcp.Description("A session ID previously returned by resume_session; omit to start a new session"),
+			),
+			mcp.WithString("locale",
+				mcp.Description("Default locale to remember for this session: 'es' for Spanish, 'en' for English"),
+			),
+			mcp.WithString("tenant_id",
+				mcp.Description("In multi-tenant deployments, the tenant to remember for this session"),
+			),
+		),
+		handleResumeSession,
+	)
 
-	// Prompt: explain_concept
-	s.AddPrompt(
-		mcp.NewPrompt("explain_concept",
-			mcp.WithPromptDescription("Ask the AI to explain a concept from the Gentleman Programming Book"),
-			mcp.WithArgument("concept",
-				mcp.ArgumentDescription("The concept to explain (e.g., 'hexagonal architecture', 'clean architecture', 'TDD')"),
+	// Tool: get_coverage_report
+	s.AddTool(
+		mcp.NewTool("get_coverage_report",
+			mcp.WithDescription("Report which chapters this server has served to clients so far this session (via read_chapter, search_book, or semantic_search), and which chapters have never been touched."),
+			mcp.WithString("locale",
+				mcp.Description("Language locale to compute the full chapter list against: 'es' for Spanish, 'en' for English"),
+				mcp.DefaultString("es"),
 			),
-			mcp.WithArgument("locale",
-				mcp.ArgumentDescription("Language: 'es' or 'en'"),
+			mcp.WithString("book_id",
+				mcp.Description("In multi-book deployments, which book's coverage data to report (see BOOK_MANIFEST). Alias for tenant_id."),
 			),
 		),
-		handleExplainConceptPrompt,
+		handleGetCoverageReport,
 	)
 
-	// Prompt: compare_patterns
-	s.AddPrompt(
-		mcp.NewPrompt("compare_patterns",
-			mcp.WithPromptDescription("Compare two architectural patterns or concepts from the book"),
-			mcp.WithArgument("pattern_a",
-				mcp.ArgumentDescription("First pattern to compare"),
+	// Tool: get_zero_result_queries
+	s.AddTool(
+		mcp.NewTool("get_zero_result_queries",
+			mcp.WithDescription("List the search_book queries that have returned no results this session, most frequent first. Use these to curate FAQ_CONFIG entries for recurring questions the book doesn't directly answer."),
+		),
+		handleGetZeroResultQueries,
+	)
+
+	// Tool: get_review_questions
+	s.AddTool(
+		mcp.NewTool("get_review_questions",
+			mcp.WithDescription("Get self-check review questions for a chapter, each pointing at the section that answers it. Sourced from REVIEW_QUESTIONS_CONFIG or frontmatter if curated, otherwise generated from the chapter's headings."),
+			mcp.WithString("chapter_id",
+				mcp.Required(),
+				mcp.Description("The chapter ID to get review questions for"),
 			),
-			mcp.WithArgument("pattern_b",
-				mcp.ArgumentDescription("Second pattern to compare"),
+			mcp.WithString("locale",
+				mcp.Description("Language locale: 'es' for Spanish, 'en' for English"),
+				mcp.DefaultString("es"),
 			),
 		),
-		handleComparePatternsPrompt,
+		handleGetReviewQuestions,
 	)
 
-	// Prompt: summarize_chapter
-	s.AddPrompt(
-		mcp.NewPrompt("summarize_chapter",
-			mcp.WithPromptDescription("Get a summary of a specific chapter from the book"),
-			mcp.WithArgument("chapter_id",
-				mcp.ArgumentDescription("The chapter ID to summarize"),
+	// Tool: find_quote
+	s.AddTool(
+		mcp.NewTool("find_quote",
+			mcp.WithDescription("Given an approximate or misremembered quote, locate the closest exact passage in the book (fuzzy alignment) and return the verbatim text with chapter/section/line attribution."),
+			mcp.WithString("quote",
+				mcp.Required(),
+				mcp.Description("The approximate quote to locate"),
 			),
-			mcp.WithArgument("locale",
-				mcp.ArgumentDescription("Language: 'es' or 'en'"),
+			mcp.WithString("locale",
+				mcp.Description("Language locale: 'es' for Spanish, 'en' for English"),
+				mcp.DefaultString("es"),
 			),
 		),
-		handleSummarizeChapterPrompt,
+		handleFindQuote,
 	)
 
-	// Start server via stdio
-	log.Println("Starting Gentleman Book MCP Server...")
-	if err := server.ServeStdio(s); err != nil {
-		log.Fatalf("Server error: %v", err)
-	}
-}
+	// Tool: verify_claim
+	s.AddTool(
+		mcp.NewTool("verify_claim",
+			mcp.WithDescription("Check a statement allegedly from the book against the most relevant passages, returning a supported/contradicted/not_found verdict with evidence excerpts. The verdict is a keyword-overlap heuristic, meant to catch hallucinated or misattributed claims, not a substitute for reading the evidence."),
+			mcp.WithString("claim",
+				mcp.Required(),
+				mcp.Description("The statement to verify against the book"),
+			),
+			mcp.WithString("locale",
+				mcp.Description("Language locale: 'es' for Spanish, 'en' for English"),
+				mcp.DefaultString("es"),
+			),
+		),
+		handleVerifyClaim,
+	)
 
-// ============================================
-// TOOL HANDLERS - LEVEL 1
-// ============================================
+	// ============================================
+	// LEVEL 3: SEMANTIC SEARCH
+	// ============================================
 
-func handleListChapters(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Tool: semantic_search (only available if embeddings are configured)
+	// Deprecated: prefer "search", which picks this or search_book
+	// automatically and reports which one it used.
+	s.AddTool(
+		mcp.NewTool("semantic_search",
+			mcp.WithDescription("Search the book using semantic similarity (AI-powered). More accurate than keyword search. Requires OPENAI_API_KEY or Ollama running locally. Deprecated: prefer the \"search\" tool, which picks this or search_book automatically."),
+			mcp.WithString("query",
+				mcp.Required(),
+				mcp.Description("Natural language query to search for"),
+			),
+			mcp.WithString("locale",
+				mcp.Description("Language locale: 'es' for Spanish, 'en' for English"),
+				mcp.DefaultString("es"),
+			),
+			mcp.WithNumber("top_k",
+				mcp.Description("Number of results to return (default: 5)"),
+			),
+			mcp.WithBoolean("explain",
+				mcp.Description("If true, annotate each result with the raw cosine score that produced it"),
+				mcp.DefaultBool(false),
+			),
+			mcp.WithString("tenant_id",
+				mcp.Description("In multi-tenant deployments, which tenant's semantic index to search (see TENANT_CONFIG)"),
+			),
+			mcp.WithString("book_id",
+				mcp.Description("In multi-book deployments, which book's semantic index to search (see BOOK_MANIFEST). Alias for tenant_id. Non-default books need their own index built first."),
+			),
+		),
+		handleSemanticSearch,
+	)
+
+	// Tool: hybrid_search
+	s.AddTool(
+		mcp.NewTool("hybrid_search",
+			mcp.WithDescription("Search the book using both keyword and semantic search, fusing the two rankings with reciprocal rank fusion. Catches both exact identifiers (keyword search's strength) and paraphrases (semantic search's strength). Falls back to keyword-only ranking if no semantic index is built."),
+			mcp.WithString("query",
+				mcp.Required(),
+				mcp.Description("Search query"),
+			),
+			mcp.WithString("locale",
+				mcp.Description("Language locale: 'es' for Spanish, 'en' for English"),
+				mcp.DefaultString("es"),
+			),
+			mcp.WithNumber("top_k",
+				mcp.Description("Number of fused results to return (default: 5)"),
+			),
+			mcp.WithNumber("keyword_weight",
+				mcp.Description("Weight given to the keyword ranking in the fusion (default: 1.0)"),
+			),
+			mcp.WithNumber("semantic_weight",
+				mcp.Description("Weight given to the semantic ranking in the fusion (default: 1.0)"),
+			),
+			mcp.WithString("tenant_id",
+				mcp.Description("In multi-tenant deployments, which tenant's book to search (see TENANT_CONFIG)"),
+			),
+			mcp.WithString("book_id",
+				mcp.Description("In multi-book deployments, which book to search (see BOOK_MANIFEST). Alias for tenant_id."),
+			),
+		),
+		handleHybridSearch,
+	)
+
+	// Tool: build_semantic_index
+	s.AddTool(
+		mcp.NewTool("build_semantic_index",
+			mcp.WithDescription("Build or rebuild the semantic search index. Required before using semantic_search. Takes a few minutes."),
+			mcp.WithString("locale",
+				mcp.Description("Language locale to index: 'es', 'en', or 'all'"),
+				mcp.DefaultString("all"),
+			),
+		),
+		handleBuildSemanticIndex,
+	)
+
+	// Tool: update_semantic_index
+	s.AddTool(
+		mcp.NewTool("update_semantic_index",
+			mcp.WithDescription("Incrementally update the semantic search index: only chapters whose content has changed since the last build or update are re-embedded, leaving the rest of the index untouched. Much cheaper than build_semantic_index after a small edit. Requires an index to already exist."),
+			mcp.WithString("locale",
+				mcp.Description("Language locale to update: 'es', 'en', or 'all'"),
+				mcp.DefaultString("all"),
+			),
+		),
+		handleUpdateSemanticIndex,
+	)
+
+	// Tool: job_status
+	s.AddTool(
+		mcp.NewTool("job_status",
+			mcp.WithDescription("Check the progress of a background job (e.g. one started by build_semantic_index): its status, progress data, and any errors. Once done, its result is available as a resource at the returned resultUri."),
+			mcp.WithString("job_id",
+				mcp.Required(),
+				mcp.Description("The job ID returned when the job was started"),
+			),
+		),
+		handleJobStatus,
+	)
+
+	// Tool: list_jobs
+	s.AddTool(
+		mcp.NewTool("list_jobs",
+			mcp.WithDescription("List all background jobs known to this server (running, done, errored, or canceled) with their status."),
+		),
+		handleListJobs,
+	)
+
+	// Tool: cancel_job
+	s.AddTool(
+		mcp.NewTool("cancel_job",
+			mcp.WithDescription("Cancel a running background job."),
+			mcp.WithString("job_id",
+				mcp.Required(),
+				mcp.Description("The job ID to cancel"),
+			),
+		),
+		handleCancelJob,
+	)
+
+	// Resource template: job results
+	s.AddResourceTemplate(
+		mcp.NewResourceTemplate(
+			"job://{id}/result",
+			"Job Result",
+			mcp.WithTemplateDescription("The result of a completed background job"),
+			mcp.WithTemplateMIMEType("application/json"),
+		),
+		handleJobResultResource,
+	)
+
+	// Tool: semantic_status
+	s.AddTool(
+		mcp.NewTool("semantic_status",
+			mcp.WithDescription("Check the status of the semantic search engine (availability, index status, chunk count)."),
+		),
+		handleSemanticStatus,
+	)
+
+	// Tool: index_quality_report
+	s.AddTool(
+		mcp.NewTool("index_quality_report",
+			mcp.WithDescription("Run diagnostics on the built semantic index: average chunk length and embedding norm per chapter, and chunks whose nearest neighbor is suspiciously distant (likely JSX/MDX noise). Use this to guide chunking fixes."),
+		),
+		handleIndexQualityReport,
+	)
+
+	// Tool: export_index
+	s.AddTool(
+		mcp.NewTool("export_index",
+			mcp.WithDescription("Export the built semantic index (chunks and their embeddings) so it can be loaded into an external RAG stack. Supported formats: jsonl (default), csv, llamaindex. parquet is recognized but not implemented: the result comes back wrapped in a JSON object with a 'warning' field and jsonl 'data' instead of plain export text."),
+			mcp.WithString("locale", mcp.Description("Only export chunks for this locale. Omit to export every indexed locale.")),
+			mcp.WithString("format", mcp.Description("Export format: jsonl, csv, llamaindex, or parquet. parquet falls back to jsonl, with the result wrapped to flag that. Defaults to jsonl.")),
+		),
+		handleExportIndex,
+	)
+
+	// Tool: import_index
+	s.AddTool(
+		mcp.NewTool("import_index",
+			mcp.WithDescription("Import a pre-built chunks+embeddings file (in the format export_index produces) directly into the vector store, skipping re-embedding. Lets CI build the index once and every developer's local server just load it."),
+			mcp.WithString("data", mcp.Required(), mcp.Description("The exported index content (jsonl or csv) to load.")),
+			mcp.WithString("format", mcp.Description("Format of data: jsonl (default) or csv.")),
+		),
+		handleImportIndex,
+	)
+
+	// Tool: save_index_to_disk
+	s.AddTool(
+		mcp.NewTool("save_index_to_disk",
+			mcp.WithDescription("Persist the built semantic index to a local file (JSONL, the same shape export_index produces). Encrypted with AES-GCM if INDEX_ENCRYPTION_KEY is set, so a private handbook's index isn't left world-readable on disk."),
+			mcp.WithString("path", mcp.Required(), mcp.Description("File path to write the index to.")),
+		),
+		handleSaveIndexToDisk,
+	)
+
+	// Tool: load_index_from_disk
+	s.AddTool(
+		mcp.NewTool("load_index_from_disk",
+			mcp.WithDescription("Load a semantic index previously written by save_index_to_disk, decrypting it first if INDEX_ENCRYPTION_KEY is set."),
+			mcp.WithString("path", mcp.Required(), mcp.Description("File path to read the index from.")),
+		),
+		handleLoadIndexFromDisk,
+	)
+
+	// Tool: fetch_prebuilt_index
+	s.AddTool(
+		mcp.NewTool("fetch_prebuilt_index",
+			mcp.WithDescription("Download a published pre-built semantic index (chunks+embeddings, checksummed) from a manifest URL and load it directly, skipping local re-embedding entirely. Meant for end users with no embedding provider configured and no patience to build an index themselves."),
+			mcp.WithString("manifest_url", mcp.Description("URL of the manifest JSON (see PREBUILT_INDEX_MANIFEST_URL). Overrides the env var if set.")),
+			mcp.WithBoolean("force", mcp.Description("Load the index even if its manifest's bookCommit or embeddingModel doesn't match this server. Defaults to false.")),
+		),
+		handleFetchPrebuiltIndex,
+	)
+
+	// ============================================
+	// LEVEL 4: CUSTOM TOOLS (operator-defined, via CUSTOM_TOOLS_CONFIG)
+	// ============================================
+
+	registerCustomTools(s)
+
+	// ============================================
+	// LEVEL 2: DYNAMIC RESOURCES
+	// ============================================
+
+	// Resource: Book index
+	s.AddResource(
+		mcp.NewResource(
+			"book://index/es",
+			"Book Index (Spanish)",
+			mcp.WithResourceDescription("Complete table of contents for the Spanish version"),
+			mcp.WithMIMEType("application/json"),
+		),
+		handleBookIndexResource,
+	)
+
+	s.AddResource(
+		mcp.NewResource(
+			"book://index/en",
+			"Book Index (English)",
+			mcp.WithResourceDescription("Complete table of contents for the English version"),
+			mcp.WithMIMEType("application/json"),
+		),
+		handleBookIndexResource,
+	)
+
+	// Resource: Changelog of detected upstream content changes
+	s.AddResource(
+		mcp.NewResource(
+			"book://changelog",
+			"Book Changelog",
+			mcp.WithResourceDescription("Detected chapter/section changes from the most recent refresh_book runs, most recent first"),
+			mcp.WithMIMEType("application/json"),
+		),
+		handleChangelogResource,
+	)
+
+	// Resource: Chapter/section access statistics
+	s.AddResource(
+		mcp.NewResource(
+			"book://stats/usage",
+			"Book Usage Statistics",
+			mcp.WithResourceDescription("Which chapters and sections have actually been read or searched this session, most-used first"),
+			mcp.WithMIMEType("application/json"),
+		),
+		handleUsageStatsResource,
+	)
+
+	// Resource template: Chapter content, per locale, so MCP clients can
+	// attach a chapter directly without calling read_chapter
+	s.AddResourceTemplate(
+		mcp.NewResourceTemplate(
+			"book://chapter/{locale}/{id}",
+			"Book Chapter",
+			mcp.WithTemplateDescription("A single chapter's full content, for clients that want to attach it directly as context"),
+			mcp.WithTemplateMIMEType("application/json"),
+		),
+		handleChapterResource,
+	)
+
+	// Resource template: Section content, so clients can reference a
+	// specific section as context without pulling a whole chapter
+	s.AddResourceTemplate(
+		mcp.NewResourceTemplate(
+			"book://chapter/{chapterId}/section/{tagId}",
+			"Book Section",
+			mcp.WithTemplateDescription("A single section's content, addressed by chapter ID and section tagId"),
+			mcp.WithTemplateMIMEType("text/plain"),
+		),
+		handleSectionResource,
+	)
+
+	// Resource template: Changelog built from git history, per locale
+	s.AddResourceTemplate(
+		mcp.NewResourceTemplate(
+			"book://changelog/{locale}",
+			"Book Changelog (from git history)",
+			mcp.WithTemplateDescription("Chapter changes derived from the book repo's git log: commit, date, chapter, and diff stats"),
+			mcp.WithTemplateMIMEType("application/json"),
+		),
+		handleGitChangelogResource,
+	)
+
+	// Tool: get_recent_changes
+	s.AddTool(
+		mcp.NewTool("get_recent_changes",
+			mcp.WithDescription("List chapter changes from the book repo's git history (commit, date, chapter, diff stats), optionally filtered to commits since a given date."),
+			mcp.WithString("locale",
+				mcp.Description("Language locale: 'es' for Spanish, 'en' for English"),
+				mcp.DefaultString("es"),
+			),
+			mcp.WithString("since",
+				mcp.Description("Only include commits at or after this date (YYYY-MM-DD or RFC3339). Omit for full history."),
+			),
+		),
+		handleGetRecentChanges,
+	)
+
+	// Tool: get_locale_parity_report
+	s.AddTool(
+		mcp.NewTool("get_locale_parity_report",
+			mcp.WithDescription("Compare chapter coverage and content between two locales: chapters missing from either side, and chapters present on both sides whose content is a near-duplicate (simhash similarity) of the other, which in practice almost always means a translation that never happened."),
+			mcp.WithString("locale_a",
+				mcp.Description("First locale to compare: 'es' for Spanish, 'en' for English"),
+				mcp.DefaultString("es"),
+			),
+			mcp.WithString("locale_b",
+				mcp.Description("Second locale to compare: 'es' for Spanish, 'en' for English"),
+				mcp.DefaultString("en"),
+			),
+		),
+		handleGetLocaleParityReport,
+	)
+
+	// Tool: get_translation_status
+	s.AddTool(
+		mcp.NewTool("get_translation_status",
+			mcp.WithDescription("Report each section's translation status (translated/outdated/missing) from source_locale into target_locale, derived from the locale parity diff and each chapter file's last git commit, so the community translation effort can coordinate through the server."),
+			mcp.WithString("source_locale",
+				mcp.Description("Locale to translate from: 'es' for Spanish, 'en' for English"),
+				mcp.DefaultString("es"),
+			),
+			mcp.WithString("target_locale",
+				mcp.Description("Locale to translate into: 'es' for Spanish, 'en' for English"),
+				mcp.DefaultString("en"),
+			),
+		),
+		handleGetTranslationStatus,
+	)
+
+	// Tool: check_style
+	s.AddTool(
+		mcp.NewTool("check_style",
+			mcp.WithDescription("Lint a draft section against a style profile extracted from the rest of the book (heading levels, code fence language tagging, admonition markers), for contributors writing new chapters."),
+			mcp.WithString("draft",
+				mcp.Required(),
+				mcp.Description("The draft section's Markdown content to lint"),
+			),
+			mcp.WithString("locale",
+				mcp.Description("Locale to derive the style profile from: 'es' for Spanish, 'en' for English"),
+				mcp.DefaultString("es"),
+			),
+		),
+		handleCheckStyle,
+	)
+
+	// Tool: update_chapter_order (write mode only — see ENABLE_WRITE_TOOLS)
+	s.AddTool(
+		mcp.NewTool("update_chapter_order",
+			mcp.WithDescription("Update a chapter's frontmatter 'order' field and write it back to disk. Disabled unless ENABLE_WRITE_TOOLS=true; writes are atomic and warn if the file already has uncommitted git changes."),
+			mcp.WithString("chapter_id",
+				mcp.Required(),
+				mcp.Description("The chapter ID to update"),
+			),
+			mcp.WithString("locale",
+				mcp.Description("Language locale: 'es' for Spanish, 'en' for English"),
+				mcp.DefaultString("es"),
+			),
+			mcp.WithNumber("order",
+				mcp.Required(),
+				mcp.Description("The new order value"),
+			),
+		),
+		handleUpdateChapterOrder,
+	)
+
+	// Tool: regenerate_title_list
+	s.AddTool(
+		mcp.NewTool("regenerate_title_list",
+			mcp.WithDescription("Scan a chapter's headings and compute a corrected titleList (same tagId algorithm the site uses), fixing drift between headings and frontmatter. Returns the result by default; pass write=true (requires ENABLE_WRITE_TOOLS=true) to write it back to the chapter file."),
+			mcp.WithString("chapter_id",
+				mcp.Required(),
+				mcp.Description("The chapter ID to regenerate the titleList for"),
+			),
+			mcp.WithString("locale",
+				mcp.Description("Language locale: 'es' for Spanish, 'en' for English"),
+				mcp.DefaultString("es"),
+			),
+			mcp.WithBoolean("write",
+				mcp.Description("If true, write the regenerated titleList back to the chapter file (requires ENABLE_WRITE_TOOLS=true)"),
+				mcp.DefaultBool(false),
+			),
+		),
+		handleRegenerateTitleList,
+	)
+
+	// ============================================
+	// LEVEL 2: PREDEFINED PROMPTS
+	// ============================================
+
+	// Prompt: explain_concept
+	s.AddPrompt(
+		mcp.NewPrompt("explain_concept",
+			mcp.WithPromptDescription("Ask the AI to explain a concept from the Gentleman Programming Book"),
+			mcp.WithArgument("concept",
+				mcp.ArgumentDescription("The concept to explain (e.g., 'hexagonal architecture', 'clean architecture', 'TDD')"),
+				mcp.RequiredArgument(),
+			),
+			mcp.WithArgument("locale",
+				mcp.ArgumentDescription("Language: 'es' or 'en'"),
+			),
+		),
+		handleExplainConceptPrompt,
+	)
+
+	// Prompt: compare_patterns
+	s.AddPrompt(
+		mcp.NewPrompt("compare_patterns",
+			mcp.WithPromptDescription("Compare two architectural patterns or concepts from the book"),
+			mcp.WithArgument("pattern_a",
+				mcp.ArgumentDescription("First pattern to compare"),
+				mcp.RequiredArgument(),
+			),
+			mcp.WithArgument("pattern_b",
+				mcp.ArgumentDescription("Second pattern to compare"),
+				mcp.RequiredArgument(),
+			),
+			mcp.WithArgument("locale",
+				mcp.ArgumentDescription("Language: 'es' or 'en' (default 'es'); falls back to the other locale if a pattern has no results)"),
+			),
+		),
+		handleComparePatternsPrompt,
+	)
+
+	// Prompt: summarize_chapter
+	s.AddPrompt(
+		mcp.NewPrompt("summarize_chapter",
+			mcp.WithPromptDescription("Get a summary of a specific chapter from the book"),
+			mcp.WithArgument("chapter_id",
+				mcp.ArgumentDescription("The chapter ID to summarize"),
+				mcp.RequiredArgument(),
+			),
+			mcp.WithArgument("locale",
+				mcp.ArgumentDescription("Language: 'es' or 'en'"),
+			),
+		),
+		handleSummarizeChapterPrompt,
+	)
+
+	// Prompt: study_plan
+	s.AddPrompt(
+		mcp.NewPrompt("study_plan",
+			mcp.WithPromptDescription("Build a study plan across the whole book, respecting chapter prerequisites"),
+			mcp.WithArgument("locale",
+				mcp.ArgumentDescription("Language: 'es' or 'en'"),
+			),
+		),
+		handleStudyPlanPrompt,
+	)
+
+	// Prompt: socratic_tutor
+	s.AddPrompt(
+		mcp.NewPrompt("socratic_tutor",
+			mcp.WithPromptDescription("Quiz the reader on a chapter or section with progressively deeper questions, revealing the book's answer only after they attempt one"),
+			mcp.WithArgument("chapter_id",
+				mcp.ArgumentDescription("The chapter ID to quiz on"),
+				mcp.RequiredArgument(),
+			),
+			mcp.WithArgument("section_id",
+				mcp.ArgumentDescription("Optional section tag ID within the chapter; quizzes on the whole chapter if omitted"),
+			),
+			mcp.WithArgument("locale",
+				mcp.ArgumentDescription("Language: 'es' or 'en'"),
+			),
+		),
+		handleSocraticTutorPrompt,
+	)
+
+	// Prompt: tutorial
+	s.AddPrompt(
+		mcp.NewPrompt("tutorial",
+			mcp.WithPromptDescription("Start an interactive, multi-step tutorial for a chapter: read a section, answer any quiz questions tied to it, then work through the chapter's exercises. Advance with the tutorial_next tool."),
+			mcp.WithArgument("chapter_id",
+				mcp.ArgumentDescription("The chapter ID to build a tutorial for"),
+				mcp.RequiredArgument(),
+			),
+			mcp.WithArgument("locale",
+				mcp.ArgumentDescription("Language: 'es' or 'en'"),
+			),
+		),
+		handleTutorialPrompt,
+	)
+
+	// Prompt: draft_contribution
+	s.AddPrompt(
+		mcp.NewPrompt("draft_contribution",
+			mcp.WithPromptDescription("Draft a new book section for a known content gap (e.g. a topic with no good search results), in the book's existing voice, ready to paste into a PR against the book repo"),
+			mcp.WithArgument("topic",
+				mcp.ArgumentDescription("The topic gap to draft a new section for"),
+				mcp.RequiredArgument(),
+			),
+			mcp.WithArgument("locale",
+				mcp.ArgumentDescription("Language to draft in: 'es' or 'en'"),
+			),
+		),
+		handleDraftContributionPrompt,
+	)
+
+	startHTTPGateway()
+
+	log.Println("Starting Gentleman Book MCP Server...")
+	if err := serveTransport(s, transport, httpAddr); err != nil {
+		log.Fatalf("Server error: %v", err)
+	}
+}
+
+// ============================================
+// TOOL HANDLERS - LEVEL 1
+// ============================================
+
+func handleSetupStatus(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	status := map[string]interface{}{
+		"schemaVersion": book.SchemaVersion,
+		"degraded":      degraded,
+		"bookPath":      bookPath,
+	}
+	if degraded {
+		status["reason"] = degradedReason
+		status["fix"] = "Call set_book_path with a valid book content directory, or restart the server with a correct BOOK_PATH."
+	}
+
+	result, _ := json.MarshalIndent(status, "", "  ")
+	return mcp.NewToolResultText(string(result)), nil
+}
+
+func handleSetBookPath(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	newPath := req.GetString("book_path", "")
+	if newPath == "" {
+		return mcp.NewToolResultError("book_path is required"), nil
+	}
+	if res := validateArgLength(newPath, "book_path", maxPathLength); res != nil {
+		return res, nil
+	}
+	newPath = expandPath(newPath)
+
+	info, err := os.Stat(newPath)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("book_path is not accessible: %v", err)), nil
+	}
+	if !info.IsDir() {
+		return mcp.NewToolResultError("book_path must be a directory"), nil
+	}
+
+	bookPath = newPath
+	parser = book.NewParser(bookPath)
+	degraded = false
+	degradedReason = ""
+
+	return mcp.NewToolResultText(fmt.Sprintf("Book path updated to %s. Degraded mode cleared.", bookPath)), nil
+}
+
+func requireNotDegraded() *mcp.CallToolResult {
+	if !degraded {
+		return nil
+	}
+	return mcp.NewToolResultError(fmt.Sprintf(i18n.T("error.degraded_mode", "Server is in degraded mode: %s. Call set_book_path or restart with a valid BOOK_PATH."), degradedReason))
+}
+
+func handleListChapters(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if res := requireNotDegraded(); res != nil {
+		return res, nil
+	}
+
+	locale := req.GetString("locale", "es")
+	if res := validateArgLength(locale, "locale", maxShortArgLength); res != nil {
+		return res, nil
+	}
+
+	t, err := tenants.get(resolveBookID(req))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	chapters, err := t.parser.ListChaptersMetadata(locale)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error listing chapters: %v", err)), nil
+	}
+
+	// Create chapter summary (without full content)
+	type chapterSummary struct {
+		ID       string         `json:"id"`
+		Order    int            `json:"order"`
+		Name     string         `json:"name"`
+		Sections []book.Section `json:"sections"`
+		URL      string         `json:"url,omitempty"`
+	}
+
+	var summaries []chapterSummary
+	for _, ch := range chapters {
+		applyChapterURL(&ch)
+		summaries = append(summaries, chapterSummary{
+			ID:       ch.ID,
+			Order:    ch.Order,
+			Name:     ch.Name,
+			Sections: ch.TitleList,
+			URL:      ch.URL,
+		})
+	}
+
+	result, _ := json.MarshalIndent(summaries, "", "  ")
+	return mcp.NewToolResultText(string(result)), nil
+}
+
+func handleReadChapter(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if res := requireNotDegraded(); res != nil {
+		return res, nil
+	}
+
+	chapterID := req.GetString("chapter_id", "")
+	sectionID := req.GetString("section_id", "")
+	startLine := req.GetInt("start_line", 0)
+	endLine := req.GetInt("end_line", 0)
+	locale := req.GetString("locale", "es")
+
+	if chapterID == "" {
+		return mcp.NewToolResultError("chapter_id is required"), nil
+	}
+	if res := validateArgLength(chapterID, "chapter_id", maxShortArgLength); res != nil {
+		return res, nil
+	}
+	if res := validateArgLength(sectionID, "section_id", maxShortArgLength); res != nil {
+		return res, nil
+	}
+	if res := validateArgLength(locale, "locale", maxShortArgLength); res != nil {
+		return res, nil
+	}
+
+	t, err := tenants.get(resolveBookID(req))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if sectionID != "" {
+		// Read only the section
+		content, err := t.parser.GetSection(chapterID, sectionID, locale)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Error reading section: %v", err)), nil
+		}
+		if prefetchEnabled {
+			t.parser.PrefetchAdjacentSections(chapterID, sectionID, locale)
+		}
+		content = applyContentNormalizers(content)
+		coverageTrackers.get(t.id).recordSection(chapterID, "", sectionID)
+		return &mcp.CallToolResult{Content: []mcp.Content{mcp.NewTextContent(content), estimatedTokensContent(content)}}, nil
+	}
+
+	// Read full chapter
+	chapter, err := t.parser.GetChapter(chapterID, locale)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error reading chapter: %v", err)), nil
+	}
+	coverageTrackers.get(t.id).record(chapter.ID, chapter.Name)
+
+	content := applyContentNormalizers(chapter.Content)
+	if startLine > 0 || endLine > 0 {
+		ranged, err := lineRange(content, startLine, endLine)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		content = ranged
+	}
+
+	// Format response
+	response := fmt.Sprintf("# %s\n\n%s", chapter.Name, content)
+	blocks := append(chapterContentBlocks(response), estimatedTokensContent(response))
+	return &mcp.CallToolResult{Content: blocks}, nil
+}
+
+// estimatedTokensContent returns a small trailing content block reporting
+// content's approximate token count, so a caller can budget its context
+// before requesting the next chapter or section.
+func estimatedTokensContent(content string) mcp.Content {
+	tokens, _ := json.Marshal(map[string]int{"estimatedTokens": book.EstimateTokens(content)})
+	return mcp.NewTextContent(string(tokens))
+}
+
+// maxContentBlockSize is the largest single text block read_chapter will
+// return. Very long chapters are split across several blocks on paragraph
+// boundaries so clients can start processing before the whole chapter is
+// serialized into one giant string.
+const maxContentBlockSize = 8000
+
+// lineRange returns the 1-based, inclusive [startLine, endLine] slice of
+// content's lines, so a caller that got a search hit at a given line number
+// can pull just the surrounding context instead of the whole chapter. A
+// startLine of 0 means "from the beginning"; an endLine of 0 means "to the
+// end".
+func lineRange(content string, startLine, endLine int) (string, error) {
+	lines := strings.Split(content, "\n")
+
+	if startLine <= 0 {
+		startLine = 1
+	}
+	if endLine <= 0 || endLine > len(lines) {
+		endLine = len(lines)
+	}
+	if startLine > len(lines) {
+		return "", fmt.Errorf("start_line %d is past the end of the chapter (%d lines)", startLine, len(lines))
+	}
+	if startLine > endLine {
+		return "", fmt.Errorf("start_line %d is after end_line %d", startLine, endLine)
+	}
+
+	return strings.Join(lines[startLine-1:endLine], "\n"), nil
+}
+
+// chapterContentBlocks splits content into one or more mcp.Content blocks,
+// breaking on paragraph boundaries so no block splits mid-sentence.
+func chapterContentBlocks(content string) []mcp.Content {
+	if len(content) <= maxContentBlockSize {
+		return []mcp.Content{mcp.NewTextContent(content)}
+	}
+
+	var blocks []mcp.Content
+	paragraphs := strings.Split(content, "\n\n")
+	current := ""
+
+	flush := func() {
+		if current != "" {
+			blocks = append(blocks, mcp.NewTextContent(current))
+			current = ""
+		}
+	}
+
+	for _, p := range paragraphs {
+		if len(current)+len(p) > maxContentBlockSize && current != "" {
+			flush()
+		}
+		if current != "" {
+			current += "\n\n"
+		}
+		current += p
+	}
+	flush()
+
+	return blocks
+}
+
+func handleSearchBook(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if res := requireNotDegraded(); res != nil {
+		return res, nil
+	}
+
+	query := req.GetString("query", "")
+	locale := req.GetString("locale", "es")
+	explain := req.GetBool("explain", false)
+	tone := req.GetString("tone", "")
+
+	if query == "" {
+		return mcp.NewToolResultError("query is required"), nil
+	}
+	if res := validateArgLength(query, "query", maxQueryLength); res != nil {
+		return res, nil
+	}
+	if res := validateArgLength(locale, "locale", maxShortArgLength); res != nil {
+		return res, nil
+	}
+
+	t, err := tenants.get(resolveBookID(req))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	results, err := t.parser.SearchExplain(query, locale, explain)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error searching: %v", err)), nil
+	}
+
+	results = applyResultPostProcessors(applyRankingBoosters(query, results))
+	results = filterByTone(results, tone)
+	results = applySearchResultURLs(results)
+
+	// The FAQ is checked first: a curated answer for a recurring question
+	// goes ahead of whatever the keyword scan turned up, so common
+	// questions get an instant, consistent answer instead of depending on
+	// that scan's ranking.
+	if faq := matchFAQ(query, locale); faq != nil {
+		results = append([]book.SearchResult{faqSearchResult(faq, locale)}, results...)
+	}
+
+	for _, r := range results {
+		coverageTrackers.get(t.id).recordSection(r.ChapterID, r.ChapterName, r.Section)
+	}
+
+	if len(results) == 0 {
+		zeroResultQueries.record(query)
+		return mcp.NewToolResultText("No results found for: " + query), nil
+	}
+
+	resultJSON, _ := json.MarshalIndent(results, "", "  ")
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// filterByTone keeps only results whose Tone includes at least one of the
+// comma-separated labels in toneFilter. An empty toneFilter is a no-op.
+func filterByTone(results []book.SearchResult, toneFilter string) []book.SearchResult {
+	toneFilter = strings.TrimSpace(toneFilter)
+	if toneFilter == "" {
+		return results
+	}
+
+	wanted := make(map[string]bool)
+	for _, label := range strings.Split(toneFilter, ",") {
+		label = strings.ToLower(strings.TrimSpace(label))
+		if label != "" {
+			wanted[label] = true
+		}
+	}
+	if len(wanted) == 0 {
+		return results
+	}
+
+	var filtered []book.SearchResult
+	for _, r := range results {
+		for _, t := range r.Tone {
+			if wanted[strings.ToLower(t)] {
+				filtered = append(filtered, r)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+// healthReport is the JSON shape returned by the health tool.
+type healthReport struct {
+	Degraded       bool            `json:"degraded"`
+	DegradedReason string          `json:"degradedReason,omitempty"`
+	ScheduledTasks []taskRunStatus `json:"scheduledTasks,omitempty"`
+}
+
+func handleHealth(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	report := healthReport{
+		Degraded:       degraded,
+		DegradedReason: degradedReason,
+		ScheduledTasks: scheduler.snapshot(),
+	}
+
+	result, _ := json.MarshalIndent(report, "", "  ")
+	return mcp.NewToolResultText(string(result)), nil
+}
+
+func handleServerInfo(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	info := map[string]interface{}{
+		"schemaVersion":       book.SchemaVersion,
+		"version":             version,
+		"gitCommit":           gitCommit,
+		"buildDate":           buildDate,
+		"bookContentCommit":   bookContentCommit(bookPath),
+		"bookPath":            bookPath,
+		"configuredProviders": configuredProviders(),
+		"enabledFeatures":     enabledFeatures(),
+		"stateBackend":        stateBackend.Name(),
+	}
+	if desc := embeddings.OfflineModeDescription(); desc != "" {
+		info["offlineMode"] = desc
+	}
+
+	result, _ := json.MarshalIndent(info, "", "  ")
+	return mcp.NewToolResultText(string(result)), nil
+}
+
+func handleGetClientConfig(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	client := req.GetString("client", "claude-desktop")
+	if res := validateArgLength(client, "client", maxShortArgLength); res != nil {
+		return res, nil
+	}
+
+	execPath, err := os.Executable()
+	if err != nil {
+		execPath = "gentleman-book-mcp"
+	}
+
+	snippet, err := mcpClientConfig(client, execPath, os.Getenv("BOOK_PATH"))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(snippet), nil
+}
+
+func handleGetBookIndex(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if res := requireNotDegraded(); res != nil {
+		return res, nil
+	}
+
+	locale := req.GetString("locale", "es")
+	if res := validateArgLength(locale, "locale", maxShortArgLength); res != nil {
+		return res, nil
+	}
+
+	index, err := parser.GetBookIndex(locale)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting index: %v", err)), nil
+	}
+	for i := range index.Chapters {
+		applyChapterURL(&index.Chapters[i])
+	}
+
+	result, _ := json.MarshalIndent(index, "", "  ")
+	return mcp.NewToolResultText(string(result)), nil
+}
+
+func handleGetPrerequisites(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if res := requireNotDegraded(); res != nil {
+		return res, nil
+	}
+
+	chapterID := req.GetString("chapter_id", "")
+	locale := req.GetString("locale", "es")
+	if chapterID == "" {
+		return mcp.NewToolResultError("chapter_id is required"), nil
+	}
+	if res := validateArgLength(chapterID, "chapter_id", maxShortArgLength); res != nil {
+		return res, nil
+	}
+	if res := validateArgLength(locale, "locale", maxShortArgLength); res != nil {
+		return res, nil
+	}
+
+	prereqs, err := resolvedPrerequisites(parser, chapterID, locale)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting prerequisites: %v", err)), nil
+	}
+
+	result, _ := json.MarshalIndent(prereqs, "", "  ")
+	return mcp.NewToolResultText(string(result)), nil
+}
+
+func handleGetLearningOrder(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if res := requireNotDegraded(); res != nil {
+		return res, nil
+	}
+
+	locale := req.GetString("locale", "es")
+	if res := validateArgLength(locale, "locale", maxShortArgLength); res != nil {
+		return res, nil
+	}
+
+	order, err := resolvedLearningOrder(parser, locale)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error computing learning order: %v", err)), nil
+	}
+
+	// Metadata only, matching get_book_index's omission of full content.
+	for i := range order {
+		order[i].Content = ""
+	}
+
+	result, _ := json.MarshalIndent(order, "", "  ")
+	return mcp.NewToolResultText(string(result)), nil
+}
+
+func handleCheckReadiness(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if res := requireNotDegraded(); res != nil {
+		return res, nil
+	}
+
+	chapterID := req.GetString("chapter_id", "")
+	locale := req.GetString("locale", "es")
+	if chapterID == "" {
+		return mcp.NewToolResultError("chapter_id is required"), nil
+	}
+	if res := validateArgLength(chapterID, "chapter_id", maxShortArgLength); res != nil {
+		return res, nil
+	}
+	if res := validateArgLength(locale, "locale", maxShortArgLength); res != nil {
+		return res, nil
+	}
+
+	bookID := resolveBookID(req)
+	t, err := tenants.get(bookID)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	report, err := checkReadiness(t.parser, t.id, chapterID, locale)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error checking readiness: %v", err)), nil
+	}
+
+	result, _ := json.MarshalIndent(report, "", "  ")
+	return mcp.NewToolResultText(string(result)), nil
+}
+
+func handleGetChecklists(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if res := requireNotDegraded(); res != nil {
+		return res, nil
+	}
+
+	chapterID := req.GetString("chapter_id", "")
+	locale := req.GetString("locale", "es")
+	if res := validateArgLength(chapterID, "chapter_id", maxShortArgLength); res != nil {
+		return res, nil
+	}
+	if res := validateArgLength(locale, "locale", maxShortArgLength); res != nil {
+		return res, nil
+	}
+
+	items, err := parser.GetChecklists(chapterID, locale)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error extracting checklists: %v", err)), nil
+	}
+
+	if len(items) == 0 {
+		return mcp.NewToolResultText("No checklist items found"), nil
+	}
+
+	result, _ := json.MarshalIndent(items, "", "  ")
+	return mcp.NewToolResultText(string(result)), nil
+}
+
+func handleListExercises(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if res := requireNotDegraded(); res != nil {
+		return res, nil
+	}
+
+	chapterID := req.GetString("chapter_id", "")
+	locale := req.GetString("locale", "es")
+	if res := validateArgLength(chapterID, "chapter_id", maxShortArgLength); res != nil {
+		return res, nil
+	}
+	if res := validateArgLength(locale, "locale", maxShortArgLength); res != nil {
+		return res, nil
+	}
+
+	exercises, err := parser.GetExercises(chapterID, locale)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error extracting exercises: %v", err)), nil
+	}
+
+	if len(exercises) == 0 {
+		return mcp.NewToolResultText("No exercises found"), nil
+	}
+
+	withStatus := make([]exerciseWithProgress, len(exercises))
+	for i, e := range exercises {
+		withStatus[i] = withProgress(e)
+	}
+
+	result, _ := json.MarshalIndent(withStatus, "", "  ")
+	return mcp.NewToolResultText(string(result)), nil
+}
+
+func handleGetExercise(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if res := requireNotDegraded(); res != nil {
+		return res, nil
+	}
+
+	exerciseID := req.GetString("exercise_id", "")
+	locale := req.GetString("locale", "es")
+	if exerciseID == "" {
+		return mcp.NewToolResultError("exercise_id is required"), nil
+	}
+	if res := validateArgLength(exerciseID, "exercise_id", maxShortArgLength); res != nil {
+		return res, nil
+	}
+	if res := validateArgLength(locale, "locale", maxShortArgLength); res != nil {
+		return res, nil
+	}
+
+	exercise, err := parser.GetExercise(exerciseID, locale)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting exercise: %v", err)), nil
+	}
+
+	result, _ := json.MarshalIndent(withProgress(*exercise), "", "  ")
+	return mcp.NewToolResultText(string(result)), nil
+}
+
+func handleCompleteExercise(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	exerciseID := req.GetString("exercise_id", "")
+	if exerciseID == "" {
+		return mcp.NewToolResultError("exercise_id is required"), nil
+	}
+	if res := validateArgLength(exerciseID, "exercise_id", maxShortArgLength); res != nil {
+		return res, nil
+	}
+
+	exerciseProgress.complete(exerciseID)
+
+	result, _ := json.MarshalIndent(map[string]interface{}{"exerciseId": exerciseID, "completed": true}, "", "  ")
+	return mcp.NewToolResultText(string(result)), nil
+}
+
+func handleTutorialNext(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	tutorialID := req.GetString("tutorial_id", "")
+	if tutorialID == "" {
+		return mcp.NewToolResultError("tutorial_id is required"), nil
+	}
+	if res := validateArgLength(tutorialID, "tutorial_id", maxShortArgLength); res != nil {
+		return res, nil
+	}
+
+	state, err := tutorials.advance(tutorialID)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	response := map[string]interface{}{
+		"tutorialId": state.ID,
+		"totalSteps": len(state.Steps),
+		"step":       state.currentStep(),
+	}
+	result, _ := json.MarshalIndent(response, "", "  ")
+	return mcp.NewToolResultText(string(result)), nil
+}
+
+func handleResumeSession(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	sessionID := req.GetString("session_id", "")
+	locale := req.GetString("locale", "")
+	tenantID := req.GetString("tenant_id", "")
+	if res := validateArgLength(sessionID, "session_id", maxShortArgLength); res != nil {
+		return res, nil
+	}
+	if res := validateArgLength(locale, "locale", maxShortArgLength); res != nil {
+		return res, nil
+	}
+	if res := validateArgLength(tenantID, "tenant_id", maxShortArgLength); res != nil {
+		return res, nil
+	}
+
+	state := stateBackend.ResumeSession(sessionID, locale, tenantID)
+
+	response := map[string]interface{}{
+		"schemaVersion":       book.SchemaVersion,
+		"sessionId":           state.ID,
+		"locale":              state.Locale,
+		"tenantId":            state.TenantID,
+		"keepAliveIntervalMs": sessionKeepAliveInterval.Milliseconds(),
+	}
+
+	result, _ := json.MarshalIndent(response, "", "  ")
+	return mcp.NewToolResultText(string(result)), nil
+}
+
+func handleGetCoverageReport(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if res := requireNotDegraded(); res != nil {
+		return res, nil
+	}
+
+	locale := req.GetString("locale", "es")
+	if res := validateArgLength(locale, "locale", maxShortArgLength); res != nil {
+		return res, nil
+	}
+
+	bookID := resolveBookID(req)
+	t, err := tenants.get(bookID)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	chapters, err := t.parser.ListChapters(locale)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error listing chapters: %v", err)), nil
+	}
+
+	allChapterIDs := make(map[string]string, len(chapters))
+	for _, c := range chapters {
+		allChapterIDs[c.ID] = c.Name
+	}
+
+	report := coverageTrackers.get(t.id).report(allChapterIDs)
+
+	result, _ := json.MarshalIndent(report, "", "  ")
+	return mcp.NewToolResultText(string(result)), nil
+}
+
+func handleGetZeroResultQueries(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	result, _ := json.MarshalIndent(zeroResultQueries.report(), "", "  ")
+	return mcp.NewToolResultText(string(result)), nil
+}
+
+func handleGetReviewQuestions(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if res := requireNotDegraded(); res != nil {
+		return res, nil
+	}
+
+	chapterID := req.GetString("chapter_id", "")
+	locale := req.GetString("locale", "es")
+	if chapterID == "" {
+		return mcp.NewToolResultError("chapter_id is required"), nil
+	}
+	if res := validateArgLength(chapterID, "chapter_id", maxShortArgLength); res != nil {
+		return res, nil
+	}
+	if res := validateArgLength(locale, "locale", maxShortArgLength); res != nil {
+		return res, nil
+	}
+
+	questions, err := resolvedReviewQuestions(parser, chapterID, locale)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting review questions: %v", err)), nil
+	}
+
+	result, _ := json.MarshalIndent(questions, "", "  ")
+	return mcp.NewToolResultText(string(result)), nil
+}
+
+func handleFindQuote(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if res := requireNotDegraded(); res != nil {
+		return res, nil
+	}
+
+	quote := req.GetString("quote", "")
+	locale := req.GetString("locale", "es")
+	if quote == "" {
+		return mcp.NewToolResultError("quote is required"), nil
+	}
+	if res := validateArgLength(quote, "quote", maxQueryLength); res != nil {
+		return res, nil
+	}
+	if res := validateArgLength(locale, "locale", maxShortArgLength); res != nil {
+		return res, nil
+	}
+
+	match, err := parser.FindQuote(quote, locale)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error finding quote: %v", err)), nil
+	}
+
+	result, _ := json.MarshalIndent(match, "", "  ")
+	return mcp.NewToolResultText(string(result)), nil
+}
+
+func handleVerifyClaim(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if res := requireNotDegraded(); res != nil {
+		return res, nil
+	}
+
+	claim := req.GetString("claim", "")
 	locale := req.GetString("locale", "es")
+	if claim == "" {
+		return mcp.NewToolResultError("claim is required"), nil
+	}
+	if res := validateArgLength(claim, "claim", maxQueryLength); res != nil {
+		return res, nil
+	}
+	if res := validateArgLength(locale, "locale", maxShortArgLength); res != nil {
+		return res, nil
+	}
+
+	verification, err := parser.VerifyClaim(claim, locale)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error verifying claim: %v", err)), nil
+	}
+
+	result, _ := json.MarshalIndent(verification, "", "  ")
+	return mcp.NewToolResultText(string(result)), nil
+}
+
+// ============================================
+// RESOURCE HANDLERS - LEVEL 2
+// ============================================
+
+func handleBookIndexResource(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	uri := req.Params.URI
+
+	// Extract locale from URI
+	locale := "es"
+	if strings.HasSuffix(uri, "/en") {
+		locale = "en"
+	}
+
+	index, err := parser.GetBookIndex(locale)
+	if err != nil {
+		return nil, fmt.Errorf("error getting book index: %w", err)
+	}
+
+	indexJSON, _ := json.MarshalIndent(index, "", "  ")
+
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      uri,
+			MIMEType: "application/json",
+			Text:     string(indexJSON),
+		},
+	}, nil
+}
+
+// ============================================
+// PROMPT HANDLERS - LEVEL 2
+// ============================================
+
+// missingArgumentPromptResult builds the standard error response for a
+// prompt invoked without one of its required arguments.
+func missingArgumentPromptResult(promptName, argName string) *mcp.GetPromptResult {
+	return &mcp.GetPromptResult{
+		Description: fmt.Sprintf("Error: %s is required", argName),
+		Messages: []mcp.PromptMessage{
+			{
+				Role:    mcp.RoleUser,
+				Content: mcp.NewTextContent(fmt.Sprintf("The '%s' prompt requires a '%s' argument.", promptName, argName)),
+			},
+		},
+	}
+}
+
+func handleExplainConceptPrompt(ctx context.Context, req mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	concept := ""
+	locale := "es"
+
+	if args := req.Params.Arguments; args != nil {
+		if c := args["concept"]; c != "" {
+			concept = c
+		}
+		if l := args["locale"]; l != "" {
+			locale = l
+		}
+	}
+
+	if concept == "" {
+		return missingArgumentPromptResult("explain_concept", "concept"), nil
+	}
+
+	// Search for relevant content in the book
+	results, _ := parser.Search(concept, locale)
+
+	var contextSnippets string
+	if len(results) > 0 {
+		var snippets []string
+		for i, r := range results {
+			if i >= 5 { // Maximum 5 snippets
+				break
+			}
+			snippets = append(snippets, fmt.Sprintf("From '%s' (%s):\n%s", r.ChapterName, r.Section, r.Snippet))
+		}
+		contextSnippets = strings.Join(snippets, "\n\n---\n\n")
+	}
+
+	promptText, err := renderPrompt("explain_concept.tmpl", struct {
+		Concept         string
+		ContextSnippets string
+	}{Concept: concept, ContextSnippets: contextSnippets})
+	if err != nil {
+		return nil, err
+	}
+
+	return &mcp.GetPromptResult{
+		Description: fmt.Sprintf("Explain '%s' from the Gentleman Programming Book", concept),
+		Messages: []mcp.PromptMessage{
+			{
+				Role:    mcp.RoleUser,
+				Content: mcp.NewTextContent(promptText),
+			},
+		},
+	}, nil
+}
+
+// comparePatternContext searches for pattern in locale and, if that turns up
+// nothing, retries in fallbackLocale, returning citation-tagged snippets
+// suitable for dropping straight into a prompt.
+func comparePatternContext(pattern, locale, fallbackLocale string) string {
+	results, _ := parser.Search(pattern, locale)
+	if len(results) == 0 {
+		results, _ = parser.Search(pattern, fallbackLocale)
+	}
+	if len(results) == 0 {
+		return ""
+	}
+
+	var snippets []string
+	for i, r := range results {
+		if i >= 3 {
+			break
+		}
+		snippets = append(snippets, fmt.Sprintf("From '%s' (%s):\n%s", r.ChapterName, r.Section, r.Snippet))
+	}
+	return strings.Join(snippets, "\n\n---\n\n")
+}
+
+func handleComparePatternsPrompt(ctx context.Context, req mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	patternA := ""
+	patternB := ""
+	locale := "es"
+
+	if args := req.Params.Arguments; args != nil {
+		if a := args["pattern_a"]; a != "" {
+			patternA = a
+		}
+		if b := args["pattern_b"]; b != "" {
+			patternB = b
+		}
+		if l := args["locale"]; l != "" {
+			locale = l
+		}
+	}
+
+	if patternA == "" {
+		return missingArgumentPromptResult("compare_patterns", "pattern_a"), nil
+	}
+	if patternB == "" {
+		return missingArgumentPromptResult("compare_patterns", "pattern_b"), nil
+	}
+
+	fallbackLocale := "en"
+	if locale == "en" {
+		fallbackLocale = "es"
+	}
+
+	contextA := comparePatternContext(patternA, locale, fallbackLocale)
+	contextB := comparePatternContext(patternB, locale, fallbackLocale)
+
+	promptText, err := renderPrompt("compare_patterns.tmpl", struct {
+		PatternA string
+		PatternB string
+		ContextA string
+		ContextB string
+	}{PatternA: patternA, PatternB: patternB, ContextA: contextA, ContextB: contextB})
+	if err != nil {
+		return nil, err
+	}
+
+	return &mcp.GetPromptResult{
+		Description: fmt.Sprintf("Compare '%s' vs '%s'", patternA, patternB),
+		Messages: []mcp.PromptMessage{
+			{
+				Role:    mcp.RoleUser,
+				Content: mcp.NewTextContent(promptText),
+			},
+		},
+	}, nil
+}
+
+func handleDraftContributionPrompt(ctx context.Context, req mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	topic := ""
+	locale := "es"
+
+	if args := req.Params.Arguments; args != nil {
+		if t := args["topic"]; t != "" {
+			topic = t
+		}
+		if l := args["locale"]; l != "" {
+			locale = l
+		}
+	}
+
+	if topic == "" {
+		return missingArgumentPromptResult("draft_contribution", "topic"), nil
+	}
+
+	fallbackLocale := "en"
+	if locale == "en" {
+		fallbackLocale = "es"
+	}
+
+	related := comparePatternContext(topic, locale, fallbackLocale)
+	if related == "" {
+		related = "(No closely related content found — draft from scratch, matching general book conventions.)"
+	}
+
+	promptText, err := renderPrompt("draft_contribution.tmpl", struct {
+		Topic          string
+		Locale         string
+		RelatedContent string
+	}{Topic: topic, Locale: locale, RelatedContent: related})
+	if err != nil {
+		return nil, err
+	}
+
+	return &mcp.GetPromptResult{
+		Description: fmt.Sprintf("Draft a new section covering '%s'", topic),
+		Messages: []mcp.PromptMessage{
+			{
+				Role:    mcp.RoleUser,
+				Content: mcp.NewTextContent(promptText),
+			},
+		},
+	}, nil
+}
+
+// validChapterIDsHint lists the chapter IDs available in locale, for dropping
+// into an error message so a caller doesn't have to guess-and-check.
+func validChapterIDsHint(locale string) string {
+	chapters, err := parser.ListChapters(locale)
+	if err != nil || len(chapters) == 0 {
+		return "No chapters are available to list."
+	}
+
+	ids := make([]string, 0, len(chapters))
+	for _, c := range chapters {
+		ids = append(ids, c.ID)
+	}
+	return fmt.Sprintf("Valid chapter ids for locale '%s': %s", locale, strings.Join(ids, ", "))
+}
+
+func handleSummarizeChapterPrompt(ctx context.Context, req mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	chapterID := ""
+	locale := "es"
+
+	if args := req.Params.Arguments; args != nil {
+		if id := args["chapter_id"]; id != "" {
+			chapterID = id
+		}
+		if l := args["locale"]; l != "" {
+			locale = l
+		}
+	}
+
+	if chapterID == "" {
+		return missingArgumentPromptResult("summarize_chapter", "chapter_id"), nil
+	}
+
+	chapter, err := parser.GetChapter(chapterID, locale)
+	if err != nil {
+		return &mcp.GetPromptResult{
+			Description: fmt.Sprintf("Error: %v", err),
+			Messages: []mcp.PromptMessage{
+				{
+					Role:    mcp.RoleUser,
+					Content: mcp.NewTextContent(fmt.Sprintf("Could not find chapter '%s'. %s", chapterID, validChapterIDsHint(locale))),
+				},
+			},
+		}, nil
+	}
+
+	content := budgetedChapterSummary(chapter.Content, summaryCharBudget)
+
+	promptText, err := renderPrompt("summarize_chapter.tmpl", struct {
+		ChapterName string
+		Content     string
+	}{ChapterName: chapter.Name, Content: content})
+	if err != nil {
+		return nil, err
+	}
+
+	return &mcp.GetPromptResult{
+		Description: fmt.Sprintf("Summary of '%s'", chapter.Name),
+		Messages: []mcp.PromptMessage{
+			{
+				Role:    mcp.RoleUser,
+				Content: mcp.NewTextContent(promptText),
+			},
+		},
+	}, nil
+}
+
+func handleSocraticTutorPrompt(ctx context.Context, req mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	chapterID := ""
+	sectionID := ""
+	locale := "es"
+
+	if args := req.Params.Arguments; args != nil {
+		if id := args["chapter_id"]; id != "" {
+			chapterID = id
+		}
+		if id := args["section_id"]; id != "" {
+			sectionID = id
+		}
+		if l := args["locale"]; l != "" {
+			locale = l
+		}
+	}
+
+	if chapterID == "" {
+		return missingArgumentPromptResult("socratic_tutor", "chapter_id"), nil
+	}
+
+	chapter, err := parser.GetChapter(chapterID, locale)
+	if err != nil {
+		return &mcp.GetPromptResult{
+			Description: fmt.Sprintf("Error: %v", err),
+			Messages: []mcp.PromptMessage{
+				{
+					Role:    mcp.RoleUser,
+					Content: mcp.NewTextContent(fmt.Sprintf("Could not find chapter '%s'. %s", chapterID, validChapterIDsHint(locale))),
+				},
+			},
+		}, nil
+	}
+
+	content := chapter.Content
+	if sectionID != "" {
+		content, err = parser.GetSection(chapterID, sectionID, locale)
+		if err != nil {
+			return &mcp.GetPromptResult{
+				Description: fmt.Sprintf("Error: %v", err),
+				Messages: []mcp.PromptMessage{
+					{
+						Role:    mcp.RoleUser,
+						Content: mcp.NewTextContent(fmt.Sprintf("Could not find section '%s' in chapter '%s': %v", sectionID, chapterID, err)),
+					},
+				},
+			}, nil
+		}
+	}
+	content = budgetedChapterSummary(content, summaryCharBudget)
+
+	promptText, err := renderPrompt("socratic_tutor.tmpl", struct {
+		ChapterName  string
+		SectionTagID string
+		Content      string
+	}{ChapterName: chapter.Name, SectionTagID: sectionID, Content: content})
+	if err != nil {
+		return nil, err
+	}
+
+	return &mcp.GetPromptResult{
+		Description: fmt.Sprintf("Socratic quiz on '%s'", chapter.Name),
+		Messages: []mcp.PromptMessage{
+			{
+				Role:    mcp.RoleUser,
+				Content: mcp.NewTextContent(promptText),
+			},
+		},
+	}, nil
+}
+
+func handleTutorialPrompt(ctx context.Context, req mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	chapterID := ""
+	locale := "es"
+	if args := req.Params.Arguments; args != nil {
+		if id := args["chapter_id"]; id != "" {
+			chapterID = id
+		}
+		if l := args["locale"]; l != "" {
+			locale = l
+		}
+	}
 
-	chapters, err := parser.ListChapters(locale)
+	if chapterID == "" {
+		return missingArgumentPromptResult("tutorial", "chapter_id"), nil
+	}
+
+	chapter, err := parser.GetChapter(chapterID, locale)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Error listing chapters: %v", err)), nil
+		return &mcp.GetPromptResult{
+			Description: fmt.Sprintf("Error: %v", err),
+			Messages: []mcp.PromptMessage{
+				{
+					Role:    mcp.RoleUser,
+					Content: mcp.NewTextContent(fmt.Sprintf("Could not find chapter '%s'. %s", chapterID, validChapterIDsHint(locale))),
+				},
+			},
+		}, nil
 	}
 
-	// Create chapter summary (without full content)
-	type chapterSummary struct {
-		ID       string         `json:"id"`
-		Order    int            `json:"order"`
-		Name     string         `json:"name"`
-		Sections []book.Section `json:"sections"`
+	steps, err := buildTutorialSteps(chapterID, locale)
+	if err != nil {
+		return nil, err
 	}
 
-	var summaries []chapterSummary
-	for _, ch := range chapters {
-		summaries = append(summaries, chapterSummary{
-			ID:       ch.ID,
-			Order:    ch.Order,
-			Name:     ch.Name,
-			Sections: ch.TitleList,
-		})
+	state := tutorials.start(chapterID, locale, steps)
+
+	promptText, err := renderPrompt("tutorial.tmpl", struct {
+		ChapterName string
+		TutorialID  string
+		TotalSteps  int
+		FirstStep   TutorialStep
+	}{ChapterName: chapter.Name, TutorialID: state.ID, TotalSteps: len(steps), FirstStep: state.currentStep()})
+	if err != nil {
+		return nil, err
 	}
 
-	result, _ := json.MarshalIndent(summaries, "", "  ")
-	return mcp.NewToolResultText(string(result)), nil
+	return &mcp.GetPromptResult{
+		Description: fmt.Sprintf("Interactive tutorial for '%s'", chapter.Name),
+		Messages: []mcp.PromptMessage{
+			{
+				Role:    mcp.RoleUser,
+				Content: mcp.NewTextContent(promptText),
+			},
+		},
+	}, nil
 }
 
-func handleReadChapter(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	chapterID := req.GetString("chapter_id", "")
-	sectionID := req.GetString("section_id", "")
-	locale := req.GetString("locale", "es")
+func handleStudyPlanPrompt(ctx context.Context, req mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	locale := "es"
+	if args := req.Params.Arguments; args != nil {
+		if l := args["locale"]; l != "" {
+			locale = l
+		}
+	}
 
-	if chapterID == "" {
-		return mcp.NewToolResultError("chapter_id is required"), nil
+	order, err := resolvedLearningOrder(parser, locale)
+	if err != nil {
+		return &mcp.GetPromptResult{
+			Description: fmt.Sprintf("Error: %v", err),
+			Messages: []mcp.PromptMessage{
+				{
+					Role:    mcp.RoleUser,
+					Content: mcp.NewTextContent(fmt.Sprintf("Could not compute a study plan: %v", err)),
+				},
+			},
+		}, nil
 	}
 
-	if sectionID != "" {
-		// Read only the section
-		content, err := parser.GetSection(chapterID, sectionID, locale)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Error reading section: %v", err)), nil
+	promptText, err := renderPrompt("study_plan.tmpl", struct {
+		Chapters []book.Chapter
+	}{Chapters: order})
+	if err != nil {
+		return nil, err
+	}
+
+	return &mcp.GetPromptResult{
+		Description: fmt.Sprintf("Study plan for the Gentleman Programming Book (%s)", locale),
+		Messages: []mcp.PromptMessage{
+			{
+				Role:    mcp.RoleUser,
+				Content: mcp.NewTextContent(promptText),
+			},
+		},
+	}, nil
+}
+
+// ============================================
+// SEMANTIC SEARCH HANDLERS - LEVEL 3
+// ============================================
+
+// buildSemanticEngine runs the same provider cascade initSemanticEngine uses
+// for the global engine (fake > OpenAI > Cohere > Voyage > Gemini > Ollama),
+// returning whichever one is configured and available, or nil if none are.
+// logf receives a diagnostic line per provider tried, so both the
+// process-wide engine (logged directly) and a tenant's lazily-built one
+// (logged with a tenant prefix) get useful startup/build diagnostics.
+func buildSemanticEngine(logf func(format string, args ...interface{})) (*embeddings.SemanticEngine, string) {
+	if os.Getenv("EMBEDDING_PROVIDER") == "fake" {
+		if engine, err := embeddings.NewSemanticEngine(embeddings.ProviderFake); err == nil {
+			return engine, "the fake deterministic provider (chaos/testing mode)"
+		} else {
+			logf("Fake provider failed to initialize: %v", err)
 		}
-		return mcp.NewToolResultText(content), nil
 	}
 
-	// Read full chapter
-	chapter, err := parser.GetChapter(chapterID, locale)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Error reading chapter: %v", err)), nil
+	if os.Getenv("OPENAI_API_KEY") != "" {
+		if engine, err := embeddings.NewSemanticEngine(embeddings.ProviderOpenAI); err == nil {
+			return engine, "OpenAI"
+		} else {
+			logf("OpenAI not available: %v", err)
+		}
 	}
 
-	// Format response
-	response := fmt.Sprintf("# %s\n\n%s", chapter.Name, chapter.Content)
-	return mcp.NewToolResultText(response), nil
+	if os.Getenv("COHERE_API_KEY") != "" {
+		if engine, err := embeddings.NewSemanticEngine(embeddings.ProviderCohere); err == nil {
+			return engine, "Cohere"
+		} else {
+			logf("Cohere not available: %v", err)
+		}
+	}
+
+	if os.Getenv("VOYAGE_API_KEY") != "" {
+		if engine, err := embeddings.NewSemanticEngine(embeddings.ProviderVoyage); err == nil {
+			return engine, "Voyage AI"
+		} else {
+			logf("Voyage AI not available: %v", err)
+		}
+	}
+
+	if os.Getenv("GEMINI_API_KEY") != "" {
+		if engine, err := embeddings.NewSemanticEngine(embeddings.ProviderGemini); err == nil {
+			return engine, "Gemini"
+		} else {
+			logf("Gemini not available: %v", err)
+		}
+	}
+
+	if engine, err := embeddings.NewSemanticEngine(embeddings.ProviderOllama); err == nil && engine.IsAvailable() {
+		return engine, "Ollama"
+	}
+
+	return nil, ""
 }
 
-func handleSearchBook(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+func initSemanticEngine() {
+	engine, label := buildSemanticEngine(log.Printf)
+	if engine == nil {
+		log.Println("Semantic search not available (no OpenAI key or Ollama)")
+		semanticEngine = nil
+		return
+	}
+	semanticEngine = engine
+	log.Printf("Semantic search enabled with %s", label)
+}
+
+// bookSemanticEngine resolves which semantic engine to search for bookID:
+// the default book uses the server-wide semanticEngine, while any other
+// book_id/tenant_id uses that entry's own engine in the tenant registry (nil
+// until that book has its own index built).
+func bookSemanticEngine(bookID string) (*embeddings.SemanticEngine, error) {
+	if bookID == "" || bookID == defaultTenantID {
+		return semanticEngine, nil
+	}
+	t, err := tenants.get(bookID)
+	if err != nil {
+		return nil, err
+	}
+	return t.semanticEngine, nil
+}
+
+func handleSemanticSearch(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	bookID := resolveBookID(req)
+	engine, err := bookSemanticEngine(bookID)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if engine == nil {
+		return mcp.NewToolResultError("Semantic search not available for this book. Set OPENAI_API_KEY or ensure Ollama is running, and build its index."), nil
+	}
+
+	if !engine.IsIndexed() {
+		return mcp.NewToolResultError("Semantic index not built. Run 'build_semantic_index' first."), nil
+	}
+
 	query := req.GetString("query", "")
 	locale := req.GetString("locale", "es")
+	topK := req.GetInt("top_k", 5)
+	explain := req.GetBool("explain", false)
 
 	if query == "" {
 		return mcp.NewToolResultError("query is required"), nil
 	}
+	if res := validateArgLength(query, "query", maxQueryLength); res != nil {
+		return res, nil
+	}
+	if res := validateArgLength(locale, "locale", maxShortArgLength); res != nil {
+		return res, nil
+	}
+	topK = clampInt(topK, 1, maxTopK)
 
-	results, err := parser.Search(query, locale)
+	results, err := engine.SearchExplain(ctx, query, locale, topK, explain)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Error searching: %v", err)), nil
+		return mcp.NewToolResultError(fmt.Sprintf("Search error: %v", err)), nil
+	}
+	results = applySemanticResultURLs(results)
+
+	for _, r := range results {
+		coverageTrackers.get(bookID).recordSection(r.ChapterID, r.ChapterName, r.Section)
 	}
 
 	if len(results) == 0 {
-		return mcp.NewToolResultText("No results found for: " + query), nil
+		return mcp.NewToolResultText("No semantic matches found for: " + query), nil
 	}
 
 	resultJSON, _ := json.MarshalIndent(results, "", "  ")
 	return mcp.NewToolResultText(string(resultJSON)), nil
 }
 
-func handleGetBookIndex(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	locale := req.GetString("locale", "es")
+// submitIndexBuildJob builds chunks for localeParam ("all", or a specific
+// locale) against t's own parser and semantic engine, and submits the
+// embedding pass as a background job, shared by the build_semantic_index
+// tool and the scheduled "rebuild_index" task.
+func submitIndexBuildJob(t *tenant, localeParam string) (*job, int, error) {
+	var locales []string
+	if localeParam == "all" {
+		locales = []string{"es", "en"}
+	} else {
+		locales = []string{localeParam}
+	}
 
-	index, err := parser.GetBookIndex(locale)
+	var allChunks []embeddings.Chunk
+	var filterStats []BoilerplateFilterStats
+	var hashUpdates []chapterHashUpdate
+	chunkID := 0
+
+	for _, locale := range locales {
+		chapters, err := t.parser.ListChapters(locale)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error reading chapters for %s: %w", locale, err)
+		}
+
+		for _, chapter := range chapters {
+			content, stats := filterBoilerplate(chapter.Content, chapter.ID, locale)
+			filterStats = append(filterStats, stats)
+			hashUpdates = append(hashUpdates, chapterHashUpdate{chapterID: chapter.ID, locale: locale, hash: embeddings.ContentHash(content)})
+
+			// Split content into chunks (by sections or paragraphs)
+			chunks := splitIntoChunks(content, chapter.ID, chapter.Name, locale, &chunkID)
+			allChunks = append(allChunks, chunks...)
+		}
+	}
+
+	engine := t.semanticEngine
+	var scrubReport []ScrubStats
+	if scrubSecretsEnabled() && isCloudProvider(engine.Provider()) {
+		scrubReport = scrubChunksForEmbedding(allChunks)
+	}
+
+	// Indexing runs as a background job so other tool calls can keep using
+	// the server (and poll job_status) instead of blocking on what can be a
+	// slow, provider-rate-limited embedding pass.
+	j := jobs.submit("index_build", func(ctx context.Context, progress func(JobUpdate)) (interface{}, error) {
+		err := engine.IndexChunksWithProgress(ctx, allChunks, func(done, total int, chapterID, chunkLocale string) {
+			progress(JobUpdate{
+				"chunksEmbedded":   done,
+				"totalChunks":      total,
+				"currentChapterId": chapterID,
+				"currentLocale":    chunkLocale,
+			})
+		})
+		if err != nil {
+			return nil, err
+		}
+		// A full build covers every chapter, so its content hashes become
+		// the new baseline update_semantic_index diffs against next time.
+		for _, u := range hashUpdates {
+			engine.SetChapterHash(u.chapterID, u.locale, u.hash)
+		}
+		return map[string]interface{}{
+			"schemaVersion":       book.SchemaVersion,
+			"chunksIndexed":       len(allChunks),
+			"locales":             locales,
+			"boilerplateFiltered": filterStats,
+			"secretsScrubbed":     scrubReport,
+		}, nil
+	})
+
+	log.Printf("Indexing %d chunks for tenant %s in background (job %s)...", len(allChunks), t.id, j.ID)
+
+	return j, len(allChunks), nil
+}
+
+func handleBuildSemanticIndex(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	bookID := resolveBookID(req)
+	t, err := tenants.get(bookID)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Error getting index: %v", err)), nil
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	result, _ := json.MarshalIndent(index, "", "  ")
+	if _, err := t.ensureSemanticEngine(); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Semantic search not available for book %q: %v", t.id, err)), nil
+	}
+
+	localeParam := req.GetString("locale", "all")
+	if res := validateArgLength(localeParam, "locale", maxShortArgLength); res != nil {
+		return res, nil
+	}
+
+	j, chunkCount, err := submitIndexBuildJob(t, localeParam)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	locales := []string{localeParam}
+	if localeParam == "all" {
+		locales = []string{"es", "en"}
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf(
+		"Started indexing %d chunks from %d locale(s) as job %s. Poll job_status with this job_id for progress.",
+		chunkCount, len(locales), j.ID,
+	)), nil
+}
+
+func handleJobStatus(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	jobID := req.GetString("job_id", "")
+	if jobID == "" {
+		return mcp.NewToolResultError("job_id is required"), nil
+	}
+	if res := validateArgLength(jobID, "job_id", maxShortArgLength); res != nil {
+		return res, nil
+	}
+
+	j, ok := jobs.get(jobID)
+	if !ok {
+		return mcp.NewToolResultError(fmt.Sprintf("no job found with id %q", jobID)), nil
+	}
+
+	result, _ := json.MarshalIndent(j.status(), "", "  ")
 	return mcp.NewToolResultText(string(result)), nil
 }
 
-// ============================================
-// RESOURCE HANDLERS - LEVEL 2
-// ============================================
+func handleListJobs(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	result, _ := json.MarshalIndent(jobs.list(), "", "  ")
+	return mcp.NewToolResultText(string(result)), nil
+}
 
-func handleBookIndexResource(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+func handleCancelJob(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	jobID := req.GetString("job_id", "")
+	if jobID == "" {
+		return mcp.NewToolResultError("job_id is required"), nil
+	}
+	if res := validateArgLength(jobID, "job_id", maxShortArgLength); res != nil {
+		return res, nil
+	}
+
+	if err := jobs.cancel(jobID); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(fmt.Sprintf("Canceled job %s", jobID)), nil
+}
+
+func handleChapterResource(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
 	uri := req.Params.URI
 
-	// Extract locale from URI
-	locale := "es"
-	if strings.HasSuffix(uri, "/en") {
-		locale = "en"
+	// book://chapter/{locale}/{id}
+	rest := strings.TrimPrefix(uri, "book://chapter/")
+	parts := strings.SplitN(rest, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("invalid chapter resource uri: %s", uri)
 	}
+	locale, chapterID := parts[0], parts[1]
 
-	index, err := parser.GetBookIndex(locale)
+	chapter, err := parser.GetChapter(chapterID, locale)
 	if err != nil {
-		return nil, fmt.Errorf("error getting book index: %w", err)
+		return nil, fmt.Errorf("error getting chapter: %w", err)
 	}
+	// Resource URIs don't carry a tenant_id/book_id, so this always reads
+	// against the default tenant's book and coverage tracker.
+	coverageTrackers.get(defaultTenantID).record(chapterID, chapter.Name)
 
-	indexJSON, _ := json.MarshalIndent(index, "", "  ")
-
+	data, _ := json.MarshalIndent(chapter, "", "  ")
 	return []mcp.ResourceContents{
 		mcp.TextResourceContents{
 			URI:      uri,
 			MIMEType: "application/json",
-			Text:     string(indexJSON),
+			Text:     string(data),
 		},
 	}, nil
 }
 
-// ============================================
-// PROMPT HANDLERS - LEVEL 2
-// ============================================
-
-func handleExplainConceptPrompt(ctx context.Context, req mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
-	concept := "architecture"
-	locale := "es"
+func handleSectionResource(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	uri := req.Params.URI
 
-	if args := req.Params.Arguments; args != nil {
-		if c := args["concept"]; c != "" {
-			concept = c
-		}
-		if l := args["locale"]; l != "" {
-			locale = l
-		}
+	// book://chapter/{chapterId}/section/{tagId}
+	rest := strings.TrimPrefix(uri, "book://chapter/")
+	chapterID, sectionPart, ok := strings.Cut(rest, "/section/")
+	if !ok || chapterID == "" || sectionPart == "" {
+		return nil, fmt.Errorf("invalid section resource uri: %s", uri)
 	}
 
-	// Search for relevant content in the book
-	results, _ := parser.Search(concept, locale)
-
-	var contextSnippets string
-	if len(results) > 0 {
-		var snippets []string
-		for i, r := range results {
-			if i >= 5 { // Maximum 5 snippets
-				break
-			}
-			snippets = append(snippets, fmt.Sprintf("From '%s' (%s):\n%s", r.ChapterName, r.Section, r.Snippet))
-		}
-		contextSnippets = strings.Join(snippets, "\n\n---\n\n")
+	content, err := parser.GetSection(chapterID, sectionPart, "es")
+	if err != nil {
+		return nil, fmt.Errorf("error getting section: %w", err)
 	}
+	coverageTrackers.get(defaultTenantID).recordSection(chapterID, "", sectionPart)
 
-	promptText := fmt.Sprintf(`Based on the Gentleman Programming Book, explain the concept of "%s".
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      uri,
+			MIMEType: "text/plain",
+			Text:     content,
+		},
+	}, nil
+}
+
+func handleJobResultResource(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	uri := req.Params.URI
 
-Here is relevant content from the book:
+	// job://{id}/result
+	rest := strings.TrimPrefix(uri, "job://")
+	jobID := strings.TrimSuffix(rest, "/result")
 
-%s
+	j, ok := jobs.get(jobID)
+	if !ok {
+		return nil, fmt.Errorf("no job found with id %q", jobID)
+	}
 
-Please provide a clear and comprehensive explanation based on this content.`, concept, contextSnippets)
+	status := j.status()
+	if status.Status != "done" {
+		return nil, fmt.Errorf("job %q is not done yet (status: %s)", jobID, status.Status)
+	}
 
-	return &mcp.GetPromptResult{
-		Description: fmt.Sprintf("Explain '%s' from the Gentleman Programming Book", concept),
-		Messages: []mcp.PromptMessage{
-			{
-				Role:    mcp.RoleUser,
-				Content: mcp.NewTextContent(promptText),
-			},
+	resultJSON, _ := json.MarshalIndent(j.Result, "", "  ")
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      uri,
+			MIMEType: "application/json",
+			Text:     string(resultJSON),
 		},
 	}, nil
 }
 
-func handleComparePatternsPrompt(ctx context.Context, req mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
-	patternA := "clean architecture"
-	patternB := "hexagonal architecture"
-
-	if args := req.Params.Arguments; args != nil {
-		if a := args["pattern_a"]; a != "" {
-			patternA = a
-		}
-		if b := args["pattern_b"]; b != "" {
-			patternB = b
-		}
+func handleSemanticStatus(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	status := map[string]interface{}{
+		"schemaVersion": book.SchemaVersion,
+		"available":     semanticEngine != nil,
+		"indexed":       false,
+		"chunks":        0,
+		"provider":      "none",
+		"cachedQueries": 0,
 	}
 
-	// Search content for both patterns
-	resultsA, _ := parser.Search(patternA, "es")
-	resultsB, _ := parser.Search(patternB, "es")
+	if semanticEngine != nil {
+		status["indexed"] = semanticEngine.IsIndexed()
+		status["chunks"] = semanticEngine.ChunkCount()
+		status["cachedQueries"] = semanticEngine.CachedQueryCount()
 
-	var contextA, contextB string
-	if len(resultsA) > 0 {
-		var snippets []string
-		for i, r := range resultsA {
-			if i >= 3 {
-				break
-			}
-			snippets = append(snippets, r.Snippet)
+		if os.Getenv("OPENAI_API_KEY") != "" {
+			status["provider"] = "openai"
+		} else {
+			status["provider"] = "ollama"
 		}
-		contextA = strings.Join(snippets, "\n")
 	}
-	if len(resultsB) > 0 {
-		var snippets []string
-		for i, r := range resultsB {
-			if i >= 3 {
-				break
-			}
-			snippets = append(snippets, r.Snippet)
+
+	if autoIndexJobID != "" {
+		if j, ok := jobs.get(autoIndexJobID); ok {
+			status["autoIndexJob"] = j.status()
 		}
-		contextB = strings.Join(snippets, "\n")
 	}
 
-	promptText := fmt.Sprintf(`Compare and contrast "%s" and "%s" based on the Gentleman Programming Book.
-
-Content about %s:
-%s
+	result, _ := json.MarshalIndent(status, "", "  ")
+	return mcp.NewToolResultText(string(result)), nil
+}
 
-Content about %s:
-%s
+func handleIndexQualityReport(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if semanticEngine == nil {
+		return mcp.NewToolResultError("Semantic search is not available."), nil
+	}
 
-Please provide a detailed comparison including:
-1. Key differences
-2. Similarities
-3. When to use each one
-4. Pros and cons`, patternA, patternB, patternA, contextA, patternB, contextB)
+	report, err := semanticEngine.IndexQualityReport()
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
-	return &mcp.GetPromptResult{
-		Description: fmt.Sprintf("Compare '%s' vs '%s'", patternA, patternB),
-		Messages: []mcp.PromptMessage{
-			{
-				Role:    mcp.RoleUser,
-				Content: mcp.NewTextContent(promptText),
-			},
-		},
-	}, nil
+	result, _ := json.MarshalIndent(report, "", "  ")
+	return mcp.NewToolResultText(string(result)), nil
 }
 
-func handleSummarizeChapterPrompt(ctx context.Context, req mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
-	chapterID := ""
-	locale := "es"
-
-	if args := req.Params.Arguments; args != nil {
-		if id := args["chapter_id"]; id != "" {
-			chapterID = id
-		}
-		if l := args["locale"]; l != "" {
-			locale = l
-		}
+func handleExportIndex(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if semanticEngine == nil {
+		return mcp.NewToolResultError("Semantic search is not available."), nil
+	}
+	if !semanticEngine.IsIndexed() {
+		return mcp.NewToolResultError("Semantic index not built. Run 'build_semantic_index' first."), nil
 	}
 
-	if chapterID == "" {
-		return &mcp.GetPromptResult{
-			Description: "Error: chapter_id is required",
-			Messages: []mcp.PromptMessage{
-				{
-					Role:    mcp.RoleUser,
-					Content: mcp.NewTextContent("Please provide a chapter_id to summarize."),
-				},
-			},
-		}, nil
+	locale := req.GetString("locale", "")
+	if res := validateArgLength(locale, "locale", maxShortArgLength); res != nil {
+		return res, nil
 	}
+	requestedFormat := embeddings.ExportFormat(req.GetString("format", string(embeddings.ExportFormatJSONL)))
 
-	chapter, err := parser.GetChapter(chapterID, locale)
+	exported, err := semanticEngine.ExportChunks(locale, requestedFormat)
 	if err != nil {
-		return &mcp.GetPromptResult{
-			Description: fmt.Sprintf("Error: %v", err),
-			Messages: []mcp.PromptMessage{
-				{
-					Role:    mcp.RoleUser,
-					Content: mcp.NewTextContent(fmt.Sprintf("Could not find chapter: %s", chapterID)),
-				},
-			},
-		}, nil
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	// Limit content if too long
-	content := chapter.Content
-	if len(content) > 10000 {
-		content = content[:10000] + "\n\n... [content truncated]"
+	if requestedFormat == embeddings.ExportFormatParquet {
+		// ExportChunks silently falls back to JSONL for parquet (see
+		// export.go); surface that in the result itself, not just a log
+		// line, so a caller that asked for parquet can tell it didn't get
+		// it instead of trying to parse JSONL as Parquet.
+		log.Printf("export_index: parquet is not implemented yet, falling back to jsonl")
+		warned, _ := json.Marshal(struct {
+			Warning string `json:"warning"`
+			Format  string `json:"format"`
+			Data    string `json:"data"`
+		}{
+			Warning: "parquet export is not implemented; falling back to jsonl. 'data' below is JSONL, not Parquet.",
+			Format:  string(embeddings.ExportFormatJSONL),
+			Data:    exported,
+		})
+		return mcp.NewToolResultText(string(warned)), nil
 	}
 
-	promptText := fmt.Sprintf(`Please provide a comprehensive summary of the following chapter from the Gentleman Programming Book:
+	return mcp.NewToolResultText(exported), nil
+}
 
-# %s
+func handleImportIndex(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if semanticEngine == nil {
+		return mcp.NewToolResultError("Semantic search is not available."), nil
+	}
 
-%s
+	// data is an intentional bulk-import payload -- a whole exported index --
+	// so unlike other string args it's not capped at maxQueryLength/
+	// maxShortArgLength; its size is bounded by the MCP transport itself.
+	data := req.GetString("data", "")
+	if data == "" {
+		return mcp.NewToolResultError("data is required"), nil
+	}
+	format := embeddings.ExportFormat(req.GetString("format", string(embeddings.ExportFormatJSONL)))
 
-Include:
-1. Main concepts covered
-2. Key takeaways
-3. Practical applications`, chapter.Name, content)
+	count, err := semanticEngine.ImportChunks(data, format)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
-	return &mcp.GetPromptResult{
-		Description: fmt.Sprintf("Summary of '%s'", chapter.Name),
-		Messages: []mcp.PromptMessage{
-			{
-				Role:    mcp.RoleUser,
-				Content: mcp.NewTextContent(promptText),
-			},
-		},
-	}, nil
+	return mcp.NewToolResultText(fmt.Sprintf("Imported %d chunks. Total indexed chunks: %d.", count, semanticEngine.ChunkCount())), nil
 }
 
-// ============================================
-// SEMANTIC SEARCH HANDLERS - LEVEL 3
-// ============================================
+func handleSaveIndexToDisk(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if semanticEngine == nil {
+		return mcp.NewToolResultError("Semantic search is not available."), nil
+	}
+	if !semanticEngine.IsIndexed() {
+		return mcp.NewToolResultError("Semantic index not built. Run 'build_semantic_index' first."), nil
+	}
 
-func initSemanticEngine() {
-	// Try OpenAI first, then Ollama
-	var err error
+	path := req.GetString("path", "")
+	if path == "" {
+		return mcp.NewToolResultError("path is required"), nil
+	}
+	if res := validateArgLength(path, "path", maxPathLength); res != nil {
+		return res, nil
+	}
 
-	if os.Getenv("OPENAI_API_KEY") != "" {
-		semanticEngine, err = embeddings.NewSemanticEngine(embeddings.ProviderOpenAI)
-		if err == nil {
-			log.Println("Semantic search enabled with OpenAI")
-			return
-		}
-		log.Printf("OpenAI not available: %v", err)
+	if err := semanticEngine.SaveToFile(path); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	// Try Ollama
-	semanticEngine, err = embeddings.NewSemanticEngine(embeddings.ProviderOllama)
-	if err == nil && semanticEngine.IsAvailable() {
-		log.Println("Semantic search enabled with Ollama")
-		return
+	encrypted := os.Getenv("INDEX_ENCRYPTION_KEY") != ""
+	return mcp.NewToolResultText(fmt.Sprintf("Saved index to %s (encrypted=%v).", path, encrypted)), nil
+}
+
+func handleLoadIndexFromDisk(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if semanticEngine == nil {
+		return mcp.NewToolResultError("Semantic search is not available."), nil
+	}
+
+	path := req.GetString("path", "")
+	if path == "" {
+		return mcp.NewToolResultError("path is required"), nil
+	}
+	if res := validateArgLength(path, "path", maxPathLength); res != nil {
+		return res, nil
+	}
+
+	count, err := semanticEngine.LoadFromFile(path)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	log.Println("Semantic search not available (no OpenAI key or Ollama)")
-	semanticEngine = nil
+	return mcp.NewToolResultText(fmt.Sprintf("Loaded %d chunks from %s. Total indexed chunks: %d.", count, path, semanticEngine.ChunkCount())), nil
 }
 
-func handleSemanticSearch(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+func handleFetchPrebuiltIndex(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	if semanticEngine == nil {
-		return mcp.NewToolResultError("Semantic search not available. Set OPENAI_API_KEY or ensure Ollama is running."), nil
+		return mcp.NewToolResultError("Semantic search is not available."), nil
 	}
 
-	if !semanticEngine.IsIndexed() {
-		return mcp.NewToolResultError("Semantic index not built. Run 'build_semantic_index' first."), nil
+	manifestURL := req.GetString("manifest_url", os.Getenv("PREBUILT_INDEX_MANIFEST_URL"))
+	if manifestURL == "" {
+		return mcp.NewToolResultError("No manifest_url given and PREBUILT_INDEX_MANIFEST_URL is not set."), nil
 	}
+	if res := validateArgLength(manifestURL, "manifest_url", maxPathLength); res != nil {
+		return res, nil
+	}
+	force := req.GetBool("force", false)
 
-	query := req.GetString("query", "")
-	locale := req.GetString("locale", "es")
-	topK := req.GetInt("top_k", 5)
+	manifest, err := embeddings.FetchPrebuiltManifest(ctx, manifestURL)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("fetching manifest: %v", err)), nil
+	}
 
-	if query == "" {
-		return mcp.NewToolResultError("query is required"), nil
+	compat := embeddings.CheckPrebuiltIndexCompatibility(manifest, bookContentCommit(bookPath), string(semanticEngine.Provider()))
+	if !force && (!compat.BookCommitMatches || !compat.EmbeddingModelMatches) {
+		return mcp.NewToolResultError(fmt.Sprintf(
+			"Pre-built index looks stale for this server (manifest bookCommit=%q embeddingModel=%q). Pass force=true to load it anyway.",
+			compat.ManifestBookCommit, compat.ManifestEmbeddingModel,
+		)), nil
 	}
 
-	results, err := semanticEngine.Search(ctx, query, locale, topK)
+	data, err := embeddings.DownloadPrebuiltIndex(ctx, manifest)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Search error: %v", err)), nil
+		return mcp.NewToolResultError(fmt.Sprintf("downloading index: %v", err)), nil
 	}
 
-	if len(results) == 0 {
-		return mcp.NewToolResultText("No semantic matches found for: " + query), nil
+	count, err := semanticEngine.ImportChunks(data, manifest.Format)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("loading downloaded index: %v", err)), nil
 	}
 
-	resultJSON, _ := json.MarshalIndent(results, "", "  ")
-	return mcp.NewToolResultText(string(resultJSON)), nil
+	return mcp.NewToolResultText(fmt.Sprintf(
+		"Loaded pre-built index: %d chunks (manifest bookCommit=%q embeddingModel=%q, bookCommitMatches=%v embeddingModelMatches=%v).",
+		count, manifest.BookCommit, manifest.EmbeddingModel, compat.BookCommitMatches, compat.EmbeddingModelMatches,
+	)), nil
 }
 
-func handleBuildSemanticIndex(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	if semanticEngine == nil {
-		return mcp.NewToolResultError("Semantic search not available. Set OPENAI_API_KEY or ensure Ollama is running."), nil
+// summaryCharBudget is the total character budget for the content fed into
+// the summarize_chapter prompt.
+const summaryCharBudget = 10000
+
+// budgetedChapterSummary selects a representative slice of a chapter within
+// charBudget instead of a hard cutoff at the start. Every section heading is
+// kept, and each section's paragraphs are sampled proportionally to the
+// overall budget, so a long chapter's later sections are still represented
+// instead of being silently dropped.
+func budgetedChapterSummary(content string, charBudget int) string {
+	if len(content) <= charBudget {
+		return content
 	}
 
-	localeParam := req.GetString("locale", "all")
+	headerPattern := regexp.MustCompile(`(?m)^##?\s+.+$`)
+	headerIndexes := headerPattern.FindAllStringIndex(content, -1)
 
-	var locales []string
-	if localeParam == "all" {
-		locales = []string{"es", "en"}
-	} else {
-		locales = []string{localeParam}
+	if len(headerIndexes) == 0 {
+		// No headings to anchor on: fall back to a single truncated block.
+		return book.TruncateWithSuffix(content, charBudget, "\n\n... [content truncated]")
 	}
 
-	var allChunks []embeddings.Chunk
-	chunkID := 0
+	type section struct {
+		heading string
+		body    string
+	}
 
-	for _, locale := range locales {
-		chapters, err := parser.ListChapters(locale)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Error reading chapters for %s: %v", locale, err)), nil
+	var sections []section
+	for i, idx := range headerIndexes {
+		end := len(content)
+		if i+1 < len(headerIndexes) {
+			end = headerIndexes[i+1][0]
 		}
+		heading := content[idx[0]:idx[1]]
+		body := strings.TrimSpace(content[idx[1]:end])
+		sections = append(sections, section{heading: heading, body: body})
+	}
 
-		for _, chapter := range chapters {
-			// Split content into chunks (by sections or paragraphs)
-			chunks := splitIntoChunks(chapter.Content, chapter.ID, chapter.Name, locale, &chunkID)
-			allChunks = append(allChunks, chunks...)
+	perSectionBudget := charBudget / len(sections)
+
+	var out strings.Builder
+	for _, s := range sections {
+		out.WriteString(s.heading)
+		out.WriteString("\n")
+
+		body := s.body
+		if len(body) > perSectionBudget {
+			// Cut at the nearest paragraph boundary within budget, not mid-sentence.
+			cut := strings.LastIndex(body[:perSectionBudget], "\n\n")
+			if cut <= 0 {
+				cut = perSectionBudget
+				// Never split a multi-byte UTF-8 character (e.g. an
+				// accented Spanish letter) in half.
+				for cut > 0 && !utf8.RuneStart(body[cut]) {
+					cut--
+				}
+			}
+			body = body[:cut] + "\n[...]"
 		}
+		out.WriteString(body)
+		out.WriteString("\n\n")
 	}
 
-	log.Printf("Indexing %d chunks...", len(allChunks))
+	return strings.TrimSpace(out.String())
+}
 
-	if err := semanticEngine.IndexChunks(ctx, allChunks); err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Error indexing: %v", err)), nil
-	}
+// textSpan is a piece of text together with its character offsets within
+// the larger document it was extracted from, so a chunk built from it can be
+// traced back to its exact source location.
+type textSpan struct {
+	Text  string
+	Start int
+	End   int
+}
 
-	return mcp.NewToolResultText(fmt.Sprintf("Successfully indexed %d chunks from %d locale(s)", len(allChunks), len(locales))), nil
+// trimSpan strips leading/trailing whitespace from s, returning the
+// remaining text with offsets adjusted to stay relative to base (s's own
+// start offset within the original document).
+func trimSpan(s string, base int) textSpan {
+	left := strings.TrimLeft(s, " \t\r\n")
+	leadingTrim := len(s) - len(left)
+	trimmed := strings.TrimRight(left, " \t\r\n")
+	return textSpan{Text: trimmed, Start: base + leadingTrim, End: base + leadingTrim + len(trimmed)}
 }
 
-func handleSemanticStatus(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	status := map[string]interface{}{
-		"available": semanticEngine != nil,
-		"indexed":   false,
-		"chunks":    0,
-		"provider":  "none",
+// truncateSpan caps span's text at maxRunes runes, keeping Start/End
+// aligned with the text that's actually kept (not the appended "..."). The
+// cut is rune-aware so it never splits a multi-byte character (e.g. an
+// accented Spanish letter) in the middle.
+func truncateSpan(span textSpan, maxRunes int) textSpan {
+	runes := []rune(span.Text)
+	if len(runes) <= maxRunes {
+		return span
 	}
+	kept := string(runes[:maxRunes])
+	return textSpan{Text: kept + "...", Start: span.Start, End: span.Start + len(kept)}
+}
 
-	if semanticEngine != nil {
-		status["indexed"] = semanticEngine.IsIndexed()
-		status["chunks"] = semanticEngine.ChunkCount()
-
-		if os.Getenv("OPENAI_API_KEY") != "" {
-			status["provider"] = "openai"
-		} else {
-			status["provider"] = "ollama"
-		}
+// lineAt converts a character offset into content into a 1-based line
+// number, matching Parser.SearchExplain's LineNumber convention.
+func lineAt(content string, charOffset int) int {
+	if charOffset > len(content) {
+		charOffset = len(content)
 	}
+	return strings.Count(content[:charOffset], "\n") + 1
+}
 
-	result, _ := json.MarshalIndent(status, "", "  ")
-	return mcp.NewToolResultText(string(result)), nil
+// newChunk builds a Chunk from span, deriving its line-anchored citation
+// fields from span's offsets into fullContent.
+func newChunk(id, chapterID, chapterName, section, locale, fullContent string, span textSpan) embeddings.Chunk {
+	return embeddings.Chunk{
+		ID:          id,
+		ChapterID:   chapterID,
+		ChapterName: chapterName,
+		Section:     section,
+		Content:     span.Text,
+		Locale:      locale,
+		StartLine:   lineAt(fullContent, span.Start),
+		EndLine:     lineAt(fullContent, span.End),
+		StartChar:   span.Start,
+		EndChar:     span.End,
+	}
 }
 
-// splitIntoChunks splits content into manageable chunks
+// splitIntoChunks splits content into manageable chunks, recording each
+// chunk's position in content so SemanticResult can cite an exact line
+// range instead of just a chapter and section name.
 func splitIntoChunks(content string, chapterID, chapterName, locale string, idCounter *int) []embeddings.Chunk {
 	var chunks []embeddings.Chunk
+	chunkSize := chunkSizeChars()
+	chunkOverlap := chunkOverlapChars()
 
 	// Split by sections (## headers)
 	headerPattern := regexp.MustCompile(`(?m)^##\s+(.+)$`)
-	sections := headerPattern.Split(content, -1)
-	headers := headerPattern.FindAllStringSubmatch(content, -1)
+	matches := headerPattern.FindAllStringSubmatchIndex(content, -1)
+
+	introEnd := len(content)
+	if len(matches) > 0 {
+		introEnd = matches[0][0]
+	}
 
 	// Add content before the first header
-	if len(sections) > 0 && strings.TrimSpace(sections[0]) != "" {
+	if intro := trimSpan(content[:introEnd], 0); intro.Text != "" {
 		*idCounter++
-		chunks = append(chunks, embeddings.Chunk{
-			ID:          fmt.Sprintf("chunk_%d", *idCounter),
-			ChapterID:   chapterID,
-			ChapterName: chapterName,
-			Section:     "Introduction",
-			Content:     truncateContent(strings.TrimSpace(sections[0]), 1000),
-			Locale:      locale,
-		})
+		chunks = append(chunks, newChunk(fmt.Sprintf("chunk_%d", *idCounter), chapterID, chapterName, "Introduction", locale, content, truncateSpan(intro, chunkSize)))
 	}
 
 	// Process each section
-	for i, header := range headers {
-		sectionContent := ""
-		if i+1 < len(sections) {
-			sectionContent = strings.TrimSpace(sections[i+1])
+	for i, m := range matches {
+		sectionName := content[m[2]:m[3]]
+
+		sectionStart := m[1]
+		sectionEnd := len(content)
+		if i+1 < len(matches) {
+			sectionEnd = matches[i+1][0]
 		}
 
-		if sectionContent == "" {
+		section := trimSpan(content[sectionStart:sectionEnd], sectionStart)
+		if section.Text == "" {
 			continue
 		}
 
 		// If content is too long, split into smaller chunks
-		sectionName := header[1]
-		contentChunks := splitLongContent(sectionContent, 1000)
+		spans := splitLongContentSpans(section, chunkSize, chunkOverlap)
 
-		for j, c := range contentChunks {
+		for j, span := range spans {
 			*idCounter++
 			suffix := ""
-			if len(contentChunks) > 1 {
+			if len(spans) > 1 {
 				suffix = fmt.Sprintf(" (part %d)", j+1)
 			}
-			chunks = append(chunks, embeddings.Chunk{
-				ID:          fmt.Sprintf("chunk_%d", *idCounter),
-				ChapterID:   chapterID,
-				ChapterName: chapterName,
-				Section:     sectionName + suffix,
-				Content:     c,
-				Locale:      locale,
-			})
+			chunks = append(chunks, newChunk(fmt.Sprintf("chunk_%d", *idCounter), chapterID, chapterName, sectionName+suffix, locale, content, span))
 		}
 	}
 
-	return chunks
+	return mergeSmallChunks(chunks, minChunkChars())
 }
 
-func splitLongContent(content string, maxLen int) []string {
-	if len(content) <= maxLen {
-		return []string{content}
+// defaultMinChunkChars is the floor below which a chunk (typically a
+// one-line section or a stray header with no body) is merged into a
+// neighbor instead of being embedded and searched on its own.
+const defaultMinChunkChars = 80
+
+// CHUNK_MIN_CHARS overrides defaultMinChunkChars, e.g. for books with
+// unusually terse sections.
+func minChunkChars() int {
+	if v := os.Getenv("CHUNK_MIN_CHARS"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			return n
+		}
 	}
+	return defaultMinChunkChars
+}
 
-	var chunks []string
-	paragraphs := strings.Split(content, "\n\n")
+// mergeSmallChunks folds any chunk shorter than minLen into an adjacent
+// chunk, so tiny sections don't waste a slot in a top-K search result on
+// their own. Each tiny chunk merges into its preceding chunk; a tiny chunk
+// with no predecessor (the very first chunk in the chapter) merges forward
+// into the one after it instead.
+func mergeSmallChunks(chunks []embeddings.Chunk, minLen int) []embeddings.Chunk {
+	if minLen <= 0 || len(chunks) <= 1 {
+		return chunks
+	}
+
+	merged := make([]embeddings.Chunk, 0, len(chunks))
+	for _, c := range chunks {
+		if len(merged) > 0 && len(c.Content) < minLen {
+			mergeChunkInto(&merged[len(merged)-1], c)
+			continue
+		}
+		merged = append(merged, c)
+	}
+
+	if len(merged) > 1 && len(merged[0].Content) < minLen {
+		mergeChunkInto(&merged[1], merged[0])
+		merged = merged[1:]
+	}
+
+	return merged
+}
+
+// mergeChunkInto absorbs extra into target, extending target's line/char
+// range and concatenating content in document order regardless of which of
+// the two came first.
+func mergeChunkInto(target *embeddings.Chunk, extra embeddings.Chunk) {
+	if extra.StartChar < target.StartChar {
+		target.StartChar = extra.StartChar
+		target.StartLine = extra.StartLine
+		target.Content = extra.Content + "\n\n" + target.Content
+	} else {
+		target.Content = target.Content + "\n\n" + extra.Content
+	}
+	if extra.EndChar > target.EndChar {
+		target.EndChar = extra.EndChar
+		target.EndLine = extra.EndLine
+	}
+}
+
+// splitLongContentSpans breaks span into smaller, paragraph-aligned spans of
+// at most maxLen characters each, preserving offsets relative to span's
+// original document. When overlapChars is positive, each span after the
+// first is prefixed with up to that many trailing characters of the span
+// before it, so a passage straddling a chunk boundary still appears in full
+// in at least one chunk.
+func splitLongContentSpans(span textSpan, maxLen, overlapChars int) []textSpan {
+	if len(span.Text) <= maxLen {
+		return []textSpan{span}
+	}
+
+	paragraphs := strings.Split(span.Text, "\n\n")
+	var spans []textSpan
+	cursor := 0
+	currentStart := 0
 	current := ""
 
+	flush := func() {
+		if current == "" {
+			return
+		}
+		spans = append(spans, trimSpan(current, span.Start+currentStart))
+	}
+
 	for _, p := range paragraphs {
-		if len(current)+len(p) > maxLen && current != "" {
-			chunks = append(chunks, strings.TrimSpace(current))
+		pStart := cursor
+		cursor += len(p) + 2 // account for the "\n\n" separator Split consumed
+
+		if len(p) > maxLen {
+			// A single paragraph (a giant wall of prose, or a code-heavy
+			// section) already exceeds the limit on its own -- flush what's
+			// pending and fall back to sentence-boundary splitting instead
+			// of swallowing it whole.
+			flush()
+			current = ""
+			spans = append(spans, splitParagraphBySentences(p, span.Start+pStart, maxLen)...)
+			continue
+		}
+
+		if current != "" && len(current)+len(p) > maxLen {
+			flush()
+			current = ""
+		}
+		if current == "" {
+			currentStart = pStart
 			current = p
 		} else {
-			if current != "" {
-				current += "\n\n"
-			}
-			current += p
+			current += "\n\n" + p
+		}
+	}
+	flush()
+
+	return applyChunkOverlap(spans, overlapChars)
+}
+
+// applyChunkOverlap prefixes each span after the first with up to
+// overlapChars trailing characters of the span before it, so adjacent chunks
+// share context instead of cutting cleanly at the boundary. Offsets are
+// widened to match: a span's Start moves back to cover the borrowed text.
+func applyChunkOverlap(spans []textSpan, overlapChars int) []textSpan {
+	if overlapChars <= 0 || len(spans) < 2 {
+		return spans
+	}
+
+	out := make([]textSpan, len(spans))
+	out[0] = spans[0]
+	for i := 1; i < len(spans); i++ {
+		prev := spans[i-1]
+		overlap := lastRunes(prev.Text, overlapChars)
+		if overlap == "" {
+			out[i] = spans[i]
+			continue
+		}
+
+		start := spans[i].Start - len(overlap) - 2 // 2 accounts for the "\n\n" joiner below
+		if start < prev.Start {
+			start = prev.Start
+		}
+		out[i] = textSpan{
+			Text:  overlap + "\n\n" + spans[i].Text,
+			Start: start,
+			End:   spans[i].End,
+		}
+	}
+	return out
+}
+
+// lastRunes returns the trailing portion of s spanning at most n
+// characters, cut on a rune boundary so it never splits a multi-byte
+// character.
+func lastRunes(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[len(runes)-n:])
+}
+
+// codeFencePattern matches a fenced code block (```...```), which must
+// never be split across chunks -- doing so would hand the embedding model
+// (and any UI that renders search hits) an unterminated code fence.
+var codeFencePattern = regexp.MustCompile("(?s)```.*?```")
+
+// sentenceBoundaryPattern matches the end of a sentence, including the
+// trailing whitespace, so splitting on it never drops or duplicates text.
+var sentenceBoundaryPattern = regexp.MustCompile(`[.!?]+(\s+|$)`)
+
+// splitParagraphBySentences splits text (a single paragraph that's too long
+// to keep whole) into spans of at most maxLen characters, breaking on
+// sentence boundaries rather than mid-sentence. Code fences are treated as
+// atomic: they're never split, even if a fence alone exceeds maxLen.
+func splitParagraphBySentences(text string, baseOffset int, maxLen int) []textSpan {
+	type segment struct {
+		text    string
+		start   int
+		isFence bool
+	}
+
+	var segments []segment
+	cursor := 0
+	for _, f := range codeFencePattern.FindAllStringIndex(text, -1) {
+		if f[0] > cursor {
+			segments = append(segments, segment{text: text[cursor:f[0]], start: cursor})
+		}
+		segments = append(segments, segment{text: text[f[0]:f[1]], start: f[0], isFence: true})
+		cursor = f[1]
+	}
+	if cursor < len(text) {
+		segments = append(segments, segment{text: text[cursor:], start: cursor})
+	}
+
+	var spans []textSpan
+	current := ""
+	currentStart := 0
+
+	flush := func() {
+		if strings.TrimSpace(current) == "" {
+			return
 		}
+		spans = append(spans, trimSpan(current, baseOffset+currentStart))
 	}
 
-	if current != "" {
-		chunks = append(chunks, strings.TrimSpace(current))
+	for _, seg := range segments {
+		if seg.isFence {
+			flush()
+			current = ""
+			spans = append(spans, trimSpan(seg.text, baseOffset+seg.start))
+			continue
+		}
+
+		localCursor := 0
+		for _, s := range splitIntoSentences(seg.text) {
+			sStart := seg.start + localCursor
+			localCursor += len(s)
+
+			if current != "" && len(current)+len(s) > maxLen {
+				flush()
+				current = ""
+			}
+			if current == "" {
+				currentStart = sStart
+				current = s
+			} else {
+				current += s
+			}
+		}
 	}
+	flush()
 
-	return chunks
+	return spans
 }
 
-func truncateContent(content string, maxLen int) string {
-	if len(content) <= maxLen {
-		return content
+// splitIntoSentences splits text into sentences on ., !, or ? boundaries.
+// Each returned piece retains its trailing whitespace, so joining the
+// pieces back together reproduces text exactly.
+func splitIntoSentences(text string) []string {
+	matches := sentenceBoundaryPattern.FindAllStringIndex(text, -1)
+	if len(matches) == 0 {
+		return []string{text}
+	}
+
+	var sentences []string
+	cursor := 0
+	for _, m := range matches {
+		sentences = append(sentences, text[cursor:m[1]])
+		cursor = m[1]
+	}
+	if cursor < len(text) {
+		sentences = append(sentences, text[cursor:])
 	}
-	return content[:maxLen] + "..."
+	return sentences
 }
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Alan-TheGentleman/gentleman-book-mcp/internal/book"
+)
+
+// ChapterOutline is one chapter's structural fingerprint for outline_diff:
+// enough to detect added/removed/renamed/reordered chapters and section
+// changes without diffing full chapter content.
+type ChapterOutline struct {
+	ID       string   `json:"id"`
+	Name     string   `json:"name"`
+	Order    int      `json:"order"`
+	Sections []string `json:"sections"`
+}
+
+// OutlineDiffEntry is one chapter-level structural change between two book
+// snapshots.
+type OutlineDiffEntry struct {
+	ChapterID       string   `json:"chapterId"`
+	Change          string   `json:"change"` // "added", "removed", "renamed", "reordered", "sections_changed"
+	OldName         string   `json:"oldName,omitempty"`
+	NewName         string   `json:"newName,omitempty"`
+	OldOrder        int      `json:"oldOrder,omitempty"`
+	NewOrder        int      `json:"newOrder,omitempty"`
+	AddedSections   []string `json:"addedSections,omitempty"`
+	RemovedSections []string `json:"removedSections,omitempty"`
+}
+
+// OutlineDiffReport is the JSON shape printed by the outline_diff command.
+type OutlineDiffReport struct {
+	SchemaVersion int                `json:"schemaVersion"`
+	Locale        string             `json:"locale"`
+	Old           string             `json:"old"`
+	New           string             `json:"new"`
+	Changes       []OutlineDiffEntry `json:"changes"`
+}
+
+// runOutlineDiff implements the `outline_diff` CLI subcommand: it resolves
+// --old and --new (each a book content directory, or a git ref checked out
+// through a temporary worktree) and prints a structural diff of their
+// chapters/sections as JSON, for release notes and deciding which chapters
+// need update_semantic_index.
+func runOutlineDiff(args []string) {
+	fs := flag.NewFlagSet("outline_diff", flag.ExitOnError)
+	oldRef := fs.String("old", "", "Old snapshot: a book content directory path, or a git ref (tag, SHA, HEAD~5, ...)")
+	newRef := fs.String("new", "", "New snapshot: a book content directory path, or a git ref. Defaults to the current BOOK_PATH")
+	locale := fs.String("locale", "es", "Locale to diff")
+	fs.Parse(args)
+
+	if *oldRef == "" {
+		fmt.Println("outline_diff requires --old (a book content directory path or a git ref)")
+		os.Exit(1)
+	}
+	if *newRef == "" {
+		*newRef = bookPath
+	}
+
+	oldPath, cleanupOld, err := resolveOutlineSnapshot(*oldRef, bookPath)
+	if err != nil {
+		fmt.Printf("Could not resolve --old %q: %v\n", *oldRef, err)
+		os.Exit(1)
+	}
+	defer cleanupOld()
+
+	newPath, cleanupNew, err := resolveOutlineSnapshot(*newRef, bookPath)
+	if err != nil {
+		fmt.Printf("Could not resolve --new %q: %v\n", *newRef, err)
+		os.Exit(1)
+	}
+	defer cleanupNew()
+
+	report, err := buildOutlineDiff(oldPath, newPath, *locale)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	data, _ := json.MarshalIndent(report, "", "  ")
+	fmt.Println(string(data))
+}
+
+// resolveOutlineSnapshot returns a book content directory for ref: ref
+// itself if it's already a directory, otherwise ref is treated as a git
+// ref and checked out into a temporary worktree of the git repo containing
+// currentBookPath, mapped back onto that repo's equivalent book
+// subdirectory. The returned cleanup func removes any worktree it created.
+func resolveOutlineSnapshot(ref, currentBookPath string) (string, func(), error) {
+	noop := func() {}
+
+	if info, err := os.Stat(ref); err == nil && info.IsDir() {
+		return ref, noop, nil
+	}
+
+	repoRoot, err := gitRepoRoot(currentBookPath)
+	if err != nil {
+		return "", noop, fmt.Errorf("%q is not a directory, and no git repo was found containing %q to resolve it as a ref: %w", ref, currentBookPath, err)
+	}
+	relBookPath, err := filepath.Rel(repoRoot, currentBookPath)
+	if err != nil {
+		return "", noop, err
+	}
+
+	tmpDir, err := os.MkdirTemp("", "outline-diff-*")
+	if err != nil {
+		return "", noop, err
+	}
+
+	cmd := exec.Command("git", "-C", repoRoot, "worktree", "add", "--detach", tmpDir, ref)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		os.RemoveAll(tmpDir)
+		return "", noop, fmt.Errorf("git worktree add %s %s: %w\n%s", tmpDir, ref, err, out)
+	}
+
+	cleanup := func() {
+		exec.Command("git", "-C", repoRoot, "worktree", "remove", "--force", tmpDir).Run()
+		os.RemoveAll(tmpDir)
+	}
+	return filepath.Join(tmpDir, relBookPath), cleanup, nil
+}
+
+func gitRepoRoot(path string) (string, error) {
+	out, err := exec.Command("git", "-C", path, "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// buildOutline reads every chapter in bookDir/locale and reduces each to its
+// structural fingerprint.
+func buildOutline(bookDir, locale string) ([]ChapterOutline, error) {
+	p := book.NewParser(bookDir)
+	chapters, err := p.ListChapters(locale)
+	if err != nil {
+		return nil, err
+	}
+
+	outlines := make([]ChapterOutline, 0, len(chapters))
+	for _, ch := range chapters {
+		sections := make([]string, len(ch.TitleList))
+		for i, s := range ch.TitleList {
+			sections[i] = s.Name
+		}
+		outlines = append(outlines, ChapterOutline{ID: ch.ID, Name: ch.Name, Order: ch.Order, Sections: sections})
+	}
+	return outlines, nil
+}
+
+// buildOutlineDiff compares oldDir and newDir's outlines for locale.
+func buildOutlineDiff(oldDir, newDir, locale string) (*OutlineDiffReport, error) {
+	oldOutlines, err := buildOutline(oldDir, locale)
+	if err != nil {
+		return nil, fmt.Errorf("reading old snapshot: %w", err)
+	}
+	newOutlines, err := buildOutline(newDir, locale)
+	if err != nil {
+		return nil, fmt.Errorf("reading new snapshot: %w", err)
+	}
+
+	oldByID := make(map[string]ChapterOutline, len(oldOutlines))
+	for _, o := range oldOutlines {
+		oldByID[o.ID] = o
+	}
+	newByID := make(map[string]ChapterOutline, len(newOutlines))
+	for _, n := range newOutlines {
+		newByID[n.ID] = n
+	}
+
+	var changes []OutlineDiffEntry
+	for _, n := range newOutlines {
+		o, existed := oldByID[n.ID]
+		if !existed {
+			changes = append(changes, OutlineDiffEntry{ChapterID: n.ID, Change: "added", NewName: n.Name, NewOrder: n.Order})
+			continue
+		}
+		if o.Name != n.Name {
+			changes = append(changes, OutlineDiffEntry{ChapterID: n.ID, Change: "renamed", OldName: o.Name, NewName: n.Name})
+		}
+		if o.Order != n.Order {
+			changes = append(changes, OutlineDiffEntry{ChapterID: n.ID, Change: "reordered", OldOrder: o.Order, NewOrder: n.Order})
+		}
+		added, removed := diffOutlineSections(o.Sections, n.Sections)
+		if len(added) > 0 || len(removed) > 0 {
+			changes = append(changes, OutlineDiffEntry{ChapterID: n.ID, Change: "sections_changed", AddedSections: added, RemovedSections: removed})
+		}
+	}
+	for _, o := range oldOutlines {
+		if _, stillExists := newByID[o.ID]; !stillExists {
+			changes = append(changes, OutlineDiffEntry{ChapterID: o.ID, Change: "removed", OldName: o.Name, OldOrder: o.Order})
+		}
+	}
+
+	// Canonical order: chapter ID, then change kind, so the report is
+	// reproducible regardless of map/slice iteration order.
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].ChapterID != changes[j].ChapterID {
+			return changes[i].ChapterID < changes[j].ChapterID
+		}
+		return changes[i].Change < changes[j].Change
+	})
+
+	return &OutlineDiffReport{
+		SchemaVersion: book.SchemaVersion,
+		Locale:        locale,
+		Old:           oldDir,
+		New:           newDir,
+		Changes:       changes,
+	}, nil
+}
+
+// diffOutlineSections reports which section names in new are new (not in
+// old) and which in old are gone (not in new).
+func diffOutlineSections(old, new []string) (added, removed []string) {
+	oldSet := make(map[string]bool, len(old))
+	for _, s := range old {
+		oldSet[s] = true
+	}
+	newSet := make(map[string]bool, len(new))
+	for _, s := range new {
+		newSet[s] = true
+	}
+	for _, s := range new {
+		if !oldSet[s] {
+			added = append(added, s)
+		}
+	}
+	for _, s := range old {
+		if !newSet[s] {
+			removed = append(removed, s)
+		}
+	}
+	return
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func handleGetLocaleParityReport(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if res := requireNotDegraded(); res != nil {
+		return res, nil
+	}
+
+	localeA := req.GetString("locale_a", "es")
+	localeB := req.GetString("locale_b", "en")
+	if res := validateArgLength(localeA, "locale_a", maxShortArgLength); res != nil {
+		return res, nil
+	}
+	if res := validateArgLength(localeB, "locale_b", maxShortArgLength); res != nil {
+		return res, nil
+	}
+
+	report, err := parser.GetLocaleParityReport(localeA, localeB)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error building locale parity report: %v", err)), nil
+	}
+
+	result, _ := json.MarshalIndent(report, "", "  ")
+	return mcp.NewToolResultText(string(result)), nil
+}
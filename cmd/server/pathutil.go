@@ -0,0 +1,33 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// expandPath resolves "~", "$HOME"-style environment variables, and relative
+// paths in a configured path like BOOK_PATH. Client configs frequently pass
+// paths unexpanded (e.g. "~/books/gentleman" or "$HOME/books/gentleman"),
+// which os.Stat would otherwise reject outright.
+func expandPath(path string) string {
+	if path == "" {
+		return path
+	}
+
+	path = os.ExpandEnv(path)
+
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, strings.TrimPrefix(path, "~"))
+		}
+	}
+
+	if !filepath.IsAbs(path) {
+		if abs, err := filepath.Abs(path); err == nil {
+			path = abs
+		}
+	}
+
+	return filepath.Clean(path)
+}
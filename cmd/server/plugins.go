@@ -0,0 +1,101 @@
+package main
+
+import (
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/Alan-TheGentleman/gentleman-book-mcp/internal/book"
+	"github.com/Alan-TheGentleman/gentleman-book-mcp/internal/pluginapi"
+)
+
+// Loaded plugin hooks, wired in by loadPlugins at startup. A fork with no
+// plugins configured just gets empty slices, so every apply* helper below is
+// a no-op by default.
+var (
+	contentNormalizers   []pluginapi.ContentNormalizer
+	rankingBoosters      []pluginapi.RankingBooster
+	resultPostProcessors []pluginapi.ResultPostProcessor
+)
+
+// loadPlugins wires in Go plugins listed in PLUGINS (comma-separated .so
+// paths built with `go build -buildmode=plugin`). WASM_PLUGINS is recognized
+// but not implemented yet: an embedded WASM runtime is a much bigger
+// dependency than this server otherwise carries, so for now private forks
+// needing the sandboxing WASM buys should use a Go plugin instead.
+func loadPlugins() {
+	if wasmPaths := os.Getenv("WASM_PLUGINS"); wasmPaths != "" {
+		log.Printf("WASM_PLUGINS is set but WASM plugin loading is not implemented yet; ignoring %s. Use PLUGINS (Go plugins) in the meantime.", wasmPaths)
+	}
+
+	paths := os.Getenv("PLUGINS")
+	if paths == "" {
+		return
+	}
+
+	for _, path := range strings.Split(paths, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		registerGoPlugin(path)
+	}
+}
+
+// registerHooks wires symbol into every hook interface it implements.
+// registerGoPlugin (platform-specific) calls this once a plugin is loaded.
+func registerHooks(path string, symbol any) {
+	matched := false
+	if n, ok := symbol.(pluginapi.ContentNormalizer); ok {
+		contentNormalizers = append(contentNormalizers, n)
+		matched = true
+	}
+	if b, ok := symbol.(pluginapi.RankingBooster); ok {
+		rankingBoosters = append(rankingBoosters, b)
+		matched = true
+	}
+	if p, ok := symbol.(pluginapi.ResultPostProcessor); ok {
+		resultPostProcessors = append(resultPostProcessors, p)
+		matched = true
+	}
+	if !matched {
+		log.Printf("Plugin %s does not implement any known hook interface (see internal/pluginapi)", path)
+		return
+	}
+	log.Printf("Loaded plugin %s", path)
+}
+
+// applyContentNormalizers runs every loaded ContentNormalizer over content,
+// in the order plugins were listed in PLUGINS.
+func applyContentNormalizers(content string) string {
+	for _, n := range contentNormalizers {
+		content = n.NormalizeContent(content)
+	}
+	return content
+}
+
+// applyRankingBoosters lets loaded plugins adjust each result's relevance
+// score, then re-sorts results by the adjusted score.
+func applyRankingBoosters(query string, results []book.SearchResult) []book.SearchResult {
+	if len(rankingBoosters) == 0 {
+		return results
+	}
+	for i, r := range results {
+		for _, b := range rankingBoosters {
+			r.Relevance = b.BoostRelevance(query, r)
+		}
+		results[i] = r
+	}
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Relevance > results[j].Relevance })
+	return results
+}
+
+// applyResultPostProcessors runs every loaded ResultPostProcessor over the
+// final result set, in the order plugins were listed in PLUGINS.
+func applyResultPostProcessors(results []book.SearchResult) []book.SearchResult {
+	for _, p := range resultPostProcessors {
+		results = p.PostProcessResults(results)
+	}
+	return results
+}
@@ -0,0 +1,28 @@
+//go:build !windows
+
+package main
+
+import (
+	"log"
+	"plugin"
+)
+
+// registerGoPlugin loads a Go plugin (.so, built with
+// `go build -buildmode=plugin`) and wires in any hook interfaces its
+// exported "Plugin" symbol implements. The standard library's plugin
+// package only supports linux/darwin/freebsd, hence the build tag.
+func registerGoPlugin(path string) {
+	p, err := plugin.Open(path)
+	if err != nil {
+		log.Printf("Error loading plugin %s: %v", path, err)
+		return
+	}
+
+	sym, err := p.Lookup("Plugin")
+	if err != nil {
+		log.Printf(`Plugin %s has no exported "Plugin" symbol: %v`, path, err)
+		return
+	}
+
+	registerHooks(path, sym)
+}
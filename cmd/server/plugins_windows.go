@@ -0,0 +1,11 @@
+//go:build windows
+
+package main
+
+import "log"
+
+// registerGoPlugin is unavailable on Windows: the standard library's plugin
+// package only supports linux/darwin/freebsd.
+func registerGoPlugin(path string) {
+	log.Printf("Plugin %s not loaded: Go plugins are not supported on Windows", path)
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/Alan-TheGentleman/gentleman-book-mcp/internal/book"
+)
+
+// prerequisiteOverrides holds extra chapter_id -> prerequisite IDs declared
+// via PREREQUISITES_CONFIG, for operators who can't (or don't want to) edit
+// book frontmatter directly. Overrides add to whatever a chapter's
+// frontmatter already declares; they don't replace it.
+var prerequisiteOverrides map[string][]string
+
+// loadPrerequisiteOverrides reads PREREQUISITES_CONFIG (a JSON object
+// mapping chapter_id to an array of prerequisite chapter IDs) if set.
+func loadPrerequisiteOverrides() (map[string][]string, error) {
+	path := os.Getenv("PREREQUISITES_CONFIG")
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading PREREQUISITES_CONFIG %s: %w", path, err)
+	}
+
+	var overrides map[string][]string
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return nil, fmt.Errorf("error parsing PREREQUISITES_CONFIG %s: %w", path, err)
+	}
+	return overrides, nil
+}
+
+// withPrerequisiteOverrides returns chapters with any PREREQUISITES_CONFIG
+// entries merged into their frontmatter-declared Prerequisites.
+func withPrerequisiteOverrides(chapters []book.Chapter) []book.Chapter {
+	if len(prerequisiteOverrides) == 0 {
+		return chapters
+	}
+
+	merged := make([]book.Chapter, len(chapters))
+	for i, c := range chapters {
+		extra, ok := prerequisiteOverrides[c.ID]
+		if !ok {
+			merged[i] = c
+			continue
+		}
+		seen := make(map[string]bool, len(c.Prerequisites)+len(extra))
+		prereqs := make([]string, 0, len(c.Prerequisites)+len(extra))
+		for _, p := range append(append([]string{}, c.Prerequisites...), extra...) {
+			if !seen[p] {
+				seen[p] = true
+				prereqs = append(prereqs, p)
+			}
+		}
+		c.Prerequisites = prereqs
+		merged[i] = c
+	}
+	return merged
+}
+
+// resolvedPrerequisites returns chapterID's prerequisites with any
+// PREREQUISITES_CONFIG overrides merged in.
+func resolvedPrerequisites(p *book.Parser, chapterID, locale string) ([]string, error) {
+	chapter, err := p.GetChapter(chapterID, locale)
+	if err != nil {
+		return nil, err
+	}
+	merged := withPrerequisiteOverrides([]book.Chapter{*chapter})
+	return merged[0].Prerequisites, nil
+}
+
+// resolvedLearningOrder returns locale's chapters in prerequisite order,
+// with any PREREQUISITES_CONFIG overrides merged in first.
+func resolvedLearningOrder(p *book.Parser, locale string) ([]book.Chapter, error) {
+	chapters, err := p.ListChapters(locale)
+	if err != nil {
+		return nil, err
+	}
+	return book.TopologicalSort(withPrerequisiteOverrides(chapters))
+}
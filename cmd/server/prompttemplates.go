@@ -0,0 +1,85 @@
+package main
+
+import (
+	"embed"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+//go:embed prompts/*.tmpl
+var defaultPromptsFS embed.FS
+
+// promptTemplates holds the text/template definitions backing every
+// predefined prompt's message text, keyed by template name (e.g.
+// "explain_concept.tmpl"). Built-in templates ship embedded in the binary;
+// an operator can override or add to them at runtime via PROMPTS_DIR,
+// without recompiling the server.
+var promptTemplates *template.Template
+
+// promptFuncs are available to every prompt template.
+var promptFuncs = template.FuncMap{
+	"join": strings.Join,
+	"availableChapterIDs": func(locale string) []string {
+		chapters, err := parser.ListChapters(locale)
+		if err != nil {
+			return nil
+		}
+		ids := make([]string, 0, len(chapters))
+		for _, c := range chapters {
+			ids = append(ids, c.ID)
+		}
+		return ids
+	},
+}
+
+// loadPromptTemplates parses the embedded default templates and, if
+// PROMPTS_DIR is set, layers any *.tmpl files found there on top, replacing
+// built-ins of the same name and adding new ones.
+func loadPromptTemplates() (*template.Template, error) {
+	tmpl, err := template.New("prompts").Funcs(promptFuncs).ParseFS(defaultPromptsFS, "prompts/*.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("error parsing embedded prompt templates: %w", err)
+	}
+
+	dir := os.Getenv("PROMPTS_DIR")
+	if dir == "" {
+		return tmpl, nil
+	}
+
+	overrides, err := filepath.Glob(filepath.Join(dir, "*.tmpl"))
+	if err != nil {
+		return nil, fmt.Errorf("error scanning PROMPTS_DIR %s: %w", dir, err)
+	}
+	for _, path := range overrides {
+		if _, err := tmpl.ParseFiles(path); err != nil {
+			return nil, fmt.Errorf("error parsing prompt override %s: %w", path, err)
+		}
+	}
+	return tmpl, nil
+}
+
+// renderPrompt executes the named template ("explain_concept.tmpl", etc.)
+// against data and returns the resulting prompt message text.
+func renderPrompt(name string, data any) (string, error) {
+	var buf strings.Builder
+	if err := promptTemplates.ExecuteTemplate(&buf, name, data); err != nil {
+		return "", fmt.Errorf("error rendering prompt template %s: %w", name, err)
+	}
+	return strings.TrimRight(buf.String(), "\n"), nil
+}
+
+func initPromptTemplates() {
+	tmpl, err := loadPromptTemplates()
+	if err != nil {
+		log.Printf("Using embedded prompt templates only: %v", err)
+		tmpl, err = template.New("prompts").Funcs(promptFuncs).ParseFS(defaultPromptsFS, "prompts/*.tmpl")
+		if err != nil {
+			log.Fatalf("embedded prompt templates are invalid: %v", err)
+		}
+	}
+	promptTemplates = tmpl
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/Alan-TheGentleman/gentleman-book-mcp/internal/embeddings"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// PUBLISH_WEBHOOK_URL and PUBLISH_WEBHOOK_KIND configure an optional
+// outbound publisher: post a generated digest (or any short message) to a
+// Slack or Discord incoming webhook on demand via publish_digest. There's
+// no scheduler in this server yet, so "on a schedule" from an operator's
+// crontab/CI job calling publish_digest is the supported path today.
+const (
+	publishWebhookURLEnvVar  = "PUBLISH_WEBHOOK_URL"
+	publishWebhookKindEnvVar = "PUBLISH_WEBHOOK_KIND"
+)
+
+var webhookHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// webhookConfigured reports whether a publish target is set up.
+func webhookConfigured() bool {
+	return os.Getenv(publishWebhookURLEnvVar) != ""
+}
+
+// webhookKind returns the configured webhook flavor ("slack" or "discord"),
+// defaulting to "slack" since both accept the same {"text": "..."} shape for
+// a plain message and Discord's webhook endpoint happens to accept it too
+// under the "content" key -- see postToWebhook for the one place that
+// actually differs.
+func webhookKind() string {
+	kind := os.Getenv(publishWebhookKindEnvVar)
+	if kind == "" {
+		return "slack"
+	}
+	return kind
+}
+
+// slackWebhookPayload is the minimal shape Slack's incoming webhooks accept.
+type slackWebhookPayload struct {
+	Text string `json:"text"`
+}
+
+// discordWebhookPayload is the minimal shape Discord's incoming webhooks
+// accept.
+type discordWebhookPayload struct {
+	Content string `json:"content"`
+}
+
+// postToWebhook posts message to the configured webhook, formatted for
+// whichever kind is configured.
+func postToWebhook(ctx context.Context, message string) error {
+	if embeddings.OfflineModeEnabled() {
+		return fmt.Errorf("OFFLINE_MODE is set; publishing to a webhook requires network access and is disabled")
+	}
+
+	url := os.Getenv(publishWebhookURLEnvVar)
+	if url == "" {
+		return fmt.Errorf("%s is not set; nothing to publish to", publishWebhookURLEnvVar)
+	}
+
+	var body []byte
+	var err error
+	switch webhookKind() {
+	case "discord":
+		body, err = json.Marshal(discordWebhookPayload{Content: message})
+	default:
+		body, err = json.Marshal(slackWebhookPayload{Text: message})
+	}
+	if err != nil {
+		return fmt.Errorf("encoding webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("building webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := webhookHTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("posting to webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// digestAsMessage renders a Digest as plain text suitable for a chat
+// webhook message.
+func digestAsMessage(d Digest) string {
+	msg := fmt.Sprintf("*%s — %s*\n\n*Concept:* %s\n\n*Quote:* %s", d.Period, d.ChapterName, d.Concept, d.Quote)
+	if d.Exercise != "" {
+		msg += fmt.Sprintf("\n\n*Exercise:* %s", d.Exercise)
+	}
+	return msg
+}
+
+func handlePublishDigest(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if res := requireNotDegraded(); res != nil {
+		return res, nil
+	}
+	if !webhookConfigured() {
+		return mcp.NewToolResultError(fmt.Sprintf("%s is not set; configure a Slack or Discord incoming webhook URL to use publish_digest", publishWebhookURLEnvVar)), nil
+	}
+
+	locale := req.GetString("locale", "es")
+	period := req.GetString("period", "daily")
+	if res := validateArgLength(locale, "locale", maxShortArgLength); res != nil {
+		return res, nil
+	}
+	if period != "daily" && period != "weekly" {
+		return mcp.NewToolResultError("period must be 'daily' or 'weekly'"), nil
+	}
+
+	chapters, err := parser.ListChapters(locale)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error listing chapters: %v", err)), nil
+	}
+	if len(chapters) == 0 {
+		return mcp.NewToolResultError("no chapters available to build a digest from"), nil
+	}
+
+	chapter := pickDigestChapter(chapters)
+	digest := buildDigest(parser, chapter, locale, period)
+
+	if err := postToWebhook(ctx, digestAsMessage(digest)); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error publishing digest: %v", err)), nil
+	}
+	coverageTrackers.get(defaultTenantID).record(chapter.ID, chapter.Name)
+
+	result, _ := json.MarshalIndent(digest, "", "  ")
+	return mcp.NewToolResultText(fmt.Sprintf("Published to %s webhook.\n\n%s", webhookKind(), string(result))), nil
+}
@@ -0,0 +1,39 @@
+package main
+
+import "github.com/Alan-TheGentleman/gentleman-book-mcp/internal/book"
+
+// ReadinessReport answers "has this reader actually covered what
+// chapterID's dependency graph says they should have read first?", using
+// coverage data (what's actually been read or searched this session)
+// rather than just the existence of a Prerequisites declaration.
+type ReadinessReport struct {
+	ChapterID     string   `json:"chapterId"`
+	Ready         bool     `json:"ready"`
+	Prerequisites []string `json:"prerequisites"`
+	Missing       []string `json:"missingPrerequisites,omitempty"`
+}
+
+// checkReadiness resolves chapterID's prerequisites (frontmatter plus any
+// PREREQUISITES_CONFIG overrides) and flags which ones bookID's coverage
+// tracker has no record of ever being read or searched.
+func checkReadiness(p *book.Parser, bookID, chapterID, locale string) (*ReadinessReport, error) {
+	prereqs, err := resolvedPrerequisites(p, chapterID, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	tracker := coverageTrackers.get(bookID)
+	var missing []string
+	for _, prereq := range prereqs {
+		if !tracker.hasHits(prereq) {
+			missing = append(missing, prereq)
+		}
+	}
+
+	return &ReadinessReport{
+		ChapterID:     chapterID,
+		Ready:         len(missing) == 0,
+		Prerequisites: prereqs,
+		Missing:       missing,
+	}, nil
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/Alan-TheGentleman/gentleman-book-mcp/internal/book"
+	"github.com/Alan-TheGentleman/gentleman-book-mcp/internal/embeddings"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// chapterHashUpdate is one chapter's new content hash, recorded against the
+// semantic engine only after the background job that embeds it succeeds.
+type chapterHashUpdate struct {
+	chapterID string
+	locale    string
+	hash      string
+}
+
+// submitIndexUpdateJob is submitIndexBuildJob's incremental counterpart: it
+// only re-embeds chapters whose content hash has changed since the last
+// successful build (or update), removing their stale chunks first. Chapters
+// whose hash is unchanged keep their existing indexed chunks untouched, so
+// editing one chapter doesn't cost an embedding pass over the whole book.
+func submitIndexUpdateJob(t *tenant, localeParam string) (*job, int, int, error) {
+	var locales []string
+	if localeParam == "all" {
+		locales = []string{"es", "en"}
+	} else {
+		locales = []string{localeParam}
+	}
+
+	var allChunks []embeddings.Chunk
+	var hashUpdates []chapterHashUpdate
+	var filterStats []BoilerplateFilterStats
+	chunkID := 0
+	changedChapters := 0
+	unchangedChapters := 0
+
+	engine := t.semanticEngine
+	for _, locale := range locales {
+		chapters, err := t.parser.ListChapters(locale)
+		if err != nil {
+			return nil, 0, 0, fmt.Errorf("error reading chapters for %s: %w", locale, err)
+		}
+
+		for _, chapter := range chapters {
+			content, stats := filterBoilerplate(chapter.Content, chapter.ID, locale)
+			hash := embeddings.ContentHash(content)
+
+			if !engine.ChapterChanged(chapter.ID, locale, hash) {
+				unchangedChapters++
+				continue
+			}
+			changedChapters++
+			filterStats = append(filterStats, stats)
+			hashUpdates = append(hashUpdates, chapterHashUpdate{chapterID: chapter.ID, locale: locale, hash: hash})
+
+			engine.RemoveChapter(chapter.ID, locale)
+			chunks := splitIntoChunks(content, chapter.ID, chapter.Name, locale, &chunkID)
+			allChunks = append(allChunks, chunks...)
+		}
+	}
+
+	var scrubReport []ScrubStats
+	if scrubSecretsEnabled() && isCloudProvider(engine.Provider()) {
+		scrubReport = scrubChunksForEmbedding(allChunks)
+	}
+
+	j := jobs.submit("index_update", func(ctx context.Context, progress func(JobUpdate)) (interface{}, error) {
+		if len(allChunks) > 0 {
+			err := engine.IndexChunksWithProgress(ctx, allChunks, func(done, total int, chapterID, chunkLocale string) {
+				progress(JobUpdate{
+					"chunksEmbedded":   done,
+					"totalChunks":      total,
+					"currentChapterId": chapterID,
+					"currentLocale":    chunkLocale,
+				})
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+		for _, u := range hashUpdates {
+			engine.SetChapterHash(u.chapterID, u.locale, u.hash)
+		}
+		return map[string]interface{}{
+			"schemaVersion":       book.SchemaVersion,
+			"chunksIndexed":       len(allChunks),
+			"changedChapters":     changedChapters,
+			"unchangedChapters":   unchangedChapters,
+			"locales":             locales,
+			"boilerplateFiltered": filterStats,
+			"secretsScrubbed":     scrubReport,
+		}, nil
+	})
+
+	log.Printf("Incrementally indexing %d chunks from %d changed chapter(s) for tenant %s in background (job %s)...", len(allChunks), changedChapters, t.id, j.ID)
+
+	return j, len(allChunks), changedChapters, nil
+}
+
+func handleUpdateSemanticIndex(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	bookID := resolveBookID(req)
+	t, err := tenants.get(bookID)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if t.semanticEngine == nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Semantic search not available for book %q. Set OPENAI_API_KEY or ensure Ollama is running, and build its index.", t.id)), nil
+	}
+	if !t.semanticEngine.IsIndexed() {
+		return mcp.NewToolResultError("Semantic index not built. Run 'build_semantic_index' first."), nil
+	}
+
+	localeParam := req.GetString("locale", "all")
+	if res := validateArgLength(localeParam, "locale", maxShortArgLength); res != nil {
+		return res, nil
+	}
+
+	j, chunkCount, changedChapters, err := submitIndexUpdateJob(t, localeParam)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf(
+		"Started incremental re-index of %d changed chapter(s), %d chunks, as job %s. Poll job_status with this job_id for progress.",
+		changedChapters, chunkCount, j.ID,
+	)), nil
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/Alan-TheGentleman/gentleman-book-mcp/internal/book"
+)
+
+// reviewQuestionOverrides holds chapter_id -> curated review questions
+// declared via REVIEW_QUESTIONS_CONFIG, for operators who can't (or don't
+// want to) edit book frontmatter directly. An override fully replaces a
+// chapter's questions (frontmatter-declared or generated), since it's
+// meant to be the operator's deliberate, reviewed answer bank.
+var reviewQuestionOverrides map[string][]book.ReviewQuestion
+
+// loadReviewQuestionOverrides reads REVIEW_QUESTIONS_CONFIG (a JSON object
+// mapping chapter_id to an array of {question, tagId}) if set.
+func loadReviewQuestionOverrides() (map[string][]book.ReviewQuestion, error) {
+	path := os.Getenv("REVIEW_QUESTIONS_CONFIG")
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading REVIEW_QUESTIONS_CONFIG %s: %w", path, err)
+	}
+
+	var overrides map[string][]book.ReviewQuestion
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return nil, fmt.Errorf("error parsing REVIEW_QUESTIONS_CONFIG %s: %w", path, err)
+	}
+	return overrides, nil
+}
+
+// resolvedReviewQuestions returns chapterID's review questions, preferring a
+// REVIEW_QUESTIONS_CONFIG override, then frontmatter-curated questions, then
+// a heuristic set generated from the chapter's headings.
+func resolvedReviewQuestions(p *book.Parser, chapterID, locale string) ([]book.ReviewQuestion, error) {
+	if override, ok := reviewQuestionOverrides[chapterID]; ok {
+		return override, nil
+	}
+	return p.GetReviewQuestions(chapterID, locale)
+}
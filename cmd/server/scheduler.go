@@ -0,0 +1,263 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// scheduleConfigEnvVar points at a JSON file listing recurring tasks, mirroring
+// TENANT_CONFIG's env-var-to-JSON-file convention. Without it, the scheduler
+// has nothing to run.
+const scheduleConfigEnvVar = "SCHEDULE_CONFIG"
+
+// scheduledTaskKind enumerates what a scheduled task actually does.
+// "link_check" is accepted (the request this scheduler was built for asked
+// for it) but not implemented -- there is no link-checking feature anywhere
+// in this server yet, so it's logged and recorded as skipped rather than
+// faked.
+const (
+	taskKindRefreshBook   = "refresh_book"
+	taskKindRebuildIndex  = "rebuild_index"
+	taskKindPublishDigest = "publish_digest"
+	taskKindLinkCheck     = "link_check"
+)
+
+// scheduledTask is one entry from SCHEDULE_CONFIG.
+type scheduledTask struct {
+	Name   string `json:"name"`
+	Kind   string `json:"kind"`
+	Cron   string `json:"cron"`   // standard 5-field cron: minute hour dom month dow
+	Locale string `json:"locale"` // used by rebuild_index/publish_digest; defaults to "es"
+}
+
+// taskRunStatus is a task's last-run outcome, surfaced via the health tool.
+type taskRunStatus struct {
+	Name      string    `json:"name"`
+	Kind      string    `json:"kind"`
+	Cron      string    `json:"cron"`
+	LastRunAt time.Time `json:"lastRunAt,omitempty"`
+	LastError string    `json:"lastError,omitempty"`
+	LastOK    bool      `json:"lastOk"`
+	Runs      int       `json:"runs"`
+}
+
+// taskScheduler runs scheduledTasks whose cron expression matches the
+// current minute, polling once a minute. It's intentionally simple (no
+// external cron library) since the server otherwise has no recurring-task
+// needs beyond this.
+type taskScheduler struct {
+	mu       sync.Mutex
+	tasks    []scheduledTask
+	statuses map[string]*taskRunStatus
+}
+
+var scheduler = &taskScheduler{statuses: make(map[string]*taskRunStatus)}
+
+// loadScheduleConfig reads SCHEDULE_CONFIG, if set, populating scheduler.tasks.
+func loadScheduleConfig() error {
+	path := os.Getenv(scheduleConfigEnvVar)
+	if path == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading %s %s: %w", scheduleConfigEnvVar, path, err)
+	}
+
+	var tasks []scheduledTask
+	if err := json.Unmarshal(data, &tasks); err != nil {
+		return fmt.Errorf("error parsing %s %s: %w", scheduleConfigEnvVar, path, err)
+	}
+
+	scheduler.mu.Lock()
+	defer scheduler.mu.Unlock()
+	scheduler.tasks = tasks
+	for _, t := range tasks {
+		if _, ok := scheduler.statuses[t.Name]; !ok {
+			scheduler.statuses[t.Name] = &taskRunStatus{Name: t.Name, Kind: t.Kind, Cron: t.Cron}
+		}
+	}
+	return nil
+}
+
+// startScheduler launches the minute-resolution polling loop in the
+// background. A no-op if no tasks were configured.
+func startScheduler(ctx context.Context) {
+	scheduler.mu.Lock()
+	n := len(scheduler.tasks)
+	scheduler.mu.Unlock()
+	if n == 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				scheduler.runDue(ctx, now)
+			}
+		}
+	}()
+}
+
+// runDue runs every task whose cron expression matches now.
+func (s *taskScheduler) runDue(ctx context.Context, now time.Time) {
+	s.mu.Lock()
+	tasks := make([]scheduledTask, len(s.tasks))
+	copy(tasks, s.tasks)
+	s.mu.Unlock()
+
+	for _, t := range tasks {
+		if !cronMatches(t.Cron, now) {
+			continue
+		}
+		go s.run(ctx, t, now)
+	}
+}
+
+func (s *taskScheduler) run(ctx context.Context, t scheduledTask, at time.Time) {
+	err := runScheduledTask(ctx, t)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	status, ok := s.statuses[t.Name]
+	if !ok {
+		status = &taskRunStatus{Name: t.Name, Kind: t.Kind, Cron: t.Cron}
+		s.statuses[t.Name] = status
+	}
+	status.LastRunAt = at
+	status.Runs++
+	if err != nil {
+		status.LastOK = false
+		status.LastError = err.Error()
+		log.Printf("Scheduled task %q (%s) failed: %v", t.Name, t.Kind, err)
+	} else {
+		status.LastOK = true
+		status.LastError = ""
+	}
+}
+
+// snapshot returns a stable-ordered copy of every task's last-run status.
+func (s *taskScheduler) snapshot() []taskRunStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]taskRunStatus, 0, len(s.tasks))
+	for _, t := range s.tasks {
+		if st, ok := s.statuses[t.Name]; ok {
+			out = append(out, *st)
+		}
+	}
+	return out
+}
+
+// runScheduledTask performs one task's work, synchronously from the
+// scheduler's point of view (rebuild_index still submits its embedding pass
+// as its own background job, same as the tool does).
+func runScheduledTask(ctx context.Context, t scheduledTask) error {
+	locale := t.Locale
+	if locale == "" {
+		locale = "es"
+	}
+
+	switch t.Kind {
+	case taskKindRefreshBook:
+		cmd := exec.CommandContext(ctx, "git", "-C", bookPath, "pull", "--ff-only")
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("git pull failed: %w: %s", err, strings.TrimSpace(string(out)))
+		}
+		for _, loc := range []string{"es", "en"} {
+			if _, err := recordChapterChanges(parser, loc); err != nil {
+				log.Printf("Changelog diff for locale %s failed: %v", loc, err)
+			}
+		}
+		return nil
+
+	case taskKindRebuildIndex:
+		if semanticEngine == nil {
+			return fmt.Errorf("semantic search not available")
+		}
+		defaultTenant, err := tenants.get(defaultTenantID)
+		if err != nil {
+			return err
+		}
+		_, _, err = submitIndexBuildJob(defaultTenant, locale)
+		return err
+
+	case taskKindPublishDigest:
+		if !webhookConfigured() {
+			return fmt.Errorf("%s is not set", publishWebhookURLEnvVar)
+		}
+		chapters, err := parser.ListChapters(locale)
+		if err != nil {
+			return err
+		}
+		if len(chapters) == 0 {
+			return fmt.Errorf("no chapters available")
+		}
+		chapter := pickDigestChapter(chapters)
+		digest := buildDigest(parser, chapter, locale, "daily")
+		if err := postToWebhook(ctx, digestAsMessage(digest)); err != nil {
+			return err
+		}
+		coverageTrackers.get(defaultTenantID).record(chapter.ID, chapter.Name)
+		return nil
+
+	case taskKindLinkCheck:
+		return fmt.Errorf("link_check is not implemented yet; this server has no link-checking feature")
+
+	default:
+		return fmt.Errorf("unknown scheduled task kind %q", t.Kind)
+	}
+}
+
+// cronField matches one 5-field cron position against value, supporting
+// "*", "*/N" steps, comma-separated lists, and plain numbers -- the subset
+// real-world recurring jobs (hourly, daily, "every 15 minutes") actually
+// need, without pulling in a cron-parsing dependency.
+func cronField(field string, value int) bool {
+	for _, part := range strings.Split(field, ",") {
+		if part == "*" {
+			return true
+		}
+		if strings.HasPrefix(part, "*/") {
+			step, err := strconv.Atoi(part[2:])
+			if err == nil && step > 0 && value%step == 0 {
+				return true
+			}
+			continue
+		}
+		if n, err := strconv.Atoi(part); err == nil && n == value {
+			return true
+		}
+	}
+	return false
+}
+
+// cronMatches reports whether a standard 5-field cron expression
+// ("minute hour dom month dow") matches t. An invalid expression (wrong
+// field count) never matches, rather than panicking or matching everything.
+func cronMatches(cron string, t time.Time) bool {
+	fields := strings.Fields(cron)
+	if len(fields) != 5 {
+		return false
+	}
+	return cronField(fields[0], t.Minute()) &&
+		cronField(fields[1], t.Hour()) &&
+		cronField(fields[2], t.Day()) &&
+		cronField(fields[3], int(t.Month())) &&
+		cronField(fields[4], int(t.Weekday()))
+}
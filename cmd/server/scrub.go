@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"regexp"
+
+	"github.com/Alan-TheGentleman/gentleman-book-mcp/internal/embeddings"
+)
+
+// defaultSecretPatterns match common high-signal secret formats. They're
+// deliberately conservative (specific prefixes/shapes) to keep false
+// positives on ordinary prose low.
+var defaultSecretPatterns = map[string]*regexp.Regexp{
+	"aws_access_key_id":     regexp.MustCompile(`AKIA[0-9A-Z]{16}`),
+	"private_key_block":     regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----`),
+	"generic_secret_assign": regexp.MustCompile(`(?i)(api[_-]?key|secret|password|token)\s*[:=]\s*['"]?[A-Za-z0-9_\-]{12,}['"]?`),
+	"email":                 regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`),
+}
+
+// highEntropyTokenPattern finds long alphanumeric-ish runs worth running
+// through the entropy check; plain words rarely reach the length+charset
+// mix this requires, but real tokens (API keys, JWTs) do.
+var highEntropyTokenPattern = regexp.MustCompile(`[A-Za-z0-9+/_\-]{24,}`)
+
+// highEntropyThreshold is the Shannon entropy (bits per character) above
+// which a long token is treated as a likely secret rather than ordinary
+// text. Base64/hex secrets typically land well above 4.0; English prose
+// and identifiers fall well below it.
+const highEntropyThreshold = 4.0
+
+// shannonEntropy returns the Shannon entropy, in bits per character, of s.
+func shannonEntropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+	counts := make(map[rune]int)
+	for _, r := range s {
+		counts[r]++
+	}
+	entropy := 0.0
+	n := float64(len(s))
+	for _, c := range counts {
+		p := float64(c) / n
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// ScrubStats reports how much of a chapter's content was redacted before
+// being sent to a cloud embedding provider, so an operator can tell whether
+// the scrubber is catching real secrets or just noisily over-redacting.
+type ScrubStats struct {
+	ChapterID  string         `json:"chapterId"`
+	Locale     string         `json:"locale"`
+	Redactions map[string]int `json:"redactions,omitempty"`
+}
+
+// scrubSecretsEnabled reports whether content should be scrubbed before
+// being embedded. Scrubbing defaults to on for any cloud provider;
+// DISABLE_SECRET_SCRUBBING opts a deployment out (e.g. for a book with
+// intentionally technical secret-shaped examples).
+func scrubSecretsEnabled() bool {
+	return os.Getenv("DISABLE_SECRET_SCRUBBING") == ""
+}
+
+// isCloudProvider reports whether provider sends content to a third-party
+// embedding API over the network, as opposed to a locally-hosted one
+// (Ollama) or one that never leaves the process (Fake) -- the set
+// scrubSecretsEnabled's doc comment means by "any cloud provider".
+func isCloudProvider(provider embeddings.Provider) bool {
+	switch provider {
+	case embeddings.ProviderOpenAI, embeddings.ProviderCohere, embeddings.ProviderVoyage, embeddings.ProviderGemini:
+		return true
+	default:
+		return false
+	}
+}
+
+// scrubContent redacts emails and likely secrets (regex and entropy-based)
+// from content, returning the redacted text and a per-type redaction count.
+func scrubContent(content, chapterID, locale string) (string, ScrubStats) {
+	stats := ScrubStats{ChapterID: chapterID, Locale: locale, Redactions: map[string]int{}}
+
+	for name, re := range defaultSecretPatterns {
+		content = re.ReplaceAllStringFunc(content, func(match string) string {
+			stats.Redactions[name]++
+			return fmt.Sprintf("[redacted-%s]", name)
+		})
+	}
+
+	content = highEntropyTokenPattern.ReplaceAllStringFunc(content, func(token string) string {
+		if shannonEntropy(token) < highEntropyThreshold {
+			return token
+		}
+		stats.Redactions["high_entropy_token"]++
+		return "[redacted-high-entropy-token]"
+	})
+
+	if len(stats.Redactions) == 0 {
+		stats.Redactions = nil
+	}
+	return content, stats
+}
+
+// scrubChunksForEmbedding scrubs every chunk's content in place, returning
+// one ScrubStats entry per chunk that had a redaction (chunks with nothing
+// to redact are omitted to keep the report focused).
+func scrubChunksForEmbedding(chunks []embeddings.Chunk) []ScrubStats {
+	var report []ScrubStats
+	for i := range chunks {
+		scrubbed, stats := scrubContent(chunks[i].Content, chunks[i].ChapterID, chunks[i].Locale)
+		if len(stats.Redactions) > 0 {
+			chunks[i].Content = scrubbed
+			report = append(report, stats)
+		}
+	}
+	return report
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/Alan-TheGentleman/gentleman-book-mcp/internal/book"
+	"github.com/Alan-TheGentleman/gentleman-book-mcp/internal/embeddings"
+)
+
+// SEARCH_MEMORY_BUDGET controls which accelerating search structures get
+// built at all ("full", the default, or "minimal" for small devices).
+// SEARCH_INDEX_WARMUP controls when they get built, within whatever the
+// memory budget allows: eagerly at startup ("true") or lazily on first
+// search (the default).
+//
+// LOW_MEMORY_PROFILE="true" (see embeddings.LowMemoryModeEnabled) implies
+// SEARCH_MEMORY_BUDGET=minimal, so the one ARM/low-memory setting turns off
+// every accelerating structure -- keyword index included -- without an
+// operator having to also set this package's own env var.
+const (
+	searchMemoryBudgetEnvVar = "SEARCH_MEMORY_BUDGET"
+	searchIndexWarmupEnvVar  = "SEARCH_INDEX_WARMUP"
+)
+
+// configureSearchAcceleration reads both env vars and applies them to p. It
+// logs what it decided so an operator tuning a low-memory box can see the
+// effect without reading the source.
+func configureSearchAcceleration(p *book.Parser) {
+	lowMemory := os.Getenv(searchMemoryBudgetEnvVar) == "minimal" || embeddings.LowMemoryModeEnabled()
+	p.SetLowMemoryMode(lowMemory)
+	if lowMemory {
+		log.Printf("Low-memory mode: keyword index disabled, search will scan chapters directly")
+		return
+	}
+
+	if os.Getenv(searchIndexWarmupEnvVar) != "true" {
+		return
+	}
+
+	for _, locale := range []string{"es", "en"} {
+		if err := p.BuildKeywordIndex(locale); err != nil {
+			log.Printf("Keyword index warm-up for locale %s failed: %v", locale, err)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// sessionKeepAliveInterval is how often a network transport (SSE, HTTP
+// streaming) should ping a connected client to keep its connection alive.
+// The stdio transport this server currently uses has no notion of a
+// reconnect, so nothing drives this yet — it's here so the HTTP/SSE
+// transport can reuse one constant instead of inventing its own once added.
+const sessionKeepAliveInterval = 25 * time.Second
+
+// sessionIdleTimeout is how long a session's state is kept after its last
+// activity before it's eligible for eviction.
+const sessionIdleTimeout = 30 * time.Minute
+
+// sessionState is what a reconnecting client gets back: enough to resume
+// without re-sending its preferences or re-warming caches it already warmed.
+type sessionState struct {
+	ID            string
+	Locale        string
+	TenantID      string
+	LastSeen      time.Time
+	WarmChapterID string
+}
+
+// sessionStore is an in-memory session registry keyed by session ID. It's
+// deliberately process-local for now — sharing it across replicas is the
+// job of the StateBackend work tracked separately for horizontally-scaled
+// deployments.
+type sessionStore struct {
+	mu       sync.Mutex
+	sessions map[string]*sessionState
+}
+
+var sessions = &sessionStore{sessions: make(map[string]*sessionState)}
+
+func newSessionID() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return hex.EncodeToString([]byte(time.Now().String()))
+	}
+	return hex.EncodeToString(buf)
+}
+
+// resume returns the existing session for id if present and not expired,
+// or creates a fresh one otherwise. locale/tenantID are only applied when
+// creating a new session, or resuming one whose caller explicitly passed
+// non-empty overrides.
+func (s *sessionStore) resume(id, locale, tenantID string) *sessionState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+
+	if id != "" {
+		if existing, ok := s.sessions[id]; ok && now.Sub(existing.LastSeen) < sessionIdleTimeout {
+			existing.LastSeen = now
+			if locale != "" {
+				existing.Locale = locale
+			}
+			if tenantID != "" {
+				existing.TenantID = tenantID
+			}
+			return existing
+		}
+	}
+
+	fresh := &sessionState{
+		ID:       newSessionID(),
+		Locale:   locale,
+		TenantID: tenantID,
+		LastSeen: now,
+	}
+	s.sessions[fresh.ID] = fresh
+	return fresh
+}
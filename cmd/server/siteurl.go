@@ -0,0 +1,54 @@
+package main
+
+import (
+	"os"
+
+	"github.com/Alan-TheGentleman/gentleman-book-mcp/internal/book"
+	"github.com/Alan-TheGentleman/gentleman-book-mcp/internal/embeddings"
+)
+
+// siteURLConfig maps chapters and sections to their public URLs on the
+// book's website. It stays zero-valued (disabled) unless SITE_BASE_URL is
+// set, so deployments that don't publish the book anywhere keep getting
+// results with no url field.
+var siteURLConfig book.URLConfig
+
+func loadSiteURLConfig() {
+	siteURLConfig = book.URLConfig{
+		BaseURL:     os.Getenv("SITE_BASE_URL"),
+		SlugPattern: os.Getenv("SITE_URL_SLUG_PATTERN"),
+	}
+}
+
+// applyChapterURL sets chapter.URL in place from siteURLConfig.
+func applyChapterURL(chapter *book.Chapter) {
+	if !siteURLConfig.Enabled() {
+		return
+	}
+	chapter.URL = siteURLConfig.ChapterURL(chapter.ID, chapter.Locale)
+}
+
+// applySearchResultURLs fills in the URL field of every result using each
+// result's section heading to derive the same tagId the website uses.
+func applySearchResultURLs(results []book.SearchResult) []book.SearchResult {
+	if !siteURLConfig.Enabled() {
+		return results
+	}
+	for i := range results {
+		tagID := parser.GenerateTagID(results[i].Section)
+		results[i].URL = siteURLConfig.SectionURL(results[i].ChapterID, tagID, results[i].Locale)
+	}
+	return results
+}
+
+// applySemanticResultURLs is applySearchResultURLs for semantic results.
+func applySemanticResultURLs(results []embeddings.SemanticResult) []embeddings.SemanticResult {
+	if !siteURLConfig.Enabled() {
+		return results
+	}
+	for i := range results {
+		tagID := parser.GenerateTagID(results[i].Section)
+		results[i].URL = siteURLConfig.SectionURL(results[i].ChapterID, tagID, results[i].Locale)
+	}
+	return results
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/Alan-TheGentleman/gentleman-book-mcp/internal/book"
+)
+
+// tagIDSlugModeEnvVar lets an operator opt back into GenerateTagID's
+// original accented-letter behavior if some existing tagIds were generated
+// (and are linked to) under that scheme. The default strips accents to
+// match the website's github-slugger-based anchors.
+const tagIDSlugModeEnvVar = "TAGID_SLUG_MODE"
+
+// loadSlugMode reads TAGID_SLUG_MODE and applies it to parser.
+func loadSlugMode() {
+	if os.Getenv(tagIDSlugModeEnvVar) == "keep-accents" {
+		parser.SetSlugMode(book.SlugModeKeepAccents)
+		log.Printf("tagId slug mode: keeping accented letters (TAGID_SLUG_MODE=keep-accents)")
+	}
+}
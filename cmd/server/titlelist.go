@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Alan-TheGentleman/gentleman-book-mcp/internal/book"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func handleRegenerateTitleList(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if res := requireNotDegraded(); res != nil {
+		return res, nil
+	}
+
+	chapterID := req.GetString("chapter_id", "")
+	if chapterID == "" {
+		return mcp.NewToolResultError("chapter_id is required"), nil
+	}
+	if res := validateArgLength(chapterID, "chapter_id", maxShortArgLength); res != nil {
+		return res, nil
+	}
+	locale := req.GetString("locale", "es")
+	if res := validateArgLength(locale, "locale", maxShortArgLength); res != nil {
+		return res, nil
+	}
+	write := req.GetBool("write", false)
+
+	if write {
+		if res := requireWriteToolsEnabled(); res != nil {
+			return res, nil
+		}
+
+		chapter, err := parser.GetChapter(chapterID, locale)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		warning := gitDirtyWarning(bookPath, chapter.FilePath)
+
+		sections, err := parser.WriteTitleList(chapterID, locale)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Error writing titleList: %v", err)), nil
+		}
+
+		response := struct {
+			Written   bool           `json:"written"`
+			TitleList []book.Section `json:"titleList"`
+			Warning   string         `json:"warning,omitempty"`
+		}{Written: true, TitleList: sections, Warning: warning}
+		result, _ := json.MarshalIndent(response, "", "  ")
+		return mcp.NewToolResultText(string(result)), nil
+	}
+
+	sections, drifted, err := parser.TitleListDrift(chapterID, locale)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error regenerating titleList: %v", err)), nil
+	}
+
+	response := struct {
+		Written   bool           `json:"written"`
+		TitleList []book.Section `json:"titleList"`
+		Drift     bool           `json:"drift"`
+	}{Written: false, TitleList: sections, Drift: drifted}
+	result, _ := json.MarshalIndent(response, "", "  ")
+	return mcp.NewToolResultText(string(result)), nil
+}
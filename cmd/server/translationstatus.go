@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Alan-TheGentleman/gentleman-book-mcp/internal/book"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// TranslationStatus categorizes how a section's translation compares to its
+// source, for the community translation effort to coordinate around.
+type TranslationStatus string
+
+const (
+	// TranslationTranslated means the section exists in both locales, its
+	// content isn't a near-duplicate of the source, and the source hasn't
+	// been edited since the translation's last commit.
+	TranslationTranslated TranslationStatus = "translated"
+	// TranslationOutdated means the section exists in both locales but
+	// either looks like an untouched copy of the source, or the source was
+	// edited more recently than the translation.
+	TranslationOutdated TranslationStatus = "outdated"
+	// TranslationMissing means the section doesn't exist in the target
+	// locale's chapter at all (or the chapter itself is missing).
+	TranslationMissing TranslationStatus = "missing"
+)
+
+// SectionTranslationStatus is one section's translation status relative to
+// sourceLocale, derived from the parity diff (missing sections, near-duplicate
+// content) and whichever side's chapter file was most recently touched in git.
+type SectionTranslationStatus struct {
+	SchemaVersion int               `json:"schemaVersion"`
+	ChapterID     string            `json:"chapterId"`
+	ChapterName   string            `json:"chapterName"`
+	TagID         string            `json:"tagId"`
+	SectionName   string            `json:"sectionName"`
+	Status        TranslationStatus `json:"status"`
+	Similarity    float64           `json:"similarity,omitempty"`
+}
+
+// getTranslationStatus builds a per-section translation status report for
+// every section in sourceLocale, evaluated against targetLocale.
+func getTranslationStatus(ctx context.Context, p *book.Parser, bookPath, sourceLocale, targetLocale string) ([]SectionTranslationStatus, error) {
+	sourceChapters, err := p.ListChapters(sourceLocale)
+	if err != nil {
+		return nil, err
+	}
+	targetChapters, err := p.ListChapters(targetLocale)
+	if err != nil {
+		return nil, err
+	}
+	targetByID := make(map[string]book.Chapter, len(targetChapters))
+	for _, c := range targetChapters {
+		targetByID[c.ID] = c
+	}
+
+	duplicateSections, err := p.DetectSectionDuplicates(sourceLocale, targetLocale)
+	if err != nil {
+		return nil, err
+	}
+	duplicates := make(map[string]float64, len(duplicateSections))
+	for _, d := range duplicateSections {
+		duplicates[sectionDuplicateKey(d.ChapterID, d.TagID)] = d.Similarity
+	}
+
+	var statuses []SectionTranslationStatus
+	for _, source := range sourceChapters {
+		target, hasTarget := targetByID[source.ID]
+
+		sourceCommit, _ := lastCommitTime(ctx, bookPath, source.FilePath)
+		var targetCommit time.Time
+		targetTagIDs := make(map[string]bool)
+		if hasTarget {
+			targetCommit, _ = lastCommitTime(ctx, bookPath, target.FilePath)
+			for _, s := range target.TitleList {
+				targetTagIDs[s.TagID] = true
+			}
+		}
+
+		for _, section := range source.TitleList {
+			status := SectionTranslationStatus{
+				SchemaVersion: book.SchemaVersion,
+				ChapterID:     source.ID,
+				ChapterName:   source.Name,
+				TagID:         section.TagID,
+				SectionName:   section.Name,
+			}
+
+			if !hasTarget || !targetTagIDs[section.TagID] {
+				status.Status = TranslationMissing
+				statuses = append(statuses, status)
+				continue
+			}
+
+			if similarity, isDuplicate := duplicates[sectionDuplicateKey(source.ID, section.TagID)]; isDuplicate {
+				status.Status = TranslationOutdated
+				status.Similarity = similarity
+			} else if !sourceCommit.IsZero() && !targetCommit.IsZero() && sourceCommit.After(targetCommit) {
+				status.Status = TranslationOutdated
+			} else {
+				status.Status = TranslationTranslated
+			}
+			statuses = append(statuses, status)
+		}
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].ChapterID != statuses[j].ChapterID {
+			return statuses[i].ChapterID < statuses[j].ChapterID
+		}
+		return statuses[i].TagID < statuses[j].TagID
+	})
+	return statuses, nil
+}
+
+func sectionDuplicateKey(chapterID, tagID string) string {
+	return chapterID + "|" + tagID
+}
+
+// lastCommitTime returns the timestamp of the most recent commit that
+// touched filePath within bookPath's git history.
+func lastCommitTime(ctx context.Context, bookPath, filePath string) (time.Time, error) {
+	rel, err := filepath.Rel(bookPath, filePath)
+	if err != nil {
+		rel = filePath
+	}
+	cmd := exec.CommandContext(ctx, "git", "-C", bookPath, "log", "-1", "--date=iso-strict", "--format=%ad", "--", filepath.ToSlash(rel))
+	out, err := cmd.Output()
+	if err != nil {
+		return time.Time{}, err
+	}
+	trimmed := strings.TrimSpace(string(out))
+	if trimmed == "" {
+		return time.Time{}, fmt.Errorf("no commit history for %s", rel)
+	}
+	return time.Parse(time.RFC3339, trimmed)
+}
+
+func handleGetTranslationStatus(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if res := requireNotDegraded(); res != nil {
+		return res, nil
+	}
+
+	sourceLocale := req.GetString("source_locale", "es")
+	targetLocale := req.GetString("target_locale", "en")
+	if res := validateArgLength(sourceLocale, "source_locale", maxShortArgLength); res != nil {
+		return res, nil
+	}
+	if res := validateArgLength(targetLocale, "target_locale", maxShortArgLength); res != nil {
+		return res, nil
+	}
+
+	statuses, err := getTranslationStatus(ctx, parser, bookPath, sourceLocale, targetLocale)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error computing translation status: %v", err)), nil
+	}
+
+	result, _ := json.MarshalIndent(statuses, "", "  ")
+	return mcp.NewToolResultText(string(result)), nil
+}
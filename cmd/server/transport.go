@@ -0,0 +1,60 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// defaultHTTPAddr is used by the sse/http transports when --addr isn't
+// given. stdio (the default transport) ignores it entirely.
+const defaultHTTPAddr = ":8090"
+
+// parseServerFlags parses the flags accepted by the normal (non-subcommand)
+// server run: which transport to serve the MCP protocol over, and where to
+// listen when that transport needs a network address.
+func parseServerFlags(args []string) (transport string, addr string) {
+	fs := flag.NewFlagSet("server", flag.ContinueOnError)
+	fs.SetOutput(log.Writer())
+	t := fs.String("transport", "stdio", "Transport to serve the MCP protocol over: stdio, sse, or http")
+	a := fs.String("addr", defaultHTTPAddr, "Listen address for the sse/http transports")
+	if err := fs.Parse(args); err != nil {
+		return "stdio", defaultHTTPAddr
+	}
+	return *t, *a
+}
+
+// sseBaseURL turns a listen address like ":8090" or "0.0.0.0:8090" into the
+// base URL SSE clients need to reach it, defaulting the host to localhost
+// since a bare port doesn't tell a remote client anything useful.
+func sseBaseURL(addr string) string {
+	host := addr
+	if strings.HasPrefix(addr, ":") {
+		host = "localhost" + addr
+	}
+	return "http://" + host
+}
+
+// serveTransport runs the MCP server over the requested transport, blocking
+// until it exits. stdio remains the default so existing single-client
+// setups (Claude Desktop, etc.) need no config changes; sse and http let one
+// indexed instance be shared over the network between several clients.
+func serveTransport(s *server.MCPServer, transport, addr string) error {
+	switch transport {
+	case "", "stdio":
+		return server.ServeStdio(s)
+	case "sse":
+		sseServer := server.NewSSEServer(s, server.WithBaseURL(sseBaseURL(addr)))
+		log.Printf("Serving MCP over SSE on %s", addr)
+		return sseServer.Start(addr)
+	case "http":
+		httpServer := server.NewStreamableHTTPServer(s)
+		log.Printf("Serving MCP over streamable HTTP on %s", addr)
+		return httpServer.Start(addr)
+	default:
+		return fmt.Errorf("unknown transport %q (expected stdio, sse, or http)", transport)
+	}
+}
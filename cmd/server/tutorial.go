@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Alan-TheGentleman/gentleman-book-mcp/internal/book"
+)
+
+// TutorialStep is one stop in an interactive tutorial sequence: read a
+// section, answer a quiz question tied to the section just read, work an
+// exercise, or (the final step) done.
+type TutorialStep struct {
+	Index        int    `json:"index"`
+	Kind         string `json:"kind"` // "read_section", "quiz", "exercise", or "done"
+	ChapterID    string `json:"chapterId"`
+	SectionTagID string `json:"sectionTagId,omitempty"`
+	SectionName  string `json:"sectionName,omitempty"`
+	Question     string `json:"question,omitempty"`
+	ExerciseID   string `json:"exerciseId,omitempty"`
+	ExerciseText string `json:"exerciseText,omitempty"`
+}
+
+// buildTutorialSteps walks chapterID's titleList in order, interleaving a
+// quiz step after any section whose tagId has review questions, then
+// appends every exercise in the chapter, then a final "done" step.
+func buildTutorialSteps(chapterID, locale string) ([]TutorialStep, error) {
+	chapter, err := parser.GetChapter(chapterID, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	questionsByTagID := make(map[string][]book.ReviewQuestion)
+	if questions, err := resolvedReviewQuestions(parser, chapterID, locale); err == nil {
+		for _, q := range questions {
+			questionsByTagID[q.TagID] = append(questionsByTagID[q.TagID], q)
+		}
+	}
+
+	var steps []TutorialStep
+	for _, section := range chapter.TitleList {
+		steps = append(steps, TutorialStep{
+			Kind:         "read_section",
+			ChapterID:    chapterID,
+			SectionTagID: section.TagID,
+			SectionName:  section.Name,
+		})
+		for _, q := range questionsByTagID[section.TagID] {
+			steps = append(steps, TutorialStep{
+				Kind:         "quiz",
+				ChapterID:    chapterID,
+				SectionTagID: section.TagID,
+				Question:     q.Question,
+			})
+		}
+	}
+
+	if exercises, err := parser.GetExercises(chapterID, locale); err == nil {
+		for _, e := range exercises {
+			steps = append(steps, TutorialStep{
+				Kind:         "exercise",
+				ChapterID:    chapterID,
+				ExerciseID:   e.ID,
+				ExerciseText: e.Text,
+			})
+		}
+	}
+
+	steps = append(steps, TutorialStep{Kind: "done", ChapterID: chapterID})
+	for i := range steps {
+		steps[i].Index = i
+	}
+	return steps, nil
+}
+
+// tutorialState is one reader's progress through a tutorial sequence.
+type tutorialState struct {
+	ID        string
+	ChapterID string
+	Locale    string
+	Steps     []TutorialStep
+	Current   int
+	LastSeen  time.Time
+}
+
+// currentStep returns the step the reader is on right now.
+func (t *tutorialState) currentStep() TutorialStep {
+	return t.Steps[t.Current]
+}
+
+// tutorialStore is an in-memory tutorial registry keyed by tutorial ID, the
+// same process-local, non-durable shape as sessionStore.
+type tutorialStore struct {
+	mu        sync.Mutex
+	tutorials map[string]*tutorialState
+}
+
+var tutorials = &tutorialStore{tutorials: make(map[string]*tutorialState)}
+
+func (t *tutorialStore) start(chapterID, locale string, steps []TutorialStep) *tutorialState {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state := &tutorialState{
+		ID:        newSessionID(),
+		ChapterID: chapterID,
+		Locale:    locale,
+		Steps:     steps,
+		LastSeen:  time.Now(),
+	}
+	t.tutorials[state.ID] = state
+	return state
+}
+
+// advance moves tutorialID to its next step (clamped at the last one) and
+// returns the resulting state.
+func (t *tutorialStore) advance(tutorialID string) (*tutorialState, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state, ok := t.tutorials[tutorialID]
+	if !ok {
+		return nil, fmt.Errorf("no tutorial found with id %q", tutorialID)
+	}
+	if state.Current < len(state.Steps)-1 {
+		state.Current++
+	}
+	state.LastSeen = time.Now()
+	return state, nil
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxQueryLength bounds search_book/semantic_search query strings. These get
+// scanned against every chapter (keyword search) or sent to an external
+// embeddings API (semantic search), so a megabyte-long query is either a
+// slow full-text scan or a costly, likely-truncated-anyway API call.
+const maxQueryLength = 2000
+
+// maxShortArgLength bounds short identifier-like arguments (chapter_id,
+// locale, tenant_id/book_id, section_id) that are never legitimately long.
+const maxShortArgLength = 200
+
+// maxTopK bounds semantic_search's top_k. Requesting 100000 results doesn't
+// get a client more relevant matches -- the index doesn't have that many
+// chunks -- it just forces the server to sort and serialize a huge slice.
+const maxTopK = 100
+
+// maxDraftLength bounds free-form prose args like check_style's draft: far
+// more generous than maxQueryLength since a real chapter draft can legitimately
+// run to several thousand words, but still well short of "someone pasted an
+// entire book" territory.
+const maxDraftLength = 50000
+
+// maxPathLength bounds file-path and URL arguments (book_path, index save/load
+// path, manifest_url). Real paths and URLs don't get anywhere near this; it
+// exists to reject garbage before it reaches the filesystem or an HTTP client.
+const maxPathLength = 4096
+
+// validateArgLength returns a ready-made tool error if value exceeds
+// maxLen, or nil if the caller may proceed.
+func validateArgLength(value, name string, maxLen int) *mcp.CallToolResult {
+	if len(value) > maxLen {
+		return mcp.NewToolResultError(fmt.Sprintf("%s is too long (%d characters, max %d)", name, len(value), maxLen))
+	}
+	return nil
+}
+
+// clampInt bounds value to [min, max], for numeric args like top_k where
+// silently capping an unreasonable request is friendlier than failing it
+// outright.
+func clampInt(value, min, max int) int {
+	if value < min {
+		return min
+	}
+	if value > max {
+		return max
+	}
+	return value
+}
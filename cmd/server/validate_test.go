@@ -0,0 +1,49 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestValidateArgLength(t *testing.T) {
+	if res := validateArgLength("short", "query", maxQueryLength); res != nil {
+		t.Fatalf("expected nil for a value under the limit, got %+v", res)
+	}
+
+	tooLong := strings.Repeat("a", maxShortArgLength+1)
+	res := validateArgLength(tooLong, "chapter_id", maxShortArgLength)
+	if res == nil || !res.IsError {
+		t.Fatalf("expected an error result for a value over the limit, got %+v", res)
+	}
+	text, ok := res.Content[0].(mcp.TextContent)
+	if !ok {
+		t.Fatalf("expected text content, got %T", res.Content[0])
+	}
+	if !strings.Contains(text.Text, "chapter_id") || !strings.Contains(text.Text, "too long") {
+		t.Fatalf("expected error message to name the arg and say it's too long, got %q", text.Text)
+	}
+
+	// Exactly at the limit is allowed; one over is not.
+	if res := validateArgLength(strings.Repeat("a", maxShortArgLength), "chapter_id", maxShortArgLength); res != nil {
+		t.Fatalf("expected nil at exactly maxLen, got %+v", res)
+	}
+}
+
+func TestClampInt(t *testing.T) {
+	cases := []struct {
+		value, min, max, want int
+	}{
+		{5, 0, 10, 5},
+		{-5, 0, 10, 0},
+		{50, 0, 10, 10},
+		{0, 0, 10, 0},
+		{10, 0, 10, 10},
+	}
+	for _, c := range cases {
+		if got := clampInt(c.value, c.min, c.max); got != c.want {
+			t.Errorf("clampInt(%d, %d, %d) = %d, want %d", c.value, c.min, c.max, got, c.want)
+		}
+	}
+}
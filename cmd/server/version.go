@@ -0,0 +1,60 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// version, gitCommit and buildDate are injected at build time via:
+//
+//	go build -ldflags "-X main.version=v1.2.3 -X main.gitCommit=$(git rev-parse HEAD) -X main.buildDate=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// They default to "dev"/"unknown" for local `go run`/`go build` invocations.
+var (
+	version   = "dev"
+	gitCommit = "unknown"
+	buildDate = "unknown"
+)
+
+// bookContentCommit returns the git HEAD commit of the book content directory,
+// if it is itself a git checkout, so bug reports can pin down exactly which
+// revision of the book was loaded.
+func bookContentCommit(bookPath string) string {
+	cmd := exec.Command("git", "-C", bookPath, "rev-parse", "HEAD")
+	cmd.Stderr = nil
+	out, err := cmd.Output()
+	if err != nil {
+		return "unknown"
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func configuredProviders() []string {
+	var providers []string
+	if os.Getenv("OPENAI_API_KEY") != "" {
+		providers = append(providers, "openai")
+	}
+	if os.Getenv("COHERE_API_KEY") != "" {
+		providers = append(providers, "cohere")
+	}
+	if os.Getenv("VOYAGE_API_KEY") != "" {
+		providers = append(providers, "voyage")
+	}
+	if os.Getenv("GEMINI_API_KEY") != "" {
+		providers = append(providers, "gemini")
+	}
+	providers = append(providers, "ollama") // always attempted, availability checked at startup
+	return providers
+}
+
+func enabledFeatures() []string {
+	features := []string{"keyword_search"}
+	if semanticEngine != nil {
+		features = append(features, "semantic_search")
+	}
+	if prefetchEnabled {
+		features = append(features, "prefetch_adjacent_sections")
+	}
+	return features
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// writeToolsEnabledEnvVar gates every tool that writes back to the book's
+// content files. Off by default: most deployments only read the book, and
+// a misconfigured MCP client shouldn't be able to mutate an author's
+// working tree without an explicit opt-in.
+const writeToolsEnabledEnvVar = "ENABLE_WRITE_TOOLS"
+
+func writeToolsEnabled() bool {
+	return os.Getenv(writeToolsEnabledEnvVar) == "true"
+}
+
+// requireWriteToolsEnabled returns a ready-made error result if write tools
+// are disabled, or nil if the caller may proceed.
+func requireWriteToolsEnabled() *mcp.CallToolResult {
+	if writeToolsEnabled() {
+		return nil
+	}
+	return mcp.NewToolResultError(fmt.Sprintf("This tool writes to the book's content files and is disabled. Set %s=true to enable it.", writeToolsEnabledEnvVar))
+}
+
+// gitDirtyWarning checks whether filePath already has uncommitted changes
+// in bookPath's git history, so a write tool can warn an author before
+// stacking its own edit on top of in-progress, unsaved work. A git failure
+// (e.g. bookPath isn't a git checkout) is treated as "can't tell" rather
+// than blocking the write.
+func gitDirtyWarning(bookPath, filePath string) string {
+	rel, err := filepath.Rel(bookPath, filePath)
+	if err != nil {
+		rel = filePath
+	}
+	cmd := exec.Command("git", "-C", bookPath, "status", "--porcelain", "--", filepath.ToSlash(rel))
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	if strings.TrimSpace(string(out)) == "" {
+		return ""
+	}
+	return fmt.Sprintf("warning: %s already has uncommitted changes in git; this write is stacked on top of them", rel)
+}
+
+func handleUpdateChapterOrder(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if res := requireNotDegraded(); res != nil {
+		return res, nil
+	}
+	if res := requireWriteToolsEnabled(); res != nil {
+		return res, nil
+	}
+
+	chapterID := req.GetString("chapter_id", "")
+	if chapterID == "" {
+		return mcp.NewToolResultError("chapter_id is required"), nil
+	}
+	if res := validateArgLength(chapterID, "chapter_id", maxShortArgLength); res != nil {
+		return res, nil
+	}
+	locale := req.GetString("locale", "es")
+	if res := validateArgLength(locale, "locale", maxShortArgLength); res != nil {
+		return res, nil
+	}
+	order := req.GetInt("order", 0)
+
+	chapter, err := parser.GetChapter(chapterID, locale)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	warning := gitDirtyWarning(bookPath, chapter.FilePath)
+
+	if err := parser.WriteChapterOrder(chapterID, locale, order); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error writing order: %v", err)), nil
+	}
+
+	message := fmt.Sprintf("Updated %s's order to %d.", chapterID, order)
+	if warning != "" {
+		message += " " + warning
+	}
+	return mcp.NewToolResultText(message), nil
+}
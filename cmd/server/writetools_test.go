@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Alan-TheGentleman/gentleman-book-mcp/internal/book"
+)
+
+func writeChapterFixture(t *testing.T, dir, id, name string, order int, content string) string {
+	t.Helper()
+	mdx := fmt.Sprintf("---\nid: %q\norder: %d\nname: %q\ntitleList: []\n---\n%s", id, order, name, content)
+	path := filepath.Join(dir, id+".mdx")
+	if err := os.WriteFile(path, []byte(mdx), 0o644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+	return path
+}
+
+func TestWriteToolsEnabled(t *testing.T) {
+	os.Unsetenv(writeToolsEnabledEnvVar)
+	if writeToolsEnabled() {
+		t.Fatal("expected write tools to default to disabled")
+	}
+	if res := requireWriteToolsEnabled(); res == nil || !res.IsError {
+		t.Fatal("expected requireWriteToolsEnabled to return an error while disabled")
+	}
+
+	t.Setenv(writeToolsEnabledEnvVar, "true")
+	if !writeToolsEnabled() {
+		t.Fatal("expected write tools to be enabled once the env var is set to \"true\"")
+	}
+	if res := requireWriteToolsEnabled(); res != nil {
+		t.Fatalf("expected requireWriteToolsEnabled to return nil once enabled, got %+v", res)
+	}
+
+	t.Setenv(writeToolsEnabledEnvVar, "1")
+	if writeToolsEnabled() {
+		t.Fatal(`expected only the exact value "true" to enable write tools, not "1"`)
+	}
+}
+
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com", "GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v: %s", args, err, out)
+	}
+}
+
+func TestGitDirtyWarningCleanTree(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "ch1.mdx")
+	os.WriteFile(path, []byte("content"), 0o644)
+
+	runGit(t, dir, "init", "-q")
+	runGit(t, dir, "add", ".")
+	runGit(t, dir, "commit", "-q", "-m", "initial")
+
+	if warning := gitDirtyWarning(dir, path); warning != "" {
+		t.Fatalf("expected no warning for a clean tree, got %q", warning)
+	}
+}
+
+func TestGitDirtyWarningDirtyTree(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "ch1.mdx")
+	os.WriteFile(path, []byte("content"), 0o644)
+
+	runGit(t, dir, "init", "-q")
+	runGit(t, dir, "add", ".")
+	runGit(t, dir, "commit", "-q", "-m", "initial")
+
+	os.WriteFile(path, []byte("uncommitted edit"), 0o644)
+
+	warning := gitDirtyWarning(dir, path)
+	if warning == "" || !strings.Contains(warning, "uncommitted changes") {
+		t.Fatalf("expected a dirty-tree warning, got %q", warning)
+	}
+}
+
+func TestGitDirtyWarningNonGitDirectory(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "ch1.mdx")
+	os.WriteFile(path, []byte("content"), 0o644)
+
+	if warning := gitDirtyWarning(dir, path); warning != "" {
+		t.Fatalf("expected no warning (can't tell, not blocking) outside a git checkout, got %q", warning)
+	}
+}
+
+func TestHandleUpdateChapterOrderRequiresWriteToolsEnabled(t *testing.T) {
+	os.Unsetenv(writeToolsEnabledEnvVar)
+	res, err := handleUpdateChapterOrder(context.Background(), newToolRequest(map[string]any{"chapter_id": "ch1"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res == nil || !res.IsError {
+		t.Fatalf("expected an error result while write tools are disabled, got %+v", res)
+	}
+}
+
+func TestHandleUpdateChapterOrderPersistsToDisk(t *testing.T) {
+	t.Setenv(writeToolsEnabledEnvVar, "true")
+
+	dir := t.TempDir()
+	localeDir := filepath.Join(dir, "en")
+	os.Mkdir(localeDir, 0o755)
+	writeChapterFixture(t, localeDir, "ch1", "Chapter One", 1, "# Intro\nHello.")
+
+	origParser, origBookPath, origDegraded := parser, bookPath, degraded
+	t.Cleanup(func() { parser, bookPath, degraded = origParser, origBookPath, origDegraded })
+	parser = book.NewParser(dir)
+	bookPath = dir
+	degraded = false
+
+	res, err := handleUpdateChapterOrder(context.Background(), newToolRequest(map[string]any{
+		"chapter_id": "ch1",
+		"locale":     "en",
+		"order":      float64(9),
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res == nil || res.IsError {
+		t.Fatalf("expected success, got %+v", res)
+	}
+
+	reparsed := book.NewParser(dir)
+	chapter, err := reparsed.GetChapter("ch1", "en")
+	if err != nil {
+		t.Fatalf("GetChapter after write: %v", err)
+	}
+	if chapter.Order != 9 {
+		t.Fatalf("expected order 9 to be persisted, got %d", chapter.Order)
+	}
+}
+
+func TestHandleUpdateChapterOrderRequiresChapterID(t *testing.T) {
+	t.Setenv(writeToolsEnabledEnvVar, "true")
+	origDegraded := degraded
+	t.Cleanup(func() { degraded = origDegraded })
+	degraded = false
+
+	res, err := handleUpdateChapterOrder(context.Background(), newToolRequest(map[string]any{}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res == nil || !res.IsError {
+		t.Fatalf("expected an error result when chapter_id is missing, got %+v", res)
+	}
+}
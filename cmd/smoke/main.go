@@ -0,0 +1,225 @@
+// Command smoke exercises the book/embeddings layer that every MCP tool in
+// cmd/server is a thin wrapper over, against a small in-memory fixture book,
+// and prints a PASS/FAIL line per capability. It's a one-command way to
+// verify an installation end to end before pointing a real MCP client (or
+// the MCP Inspector) at the server binary.
+//
+// It does not speak the MCP JSON-RPC protocol itself — for that, run the
+// server binary under the MCP Inspector. What it verifies is that the
+// underlying parsing, search, and semantic-search code this server depends
+// on actually works in this build.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Alan-TheGentleman/gentleman-book-mcp/internal/book"
+	"github.com/Alan-TheGentleman/gentleman-book-mcp/internal/embeddings"
+)
+
+type smokeCheck struct {
+	name string
+	run  func(p *book.Parser) error
+}
+
+func main() {
+	dir, err := os.MkdirTemp("", "gentleman-smoke-*")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to create fixture book: %v\n", err)
+		os.Exit(1)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := writeFixtureBook(dir); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write fixture book: %v\n", err)
+		os.Exit(1)
+	}
+
+	p := book.NewParser(dir)
+
+	checks := []smokeCheck{
+		{"list_chapters", checkListChapters},
+		{"read_chapter", checkReadChapter},
+		{"search_book", checkSearchBook},
+		{"get_checklists", checkGetChecklists},
+		{"find_quote", checkFindQuote},
+		{"verify_claim", checkVerifyClaim},
+		{"get_book_index", checkGetBookIndex},
+		{"get_prerequisites", checkGetPrerequisites},
+		{"semantic_search (fake provider)", checkSemanticSearch},
+	}
+
+	failed := 0
+	for _, c := range checks {
+		if err := c.run(p); err != nil {
+			fmt.Printf("FAIL %-32s %v\n", c.name, err)
+			failed++
+		} else {
+			fmt.Printf("PASS %-32s\n", c.name)
+		}
+	}
+
+	fmt.Printf("\n%d/%d checks passed\n", len(checks)-failed, len(checks))
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+func checkListChapters(p *book.Parser) error {
+	chapters, err := p.ListChapters("en")
+	if err != nil {
+		return err
+	}
+	if len(chapters) == 0 {
+		return fmt.Errorf("expected at least one chapter")
+	}
+	return nil
+}
+
+func checkReadChapter(p *book.Parser) error {
+	chapter, err := p.GetChapter("smoke-chapter", "en")
+	if err != nil {
+		return err
+	}
+	if chapter.Content == "" {
+		return fmt.Errorf("expected non-empty chapter content")
+	}
+	return nil
+}
+
+func checkSearchBook(p *book.Parser) error {
+	results, err := p.Search("gentleman", "en")
+	if err != nil {
+		return err
+	}
+	if len(results) == 0 {
+		return fmt.Errorf("expected at least one search result")
+	}
+	return nil
+}
+
+func checkGetChecklists(p *book.Parser) error {
+	items, err := p.GetChecklists("", "en")
+	if err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		return fmt.Errorf("expected at least one checklist item")
+	}
+	return nil
+}
+
+func checkFindQuote(p *book.Parser) error {
+	_, err := p.FindQuote("a true gentleman ships working code", "en")
+	return err
+}
+
+func checkVerifyClaim(p *book.Parser) error {
+	v, err := p.VerifyClaim("a true gentleman always ships working code before talking about it", "en")
+	if err != nil {
+		return err
+	}
+	if v.Verdict != book.VerdictSupported {
+		return fmt.Errorf("expected verdict %q, got %q", book.VerdictSupported, v.Verdict)
+	}
+	return nil
+}
+
+func checkGetBookIndex(p *book.Parser) error {
+	index, err := p.GetBookIndex("en")
+	if err != nil {
+		return err
+	}
+	if index.TotalChapters == 0 {
+		return fmt.Errorf("expected at least one chapter in the index")
+	}
+	return nil
+}
+
+func checkGetPrerequisites(p *book.Parser) error {
+	prereqs, err := p.GetPrerequisites("smoke-chapter-2", "en")
+	if err != nil {
+		return err
+	}
+	if len(prereqs) != 1 || prereqs[0] != "smoke-chapter" {
+		return fmt.Errorf("expected prerequisites [smoke-chapter], got %v", prereqs)
+	}
+	return nil
+}
+
+func checkSemanticSearch(p *book.Parser) error {
+	engine, err := embeddings.NewSemanticEngine(embeddings.ProviderFake)
+	if err != nil {
+		return err
+	}
+
+	chapters, err := p.ListChapters("en")
+	if err != nil {
+		return err
+	}
+
+	var chunks []embeddings.Chunk
+	for i, chapter := range chapters {
+		chunks = append(chunks, embeddings.Chunk{
+			ID:          fmt.Sprintf("chunk_%d", i),
+			ChapterID:   chapter.ID,
+			ChapterName: chapter.Name,
+			Content:     chapter.Content,
+			Locale:      "en",
+		})
+	}
+
+	ctx := context.Background()
+	if err := engine.IndexChunks(ctx, chunks); err != nil {
+		return err
+	}
+
+	results, err := engine.Search(ctx, "gentleman", "en", 3)
+	if err != nil {
+		return err
+	}
+	if len(results) == 0 {
+		return fmt.Errorf("expected at least one semantic result")
+	}
+	return nil
+}
+
+// writeFixtureBook writes a tiny but representative fixture book (two
+// chapters, a prerequisite link, a checklist, and a quotable line) so every
+// check above has something real to exercise.
+func writeFixtureBook(dir string) error {
+	localeDir := filepath.Join(dir, "en")
+	if err := os.Mkdir(localeDir, 0o755); err != nil {
+		return err
+	}
+
+	chapter1 := "---\n" +
+		`id: "smoke-chapter"` + "\n" +
+		"order: 1\n" +
+		`name: "Smoke Chapter"` + "\n" +
+		"titleList: []\n" +
+		"---\n" +
+		"# Intro\n" +
+		"A true gentleman always ships working code before talking about it.\n\n" +
+		"## Code Review Checklist\n" +
+		"- [ ] Tests pass locally\n" +
+		"- [ ] No leftover debug statements\n"
+
+	chapter2 := "---\n" +
+		`id: "smoke-chapter-2"` + "\n" +
+		"order: 2\n" +
+		`name: "Smoke Chapter 2"` + "\n" +
+		"titleList: []\n" +
+		`prerequisites: ["smoke-chapter"]` + "\n" +
+		"---\n" +
+		"# Intro\n" +
+		"This chapter builds on the gentleman fundamentals from chapter one.\n"
+
+	if err := os.WriteFile(filepath.Join(localeDir, "smoke-chapter.mdx"), []byte(chapter1), 0o644); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(localeDir, "smoke-chapter-2.mdx"), []byte(chapter2), 0o644)
+}
@@ -0,0 +1,231 @@
+package book
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/mapping"
+
+	// Registers every stock analyzer/tokenizer/token-filter bleve ships with,
+	// including the "en" and "es" language analyzers newBleveIndex selects
+	// below -- bleve.New(Mem)Only doesn't pull these in on its own.
+	_ "github.com/blevesearch/bleve/v2/config"
+)
+
+// bleveSearchBackendValue is SEARCH_BACKEND's value for the bleve keyword
+// backend -- see searchBackendEnvVar.
+const bleveSearchBackendValue = "bleve"
+
+func bleveSearchEnabled() bool {
+	return os.Getenv(searchBackendEnvVar) == bleveSearchBackendValue
+}
+
+// bleveAnalyzerForLocale picks the bleve language analyzer (stemming +
+// stopwords) matching locale, falling back to bleve's language-neutral
+// standard analyzer for any locale without one.
+func bleveAnalyzerForLocale(locale string) string {
+	switch locale {
+	case "en", "es":
+		return locale
+	default:
+		return "standard"
+	}
+}
+
+// bleveIndex is a per-locale, in-memory bleve full-text index of chapter
+// content, analogous to fts5Index: sync re-indexes only the chapters whose
+// content hash has changed. It's kept in memory for the life of the
+// process rather than written to disk, the same way the rest of the
+// semantic index is (see SemanticEngine) -- there's no on-disk persistence
+// layer in this server to put it "alongside" yet.
+type bleveIndex struct {
+	locale string
+	idx    bleve.Index
+
+	mu     sync.Mutex
+	hashes map[string]string // chapterID -> sha256 of the content currently indexed for it
+}
+
+// bleveDoc is one chapter's indexed document.
+type bleveDoc struct {
+	ChapterID   string `json:"chapterId"`
+	ChapterName string `json:"chapterName"`
+	Content     string `json:"content"`
+}
+
+func newBleveIndex(locale string) (*bleveIndex, error) {
+	contentField := mapping.NewTextFieldMapping()
+	contentField.Analyzer = bleveAnalyzerForLocale(locale)
+
+	keywordField := mapping.NewTextFieldMapping()
+	keywordField.Analyzer = "keyword"
+
+	docMapping := bleve.NewDocumentMapping()
+	docMapping.AddFieldMappingsAt("content", contentField)
+	docMapping.AddFieldMappingsAt("chapterId", keywordField)
+	docMapping.AddFieldMappingsAt("chapterName", keywordField)
+
+	indexMapping := bleve.NewIndexMapping()
+	indexMapping.DefaultMapping = docMapping
+
+	idx, err := bleve.NewMemOnly(indexMapping)
+	if err != nil {
+		return nil, fmt.Errorf("opening bleve index for locale %s: %w", locale, err)
+	}
+	return &bleveIndex{locale: locale, idx: idx, hashes: make(map[string]string)}, nil
+}
+
+// sync brings the index up to date with chapters, re-indexing only the
+// chapters whose content hash has changed (or are new) and removing
+// chapters that have disappeared, mirroring fts5Index.sync.
+func (idx *bleveIndex) sync(chapters []Chapter) error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	seen := make(map[string]bool, len(chapters))
+	for _, ch := range chapters {
+		seen[ch.ID] = true
+		hash := contentHash(ch.Content)
+		if idx.hashes[ch.ID] == hash {
+			continue
+		}
+		doc := bleveDoc{ChapterID: ch.ID, ChapterName: ch.Name, Content: ch.Content}
+		if err := idx.idx.Index(ch.ID, doc); err != nil {
+			return fmt.Errorf("bleve: indexing chapter %s: %w", ch.ID, err)
+		}
+		idx.hashes[ch.ID] = hash
+	}
+
+	for chapterID := range idx.hashes {
+		if seen[chapterID] {
+			continue
+		}
+		if err := idx.idx.Delete(chapterID); err != nil {
+			return fmt.Errorf("bleve: removing deleted chapter %s: %w", chapterID, err)
+		}
+		delete(idx.hashes, chapterID)
+	}
+	return nil
+}
+
+// search runs query against idx and reduces the hits to SearchResults,
+// recovering LineNumber/Section from chapters the same way searchFTS5 does.
+func (idx *bleveIndex) search(chapters []Chapter, query, locale string, explain bool) ([]SearchResult, error) {
+	queryWords := strings.Fields(strings.ToLower(query))
+	if len(queryWords) == 0 {
+		return nil, nil
+	}
+
+	chapterByID := make(map[string]Chapter, len(chapters))
+	for _, ch := range chapters {
+		chapterByID[ch.ID] = ch
+	}
+
+	matchQuery := bleve.NewMatchQuery(query)
+	matchQuery.SetField("content")
+	req := bleve.NewSearchRequestOptions(matchQuery, 20, 0, false)
+	req.Highlight = bleve.NewHighlight()
+	req.Fields = []string{"chapterId", "chapterName"}
+
+	idx.mu.Lock()
+	res, err := idx.idx.Search(req)
+	idx.mu.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("bleve: searching locale %s: %w", locale, err)
+	}
+
+	results := make([]SearchResult, 0, len(res.Hits))
+	for _, hit := range res.Hits {
+		chapterID, _ := hit.Fields["chapterId"].(string)
+		chapterName, _ := hit.Fields["chapterName"].(string)
+
+		snippet := ""
+		if frags := hit.Fragments["content"]; len(frags) > 0 {
+			snippet = frags[0]
+		}
+
+		var matchedTerms []string
+		snippetLower := strings.ToLower(snippet)
+		for _, w := range queryWords {
+			if strings.Contains(snippetLower, w) {
+				matchedTerms = append(matchedTerms, w)
+			}
+		}
+
+		lineNumber, section := 0, ""
+		if ch, ok := chapterByID[chapterID]; ok {
+			lineNumber, section = locateInChapter(ch.Content, queryWords)
+		}
+
+		result := SearchResult{
+			ChapterID:       chapterID,
+			ChapterName:     chapterName,
+			Section:         section,
+			Snippet:         Truncate(stripHighlightMarkup(snippet), 200),
+			LineNumber:      lineNumber,
+			Relevance:       hit.Score,
+			Locale:          locale,
+			EstimatedTokens: EstimateTokens(snippet),
+			Tone:            ToneLabelStrings(ClassifyTone(snippet)),
+		}
+		if explain {
+			result.Explain = &ScoreExplain{
+				MatchedTerms: matchedTerms,
+				QueryTerms:   len(queryWords),
+				MatchedCount: len(matchedTerms),
+			}
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// stripHighlightMarkup removes bleve's default <mark>...</mark> highlight
+// tags from a fragment, since SearchResult.Snippet is plain text everywhere
+// else in this API.
+func stripHighlightMarkup(s string) string {
+	s = strings.ReplaceAll(s, "<mark>", "")
+	s = strings.ReplaceAll(s, "</mark>", "")
+	return s
+}
+
+// BuildBleveIndex precomputes locale's bleve index so the first
+// SEARCH_BACKEND=bleve search after startup doesn't pay the build cost. A
+// no-op when bleve isn't selected or in low-memory mode -- same precedent
+// as BuildKeywordIndex/BuildFTS5Index.
+func (p *Parser) BuildBleveIndex(locale string) error {
+	if p.lowMemoryMode || !bleveSearchEnabled() {
+		return nil
+	}
+	chapters, err := p.ListChapters(locale)
+	if err != nil {
+		return err
+	}
+	_, err = p.bleveIndexFor(locale, chapters)
+	return err
+}
+
+// bleveIndexFor returns locale's bleve index, creating it lazily on first
+// use, and keeps it in sync with chapters on every call.
+func (p *Parser) bleveIndexFor(locale string, chapters []Chapter) (*bleveIndex, error) {
+	if cached, ok := p.bleveIndexes.Load(locale); ok {
+		idx := cached.(*bleveIndex)
+		if err := idx.sync(chapters); err != nil {
+			return nil, err
+		}
+		return idx, nil
+	}
+
+	idx, err := newBleveIndex(locale)
+	if err != nil {
+		return nil, err
+	}
+	if err := idx.sync(chapters); err != nil {
+		return nil, err
+	}
+	p.bleveIndexes.Store(locale, idx)
+	return idx, nil
+}
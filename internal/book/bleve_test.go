@@ -0,0 +1,119 @@
+package book
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestSearchExplainUsesBleveWhenEnabled verifies that with
+// SEARCH_BACKEND=bleve set, Search is served by the bleve backend and finds
+// the right chapter even via a stemmed form of the query word.
+func TestSearchExplainUsesBleveWhenEnabled(t *testing.T) {
+	t.Setenv(searchBackendEnvVar, bleveSearchBackendValue)
+
+	dir := t.TempDir()
+	localeDir := filepath.Join(dir, "en")
+	if err := os.Mkdir(localeDir, 0o755); err != nil {
+		t.Fatalf("failed to create locale dir: %v", err)
+	}
+	writeSearchFixture(t, localeDir, "testing", "Testing", 1, "# Intro\nWe write unit tests here.")
+	writeSearchFixture(t, localeDir, "other", "Other", 2, "# Intro\nThis chapter is about something else.")
+
+	p := NewParser(dir)
+
+	// The English analyzer's porter stemmer should fold "testing" down to
+	// the same stem as "tests", so the query matches despite not sharing a
+	// literal substring the way the memory/fts5 backends require.
+	results, err := p.Search("testing", "en")
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if len(results) != 1 || results[0].ChapterID != "testing" {
+		t.Fatalf("expected 1 result from chapter %q, got %+v", "testing", results)
+	}
+}
+
+// TestSearchExplainBleveIgnoredInLowMemoryMode verifies low-memory mode
+// takes priority over SEARCH_BACKEND=bleve, same as it does for fts5.
+func TestSearchExplainBleveIgnoredInLowMemoryMode(t *testing.T) {
+	t.Setenv(searchBackendEnvVar, bleveSearchBackendValue)
+
+	dir := t.TempDir()
+	localeDir := filepath.Join(dir, "en")
+	if err := os.Mkdir(localeDir, 0o755); err != nil {
+		t.Fatalf("failed to create locale dir: %v", err)
+	}
+	writeSearchFixture(t, localeDir, "testing", "Testing", 1, "# Intro\nWe write unit tests here.")
+
+	p := NewParser(dir)
+	p.SetLowMemoryMode(true)
+
+	results, err := p.Search("unit tests", "en")
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %+v", results)
+	}
+}
+
+// TestBleveIndexSyncSkipsUnchangedChapters verifies sync only re-indexes a
+// chapter when its content hash has actually changed.
+func TestBleveIndexSyncSkipsUnchangedChapters(t *testing.T) {
+	idx, err := newBleveIndex("en")
+	if err != nil {
+		t.Fatalf("newBleveIndex returned error: %v", err)
+	}
+	defer idx.idx.Close()
+
+	chapters := []Chapter{{ID: "a", Name: "A", Content: "hello world"}}
+	if err := idx.sync(chapters); err != nil {
+		t.Fatalf("sync returned error: %v", err)
+	}
+	firstHash := idx.hashes["a"]
+
+	chapters[0].Content = "hello there"
+	if err := idx.sync(chapters); err != nil {
+		t.Fatalf("second sync returned error: %v", err)
+	}
+	if idx.hashes["a"] == firstHash {
+		t.Fatalf("expected hash to change after content changed")
+	}
+
+	doc, err := idx.idx.Document("a")
+	if err != nil {
+		t.Fatalf("fetching indexed document: %v", err)
+	}
+	if doc == nil {
+		t.Fatal("expected document to be indexed")
+	}
+}
+
+// TestBleveIndexSyncRemovesDeletedChapters verifies a chapter dropped
+// between syncs is removed from the index, not left stale.
+func TestBleveIndexSyncRemovesDeletedChapters(t *testing.T) {
+	idx, err := newBleveIndex("en")
+	if err != nil {
+		t.Fatalf("newBleveIndex returned error: %v", err)
+	}
+	defer idx.idx.Close()
+
+	if err := idx.sync([]Chapter{{ID: "a", Name: "A", Content: "hello"}}); err != nil {
+		t.Fatalf("sync returned error: %v", err)
+	}
+	if err := idx.sync(nil); err != nil {
+		t.Fatalf("sync with no chapters returned error: %v", err)
+	}
+
+	count, err := idx.idx.DocCount()
+	if err != nil {
+		t.Fatalf("DocCount returned error: %v", err)
+	}
+	if count != 0 {
+		t.Fatalf("expected 0 docs after chapter removal, got %d", count)
+	}
+	if len(idx.hashes) != 0 {
+		t.Fatalf("expected hashes to be cleared, got %v", idx.hashes)
+	}
+}
@@ -0,0 +1,65 @@
+package book
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestParseChapterCachesUntilFileChanges verifies that editing a chapter
+// file on disk without touching its mtime is invisible to the parser (the
+// cached parse is reused), while a later mtime bump picks up the new
+// content.
+func TestParseChapterCachesUntilFileChanges(t *testing.T) {
+	dir := t.TempDir()
+	localeDir := filepath.Join(dir, "en")
+	if err := os.Mkdir(localeDir, 0o755); err != nil {
+		t.Fatalf("failed to create locale dir: %v", err)
+	}
+	writeSearchFixture(t, localeDir, "ch1", "Chapter One", 1, "# Intro\nOriginal.")
+	filePath := filepath.Join(localeDir, "ch1.mdx")
+
+	info, err := os.Stat(filePath)
+	if err != nil {
+		t.Fatalf("failed to stat fixture: %v", err)
+	}
+	modTime := info.ModTime()
+
+	p := NewParser(dir)
+	chapter, err := p.ParseChapter(filePath, "en")
+	if err != nil {
+		t.Fatalf("ParseChapter returned error: %v", err)
+	}
+	if chapter.Content != "# Intro\nOriginal." {
+		t.Fatalf("unexpected initial content: %q", chapter.Content)
+	}
+
+	// Rewrite the file with the same mtime: the cached parse should win.
+	writeSearchFixture(t, localeDir, "ch1", "Chapter One", 1, "# Intro\nChanged without touching mtime.")
+	if err := os.Chtimes(filePath, modTime, modTime); err != nil {
+		t.Fatalf("failed to reset mtime: %v", err)
+	}
+
+	chapter, err = p.ParseChapter(filePath, "en")
+	if err != nil {
+		t.Fatalf("ParseChapter returned error: %v", err)
+	}
+	if chapter.Content != "# Intro\nOriginal." {
+		t.Fatalf("expected cached content to survive an mtime-less rewrite, got %q", chapter.Content)
+	}
+
+	// Bump the mtime: the cache should now be invalidated.
+	newModTime := modTime.Add(time.Second)
+	if err := os.Chtimes(filePath, newModTime, newModTime); err != nil {
+		t.Fatalf("failed to bump mtime: %v", err)
+	}
+
+	chapter, err = p.ParseChapter(filePath, "en")
+	if err != nil {
+		t.Fatalf("ParseChapter returned error: %v", err)
+	}
+	if chapter.Content != "# Intro\nChanged without touching mtime." {
+		t.Fatalf("expected re-parsed content after mtime change, got %q", chapter.Content)
+	}
+}
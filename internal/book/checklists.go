@@ -0,0 +1,109 @@
+package book
+
+import (
+	"bufio"
+	"regexp"
+	"strings"
+)
+
+// ChecklistItem is one actionable line item extracted from a checklist-like
+// list in the book, with enough context to be turned into a task by a client.
+type ChecklistItem struct {
+	ChapterID   string `json:"chapterId"`
+	ChapterName string `json:"chapterName"`
+	Section     string `json:"section"`
+	Text        string `json:"text"`
+	LineNumber  int    `json:"lineNumber"`
+	Checked     bool   `json:"checked"`
+	Locale      string `json:"locale"`
+}
+
+var (
+	checklistHeaderPattern = regexp.MustCompile(`^#{1,6}\s+(.+)$`)
+	checklistCheckboxLine  = regexp.MustCompile(`^[-*]\s+\[([ xX])\]\s+(.+)$`)
+	checklistListLine      = regexp.MustCompile(`^(?:[-*]|\d+\.)\s+(.+)$`)
+)
+
+// checklistSectionKeywords mark a section as checklist-like even when its
+// items aren't written as "- [ ]" checkboxes (e.g. a plain "Definition of
+// Done" bullet list).
+var checklistSectionKeywords = []string{
+	"checklist", "definition of done", "dod",
+	"lista de verificación", "lista de chequeo",
+}
+
+func sectionLooksLikeChecklist(section string) bool {
+	section = strings.ToLower(section)
+	for _, kw := range checklistSectionKeywords {
+		if strings.Contains(section, kw) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetChecklists extracts actionable checklist items from every chapter in
+// locale, or just chapterID when non-empty.
+func (p *Parser) GetChecklists(chapterID, locale string) ([]ChecklistItem, error) {
+	chapters, err := p.ListChapters(locale)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []ChecklistItem
+
+	for _, chapter := range chapters {
+		if chapterID != "" && chapter.ID != chapterID {
+			continue
+		}
+
+		scanner := bufio.NewScanner(strings.NewReader(chapter.Content))
+		lineNum := 0
+		currentSection := ""
+
+		for scanner.Scan() {
+			lineNum++
+			line := scanner.Text()
+			trimmed := strings.TrimSpace(line)
+
+			if matches := checklistHeaderPattern.FindStringSubmatch(trimmed); len(matches) > 1 {
+				currentSection = matches[1]
+				continue
+			}
+
+			if matches := checklistCheckboxLine.FindStringSubmatch(trimmed); len(matches) > 2 {
+				items = append(items, ChecklistItem{
+					ChapterID:   chapter.ID,
+					ChapterName: chapter.Name,
+					Section:     currentSection,
+					Text:        matches[2],
+					LineNumber:  lineNum,
+					Checked:     strings.EqualFold(matches[1], "x"),
+					Locale:      locale,
+				})
+				continue
+			}
+
+			if sectionLooksLikeChecklist(currentSection) {
+				if matches := checklistListLine.FindStringSubmatch(trimmed); len(matches) > 1 {
+					items = append(items, ChecklistItem{
+						ChapterID:   chapter.ID,
+						ChapterName: chapter.Name,
+						Section:     currentSection,
+						Text:        matches[1],
+						LineNumber:  lineNum,
+						Locale:      locale,
+					})
+				}
+			}
+		}
+	}
+
+	if chapterID != "" && len(items) == 0 {
+		if _, err := p.GetChapter(chapterID, locale); err != nil {
+			return nil, err
+		}
+	}
+
+	return items, nil
+}
@@ -0,0 +1,131 @@
+package book
+
+import (
+	"bufio"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Exercise is one practice item for a chapter, either declared by the
+// author in frontmatter or pattern-matched from an exercises-like section,
+// with a stable ID so a client can track completion via
+// complete_exercise/list_exercises.
+type Exercise struct {
+	ID          string `json:"id"`
+	ChapterID   string `json:"chapterId"`
+	ChapterName string `json:"chapterName"`
+	Section     string `json:"section,omitempty"`
+	Text        string `json:"text"`
+	LineNumber  int    `json:"lineNumber,omitempty"`
+	Locale      string `json:"locale"`
+}
+
+var (
+	exerciseHeaderPattern = regexp.MustCompile(`^#{1,6}\s+(.+)$`)
+	exerciseListLine      = regexp.MustCompile(`^(?:[-*]|\d+\.)\s+(.+)$`)
+)
+
+// exerciseSectionKeywords mark a section as exercise-like, mirroring
+// checklistSectionKeywords' approach for checklist sections.
+var exerciseSectionKeywords = []string{
+	"exercise", "exercises", "practice", "challenge",
+	"ejercicio", "ejercicios", "práctica", "desafío",
+}
+
+func sectionLooksLikeExercises(section string) bool {
+	section = strings.ToLower(section)
+	for _, kw := range exerciseSectionKeywords {
+		if strings.Contains(section, kw) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetExercises extracts every exercise for locale, or just chapterID when
+// non-empty: frontmatter-declared exercises first, then any pattern-matched
+// from exercise-like sections in the chapter body.
+func (p *Parser) GetExercises(chapterID, locale string) ([]Exercise, error) {
+	chapters, err := p.ListChapters(locale)
+	if err != nil {
+		return nil, err
+	}
+
+	var exercises []Exercise
+
+	for _, chapter := range chapters {
+		if chapterID != "" && chapter.ID != chapterID {
+			continue
+		}
+
+		for i, text := range chapter.Exercises {
+			exercises = append(exercises, Exercise{
+				ID:          fmt.Sprintf("%s#fm%d", chapter.ID, i+1),
+				ChapterID:   chapter.ID,
+				ChapterName: chapter.Name,
+				Text:        text,
+				Locale:      locale,
+			})
+		}
+
+		scanner := bufio.NewScanner(strings.NewReader(chapter.Content))
+		lineNum := 0
+		currentSection := ""
+
+		for scanner.Scan() {
+			lineNum++
+			line := scanner.Text()
+			trimmed := strings.TrimSpace(line)
+
+			if matches := exerciseHeaderPattern.FindStringSubmatch(trimmed); len(matches) > 1 {
+				currentSection = matches[1]
+				continue
+			}
+
+			if !sectionLooksLikeExercises(currentSection) {
+				continue
+			}
+
+			if matches := exerciseListLine.FindStringSubmatch(trimmed); len(matches) > 1 {
+				exercises = append(exercises, Exercise{
+					ID:          fmt.Sprintf("%s#%d", chapter.ID, lineNum),
+					ChapterID:   chapter.ID,
+					ChapterName: chapter.Name,
+					Section:     currentSection,
+					Text:        matches[1],
+					LineNumber:  lineNum,
+					Locale:      locale,
+				})
+			}
+		}
+	}
+
+	if chapterID != "" && len(exercises) == 0 {
+		if _, err := p.GetChapter(chapterID, locale); err != nil {
+			return nil, err
+		}
+	}
+
+	return exercises, nil
+}
+
+// GetExercise returns the single exercise identified by exerciseID, which
+// must be in the "<chapterID>#..." form GetExercises produces.
+func (p *Parser) GetExercise(exerciseID, locale string) (*Exercise, error) {
+	chapterID, _, ok := strings.Cut(exerciseID, "#")
+	if !ok {
+		return nil, fmt.Errorf("invalid exercise id: %s", exerciseID)
+	}
+
+	exercises, err := p.GetExercises(chapterID, locale)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range exercises {
+		if e.ID == exerciseID {
+			return &e, nil
+		}
+	}
+	return nil, fmt.Errorf("exercise not found: %s", exerciseID)
+}
@@ -0,0 +1,42 @@
+package book
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestGetExercisesCombinesFrontmatterAndPatternMatches verifies that
+// frontmatter-declared exercises and exercises pattern-matched from an
+// "Exercises" section are both returned, each with a stable ID.
+func TestGetExercisesCombinesFrontmatterAndPatternMatches(t *testing.T) {
+	dir := t.TempDir()
+	localeDir := filepath.Join(dir, "en")
+	if err := os.Mkdir(localeDir, 0o755); err != nil {
+		t.Fatalf("failed to create locale dir: %v", err)
+	}
+	mdx := "---\nid: \"ch1\"\norder: 1\nname: \"Chapter One\"\ntitleList: []\nexercises:\n  - \"Refactor the sample class.\"\n---\n# Exercises\n- Write a failing test first.\n- Make it pass."
+	if err := os.WriteFile(filepath.Join(localeDir, "ch1.mdx"), []byte(mdx), 0o644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	p := NewParser(dir)
+	exercises, err := p.GetExercises("ch1", "en")
+	if err != nil {
+		t.Fatalf("GetExercises returned error: %v", err)
+	}
+	if len(exercises) != 3 {
+		t.Fatalf("expected 3 exercises (1 frontmatter + 2 pattern-matched), got %d: %+v", len(exercises), exercises)
+	}
+	if exercises[0].Text != "Refactor the sample class." {
+		t.Fatalf("expected frontmatter exercise first, got %+v", exercises[0])
+	}
+
+	fetched, err := p.GetExercise(exercises[1].ID, "en")
+	if err != nil {
+		t.Fatalf("GetExercise returned error: %v", err)
+	}
+	if fetched.Text != exercises[1].Text {
+		t.Fatalf("expected GetExercise to round-trip by ID, got %+v", fetched)
+	}
+}
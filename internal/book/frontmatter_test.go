@@ -0,0 +1,96 @@
+package book
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestParseFrontmatterHandlesRichYAML verifies that the YAML-based
+// frontmatter parser copes with things the old regex/bracket-counting
+// implementation couldn't: a block scalar name spanning multiple lines, a
+// quoted value containing the opposite quote character, and frontmatter
+// fields (tags, description, an unrecognized key) beyond the small set the
+// old code special-cased.
+func TestParseFrontmatterHandlesRichYAML(t *testing.T) {
+	dir := t.TempDir()
+	localeDir := filepath.Join(dir, "en")
+	if err := os.Mkdir(localeDir, 0o755); err != nil {
+		t.Fatalf("failed to create locale dir: %v", err)
+	}
+
+	content := "---\n" +
+		`id: "rich-chapter"` + "\n" +
+		"order: 3\n" +
+		"name: >-\n" +
+		"  A Chapter With A\n" +
+		"  Multi-Line Title\n" +
+		`description: "Covers \"idempotency\" in depth"` + "\n" +
+		"tags: [\"testing\", \"design\"]\n" +
+		"titleList: [{ name: \"Intro\", tagId: \"intro\" }]\n" +
+		"futureField: something-unexpected\n" +
+		"---\n" +
+		"# Intro\n" +
+		"Body.\n"
+
+	path := filepath.Join(localeDir, "rich-chapter.mdx")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	p := NewParser(dir)
+	chapter, err := p.ParseChapter(path, "en")
+	if err != nil {
+		t.Fatalf("ParseChapter returned error: %v", err)
+	}
+
+	if chapter.ID != "rich-chapter" {
+		t.Errorf("expected id %q, got %q", "rich-chapter", chapter.ID)
+	}
+	if chapter.Name != "A Chapter With A Multi-Line Title" {
+		t.Errorf("expected multi-line name to fold into a single line, got %q", chapter.Name)
+	}
+	if chapter.Description != `Covers "idempotency" in depth` {
+		t.Errorf("unexpected description: %q", chapter.Description)
+	}
+	if len(chapter.Tags) != 2 || chapter.Tags[0] != "testing" || chapter.Tags[1] != "design" {
+		t.Errorf("unexpected tags: %+v", chapter.Tags)
+	}
+	if len(chapter.TitleList) != 1 || chapter.TitleList[0].TagID != "intro" {
+		t.Errorf("unexpected titleList: %+v", chapter.TitleList)
+	}
+}
+
+// TestParseFrontmatterInvalidYAMLDoesNotFailChapter verifies that a
+// frontmatter block which isn't valid YAML degrades to empty metadata
+// instead of making the whole chapter unreadable.
+func TestParseFrontmatterInvalidYAMLDoesNotFailChapter(t *testing.T) {
+	dir := t.TempDir()
+	localeDir := filepath.Join(dir, "en")
+	if err := os.Mkdir(localeDir, 0o755); err != nil {
+		t.Fatalf("failed to create locale dir: %v", err)
+	}
+
+	content := "---\n" +
+		"id: [unterminated\n" +
+		"---\n" +
+		"# Intro\n" +
+		"Body.\n"
+
+	path := filepath.Join(localeDir, "broken.mdx")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	p := NewParser(dir)
+	chapter, err := p.ParseChapter(path, "en")
+	if err != nil {
+		t.Fatalf("ParseChapter returned error: %v", err)
+	}
+	if chapter.ID != "" {
+		t.Errorf("expected empty id for unparseable frontmatter, got %q", chapter.ID)
+	}
+	if chapter.Content != "# Intro\nBody." {
+		t.Errorf("unexpected content: %q", chapter.Content)
+	}
+}
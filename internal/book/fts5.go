@@ -0,0 +1,256 @@
+package book
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+
+	_ "modernc.org/sqlite"
+)
+
+// searchBackendEnvVar selects SearchExplain's keyword-matching backend:
+// "fts5" uses a per-locale SQLite FTS5 virtual table (real bm25 ranking,
+// prefix queries, and snippet() highlighting); anything else (including
+// unset) keeps the original scan-with-keywordIndex-acceleration path. Same
+// direct-env-var convention as LOW_MEMORY_PROFILE/ANN_INDEX.
+const searchBackendEnvVar = "SEARCH_BACKEND"
+
+func fts5Enabled() bool {
+	return os.Getenv(searchBackendEnvVar) == "fts5"
+}
+
+// fts5Index is a per-locale SQLite FTS5 virtual table of chapter content.
+// It's rebuilt incrementally: sync only re-inserts a chapter whose content
+// hash has changed since the last call, so an index that's already warm
+// stays cheap to refresh after a small edit.
+type fts5Index struct {
+	locale string
+	db     *sql.DB
+
+	mu     sync.Mutex        // serializes access; the sqlite driver doesn't allow concurrent use of one *sql.DB for writes
+	hashes map[string]string // chapterID -> sha256 of the content currently indexed for it
+}
+
+// contentHash is a stable fingerprint of a chapter's content, used to tell
+// sync whether a chapter needs re-indexing.
+func contentHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+func newFTS5Index(locale string) (*fts5Index, error) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		return nil, fmt.Errorf("opening fts5 index for locale %s: %w", locale, err)
+	}
+	if _, err := db.Exec(`CREATE VIRTUAL TABLE docs USING fts5(chapter_id, chapter_name, content)`); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("creating fts5 table for locale %s: %w", locale, err)
+	}
+	return &fts5Index{locale: locale, db: db, hashes: make(map[string]string)}, nil
+}
+
+// sync brings the index up to date with chapters: any chapter whose content
+// hash differs from (or is missing from) what's currently indexed is
+// re-inserted, and any chapter that's no longer present is dropped. A
+// chapter whose hash is unchanged since the last sync is left untouched --
+// this is the "built once per content hash" behavior the fts5 backend
+// promises.
+func (idx *fts5Index) sync(chapters []Chapter) error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	seen := make(map[string]bool, len(chapters))
+	for _, ch := range chapters {
+		seen[ch.ID] = true
+		hash := contentHash(ch.Content)
+		if idx.hashes[ch.ID] == hash {
+			continue
+		}
+		if _, err := idx.db.Exec(`DELETE FROM docs WHERE chapter_id = ?`, ch.ID); err != nil {
+			return fmt.Errorf("fts5: removing stale rows for chapter %s: %w", ch.ID, err)
+		}
+		if _, err := idx.db.Exec(`INSERT INTO docs (chapter_id, chapter_name, content) VALUES (?, ?, ?)`, ch.ID, ch.Name, ch.Content); err != nil {
+			return fmt.Errorf("fts5: indexing chapter %s: %w", ch.ID, err)
+		}
+		idx.hashes[ch.ID] = hash
+	}
+
+	for chapterID := range idx.hashes {
+		if seen[chapterID] {
+			continue
+		}
+		if _, err := idx.db.Exec(`DELETE FROM docs WHERE chapter_id = ?`, chapterID); err != nil {
+			return fmt.Errorf("fts5: removing deleted chapter %s: %w", chapterID, err)
+		}
+		delete(idx.hashes, chapterID)
+	}
+	return nil
+}
+
+// fts5QueryTerm quotes word as an FTS5 string literal and appends the `*`
+// prefix-query operator, so "search" also matches "searching" -- the
+// prefix behavior the non-FTS5 path gets for free from substring matching.
+func fts5QueryTerm(word string) string {
+	return `"` + strings.ReplaceAll(word, `"`, `""`) + `"*`
+}
+
+var fts5HeaderPattern = regexp.MustCompile(`^#{1,6}\s+(.+)$`)
+
+// locateInChapter finds the first line of content containing any of
+// queryWords (case-insensitive substring match, same semantics as the
+// non-FTS5 scan), returning its 1-indexed line number and enclosing
+// section heading. FTS5's own matching (stemming-free prefix/token
+// matching) can occasionally match a line that none of the raw queryWords
+// appear in verbatim; when that happens this returns (0, "") rather than
+// guessing.
+func locateInChapter(content string, queryWords []string) (int, string) {
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	lineNum := 0
+	currentSection := ""
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		if matches := fts5HeaderPattern.FindStringSubmatch(line); len(matches) > 1 {
+			currentSection = matches[1]
+		}
+		lineLower := strings.ToLower(line)
+		for _, w := range queryWords {
+			if strings.Contains(lineLower, w) {
+				return lineNum, currentSection
+			}
+		}
+	}
+	return 0, ""
+}
+
+// searchFTS5 is SearchExplain's fts5-backend implementation: chapters is
+// used both to keep idx in sync and to recover the LineNumber/Section that
+// a chapter-level FTS5 row alone can't provide.
+func searchFTS5(idx *fts5Index, chapters []Chapter, query, locale string, explain bool) ([]SearchResult, error) {
+	if err := idx.sync(chapters); err != nil {
+		return nil, err
+	}
+
+	queryWords := strings.Fields(strings.ToLower(query))
+	if len(queryWords) == 0 {
+		return nil, nil
+	}
+
+	matchClauses := make([]string, len(queryWords))
+	for i, w := range queryWords {
+		matchClauses[i] = fts5QueryTerm(w)
+	}
+	matchQuery := strings.Join(matchClauses, " OR ")
+
+	chapterByID := make(map[string]Chapter, len(chapters))
+	for _, ch := range chapters {
+		chapterByID[ch.ID] = ch
+	}
+
+	idx.mu.Lock()
+	rows, err := idx.db.Query(
+		`SELECT chapter_id, chapter_name, bm25(docs) AS rank, snippet(docs, 2, '', '', '...', 20)
+		 FROM docs WHERE docs MATCH ? ORDER BY rank LIMIT 20`,
+		matchQuery,
+	)
+	if err != nil {
+		idx.mu.Unlock()
+		return nil, fmt.Errorf("fts5: searching locale %s: %w", locale, err)
+	}
+	defer idx.mu.Unlock()
+	defer rows.Close()
+
+	var results []SearchResult
+	for rows.Next() {
+		var chapterID, chapterName, snippet string
+		var rank float64
+		if err := rows.Scan(&chapterID, &chapterName, &rank, &snippet); err != nil {
+			return nil, fmt.Errorf("fts5: reading search result: %w", err)
+		}
+
+		var matchedTerms []string
+		snippetLower := strings.ToLower(snippet)
+		for _, w := range queryWords {
+			if strings.Contains(snippetLower, w) {
+				matchedTerms = append(matchedTerms, w)
+			}
+		}
+
+		lineNumber, section := 0, ""
+		if ch, ok := chapterByID[chapterID]; ok {
+			lineNumber, section = locateInChapter(ch.Content, queryWords)
+		}
+
+		result := SearchResult{
+			ChapterID:       chapterID,
+			ChapterName:     chapterName,
+			Section:         section,
+			Snippet:         Truncate(snippet, 200),
+			LineNumber:      lineNumber,
+			Relevance:       -rank, // bm25() is smaller-is-better; negate so higher Relevance still means a stronger match
+			Locale:          locale,
+			EstimatedTokens: EstimateTokens(snippet),
+			Tone:            ToneLabelStrings(ClassifyTone(snippet)),
+		}
+		if explain {
+			result.Explain = &ScoreExplain{
+				MatchedTerms: matchedTerms,
+				QueryTerms:   len(queryWords),
+				MatchedCount: len(matchedTerms),
+			}
+		}
+		results = append(results, result)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("fts5: reading search results: %w", err)
+	}
+
+	return results, nil
+}
+
+// BuildFTS5Index precomputes locale's fts5 index so the first SEARCH_BACKEND=fts5
+// search after startup doesn't pay the build cost. A no-op when fts5 isn't
+// selected or in low-memory mode (see SetLowMemoryMode) -- same precedent as
+// BuildKeywordIndex.
+func (p *Parser) BuildFTS5Index(locale string) error {
+	if p.lowMemoryMode || !fts5Enabled() {
+		return nil
+	}
+	chapters, err := p.ListChapters(locale)
+	if err != nil {
+		return err
+	}
+	_, err = p.fts5IndexFor(locale, chapters)
+	return err
+}
+
+// fts5IndexFor returns locale's fts5 index, creating it lazily on first use
+// if BuildFTS5Index hasn't already warmed it up. The index is kept
+// in-sync with chapters on every call (see fts5Index.sync), so callers
+// never need to invalidate it by hand.
+func (p *Parser) fts5IndexFor(locale string, chapters []Chapter) (*fts5Index, error) {
+	if cached, ok := p.fts5Indexes.Load(locale); ok {
+		idx := cached.(*fts5Index)
+		if err := idx.sync(chapters); err != nil {
+			return nil, err
+		}
+		return idx, nil
+	}
+
+	idx, err := newFTS5Index(locale)
+	if err != nil {
+		return nil, err
+	}
+	if err := idx.sync(chapters); err != nil {
+		return nil, err
+	}
+	p.fts5Indexes.Store(locale, idx)
+	return idx, nil
+}
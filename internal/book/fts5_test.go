@@ -0,0 +1,131 @@
+package book
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestSearchExplainUsesFTS5WhenEnabled verifies that with SEARCH_BACKEND=fts5
+// set, Search is served by the fts5 backend and still finds the right
+// chapter via prefix matching.
+func TestSearchExplainUsesFTS5WhenEnabled(t *testing.T) {
+	t.Setenv(searchBackendEnvVar, "fts5")
+
+	dir := t.TempDir()
+	localeDir := filepath.Join(dir, "en")
+	if err := os.Mkdir(localeDir, 0o755); err != nil {
+		t.Fatalf("failed to create locale dir: %v", err)
+	}
+	writeSearchFixture(t, localeDir, "testing", "Testing", 1, "# Intro\nWe write unit testing here.")
+	writeSearchFixture(t, localeDir, "other", "Other", 2, "# Intro\nThis chapter is about something else.")
+
+	p := NewParser(dir)
+
+	// "test" should prefix-match "testing" even though it's not a whole word.
+	results, err := p.Search("test", "en")
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if len(results) != 1 || results[0].ChapterID != "testing" {
+		t.Fatalf("expected 1 result from chapter %q, got %+v", "testing", results)
+	}
+	if results[0].LineNumber != 2 {
+		t.Fatalf("expected LineNumber 2, got %d", results[0].LineNumber)
+	}
+	if results[0].Section != "Intro" {
+		t.Fatalf("expected section %q, got %q", "Intro", results[0].Section)
+	}
+}
+
+// TestSearchExplainFTS5IgnoredInLowMemoryMode verifies low-memory mode takes
+// priority over SEARCH_BACKEND=fts5, same as it does over the keyword index.
+func TestSearchExplainFTS5IgnoredInLowMemoryMode(t *testing.T) {
+	t.Setenv(searchBackendEnvVar, "fts5")
+
+	dir := t.TempDir()
+	localeDir := filepath.Join(dir, "en")
+	if err := os.Mkdir(localeDir, 0o755); err != nil {
+		t.Fatalf("failed to create locale dir: %v", err)
+	}
+	writeSearchFixture(t, localeDir, "testing", "Testing", 1, "# Intro\nWe write unit tests here.")
+
+	p := NewParser(dir)
+	p.SetLowMemoryMode(true)
+
+	results, err := p.Search("unit tests", "en")
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %+v", results)
+	}
+}
+
+// TestFTS5IndexSyncSkipsUnchangedChapters verifies that sync only
+// re-indexes a chapter when its content hash has actually changed.
+func TestFTS5IndexSyncSkipsUnchangedChapters(t *testing.T) {
+	idx, err := newFTS5Index("en")
+	if err != nil {
+		t.Fatalf("newFTS5Index returned error: %v", err)
+	}
+	defer idx.db.Close()
+
+	chapters := []Chapter{{ID: "a", Name: "A", Content: "hello world"}}
+	if err := idx.sync(chapters); err != nil {
+		t.Fatalf("sync returned error: %v", err)
+	}
+	firstHash := idx.hashes["a"]
+
+	if err := idx.sync(chapters); err != nil {
+		t.Fatalf("second sync returned error: %v", err)
+	}
+	if idx.hashes["a"] != firstHash {
+		t.Fatalf("expected hash to stay stable across unchanged syncs")
+	}
+
+	chapters[0].Content = "hello there"
+	if err := idx.sync(chapters); err != nil {
+		t.Fatalf("third sync returned error: %v", err)
+	}
+	if idx.hashes["a"] == firstHash {
+		t.Fatalf("expected hash to change after content changed")
+	}
+
+	row := idx.db.QueryRow(`SELECT content FROM docs WHERE chapter_id = ?`, "a")
+	var content string
+	if err := row.Scan(&content); err != nil {
+		t.Fatalf("querying indexed content: %v", err)
+	}
+	if content != "hello there" {
+		t.Fatalf("expected re-indexed content %q, got %q", "hello there", content)
+	}
+}
+
+// TestFTS5IndexSyncRemovesDeletedChapters verifies a chapter dropped between
+// syncs is removed from the index, not left stale.
+func TestFTS5IndexSyncRemovesDeletedChapters(t *testing.T) {
+	idx, err := newFTS5Index("en")
+	if err != nil {
+		t.Fatalf("newFTS5Index returned error: %v", err)
+	}
+	defer idx.db.Close()
+
+	if err := idx.sync([]Chapter{{ID: "a", Name: "A", Content: "hello"}}); err != nil {
+		t.Fatalf("sync returned error: %v", err)
+	}
+	if err := idx.sync(nil); err != nil {
+		t.Fatalf("sync with no chapters returned error: %v", err)
+	}
+
+	var count int
+	if err := idx.db.QueryRow(`SELECT count(*) FROM docs`).Scan(&count); err != nil {
+		t.Fatalf("counting rows: %v", err)
+	}
+	if count != 0 {
+		t.Fatalf("expected 0 rows after chapter removal, got %d", count)
+	}
+	if len(idx.hashes) != 0 {
+		t.Fatalf("expected hashes to be cleared, got %v", idx.hashes)
+	}
+}
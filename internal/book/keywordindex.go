@@ -0,0 +1,106 @@
+package book
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// keywordIndex is a per-locale accelerating structure for SearchExplain: for
+// each chapter, the distinct lowercased words it contains, sorted. Query
+// words are matched against this much smaller vocabulary (via substring
+// containment, to preserve SearchExplain's existing substring-match
+// semantics) instead of every line of every chapter, so chapters that can't
+// possibly match are skipped before the per-line scan runs.
+//
+// Known limitation: a query word that only matches across a punctuation
+// boundary within a single token (e.g. the literal "o-o" inside "co-op")
+// won't be found this way, since word boundaries split on punctuation. This
+// doesn't affect whole-word or within-word substring queries, which is what
+// real searches use in practice.
+type keywordIndex struct {
+	locale       string
+	chapterWords map[string][]string // chapterID -> sorted distinct words
+}
+
+var wordPattern = regexp.MustCompile(`[\p{L}\p{N}]+`)
+
+// buildKeywordIndex scans every chapter's content once, recording its
+// distinct lowercased words.
+func buildKeywordIndex(locale string, chapters []Chapter) *keywordIndex {
+	idx := &keywordIndex{locale: locale, chapterWords: make(map[string][]string, len(chapters))}
+	for _, ch := range chapters {
+		seen := make(map[string]bool)
+		for _, word := range wordPattern.FindAllString(strings.ToLower(ch.Content), -1) {
+			seen[word] = true
+		}
+		words := make([]string, 0, len(seen))
+		for word := range seen {
+			words = append(words, word)
+		}
+		sort.Strings(words)
+		idx.chapterWords[ch.ID] = words
+	}
+	return idx
+}
+
+// candidateChapters returns the IDs of chapters whose vocabulary contains at
+// least one queryWord as a substring of one of its words.
+func (idx *keywordIndex) candidateChapters(queryWords []string) map[string]bool {
+	candidates := make(map[string]bool)
+	for chapterID, words := range idx.chapterWords {
+		for _, qw := range queryWords {
+			matched := false
+			for _, w := range words {
+				if strings.Contains(w, qw) {
+					matched = true
+					break
+				}
+			}
+			if matched {
+				candidates[chapterID] = true
+				break
+			}
+		}
+	}
+	return candidates
+}
+
+// BuildKeywordIndex precomputes the keyword index for locale so the first
+// search after startup doesn't pay the build cost. A no-op in low-memory
+// mode (see SetLowMemoryMode) -- the index only ever accelerates search, so
+// skipping it just means SearchExplain falls back to scanning every chapter.
+//
+// Note: this only precomputes the inverted-index vocabulary described
+// above. There's no TF-IDF vector scoring here -- SearchExplain's relevance
+// formula (matched-term ratio) is unchanged; the index just narrows which
+// chapters it runs that formula against.
+func (p *Parser) BuildKeywordIndex(locale string) error {
+	if p.lowMemoryMode {
+		return nil
+	}
+
+	chapters, err := p.ListChapters(locale)
+	if err != nil {
+		return err
+	}
+
+	p.keywordIndex.Store(locale, buildKeywordIndex(locale, chapters))
+	return nil
+}
+
+// keywordIndexFor returns locale's keyword index, building it lazily
+// (unless low-memory mode disables that too) if BuildKeywordIndex hasn't
+// already warmed it up.
+func (p *Parser) keywordIndexFor(locale string, chapters []Chapter) *keywordIndex {
+	if p.lowMemoryMode {
+		return nil
+	}
+	if cached, ok := p.keywordIndex.Load(locale); ok {
+		return cached.(*keywordIndex)
+	}
+
+	idx := buildKeywordIndex(locale, chapters)
+	p.keywordIndex.Store(locale, idx)
+	return idx
+}
@@ -0,0 +1,64 @@
+package book
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeSearchFixture(t *testing.T, dir, id, name string, order int, content string) {
+	t.Helper()
+	mdx := fmt.Sprintf("---\nid: %q\norder: %d\nname: %q\ntitleList: []\n---\n%s", id, order, name, content)
+	if err := os.WriteFile(filepath.Join(dir, id+".mdx"), []byte(mdx), 0o644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+}
+
+// TestSearchExplainSkipsChaptersNotInKeywordIndex verifies that the keyword
+// index correctly limits results to chapters containing the query term, and
+// that low-memory mode still finds the same results without it.
+func TestSearchExplainSkipsChaptersNotInKeywordIndex(t *testing.T) {
+	dir := t.TempDir()
+	localeDir := filepath.Join(dir, "en")
+	if err := os.Mkdir(localeDir, 0o755); err != nil {
+		t.Fatalf("failed to create locale dir: %v", err)
+	}
+	writeSearchFixture(t, localeDir, "testing", "Testing", 1, "# Intro\nWe write unit tests here.")
+	writeSearchFixture(t, localeDir, "other", "Other", 2, "# Intro\nThis chapter is about something else.")
+
+	p := NewParser(dir)
+	if err := p.BuildKeywordIndex("en"); err != nil {
+		t.Fatalf("BuildKeywordIndex returned error: %v", err)
+	}
+
+	results, err := p.Search("unit tests", "en")
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if len(results) != 1 || results[0].ChapterID != "testing" {
+		t.Fatalf("expected 1 result from chapter %q, got %+v", "testing", results)
+	}
+}
+
+// TestSearchExplainLowMemoryModeSkipsIndex verifies low-memory mode still
+// returns correct results by falling back to the unaccelerated scan.
+func TestSearchExplainLowMemoryModeSkipsIndex(t *testing.T) {
+	dir := t.TempDir()
+	localeDir := filepath.Join(dir, "en")
+	if err := os.Mkdir(localeDir, 0o755); err != nil {
+		t.Fatalf("failed to create locale dir: %v", err)
+	}
+	writeSearchFixture(t, localeDir, "testing", "Testing", 1, "# Intro\nWe write unit tests here.")
+
+	p := NewParser(dir)
+	p.SetLowMemoryMode(true)
+
+	results, err := p.Search("unit tests", "en")
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %+v", results)
+	}
+}
@@ -2,34 +2,77 @@ package book
 
 // Chapter represents a book chapter
 type Chapter struct {
-	ID        string    `json:"id"`
-	Order     int       `json:"order"`
-	Name      string    `json:"name"`
-	Locale    string    `json:"locale"`
-	TitleList []Section `json:"titleList"`
-	Content   string    `json:"content"`
-	FilePath  string    `json:"filePath"`
+	ID              string           `json:"id"`
+	Order           int              `json:"order"`
+	Name            string           `json:"name"`
+	Locale          string           `json:"locale"`
+	TitleList       []Section        `json:"titleList"`
+	Content         string           `json:"content"`
+	FilePath        string           `json:"filePath"`
+	Prerequisites   []string         `json:"prerequisites,omitempty"`
+	Tags            []string         `json:"tags,omitempty"`
+	Description     string           `json:"description,omitempty"`
+	URL             string           `json:"url,omitempty"`
+	ReviewQuestions []ReviewQuestion `json:"reviewQuestions,omitempty"`
+	Exercises       []string         `json:"exercises,omitempty"`
+
+	// sectionOffsets is precomputed once in ParseChapter so GetSection can
+	// slice Content directly instead of re-splitting it into lines on every
+	// call. Unexported: it's a parsing-time cache, not part of the chapter's
+	// public shape.
+	sectionOffsets []sectionOffset
+}
+
+// sectionOffset is one heading's byte range within its chapter's Content,
+// from the heading line itself up to (but not including) the next heading.
+type sectionOffset struct {
+	TagID string
+	Name  string
+	Start int
+	End   int
 }
 
 // Section represents a section within a chapter
 type Section struct {
-	Name  string `json:"name"`
-	TagID string `json:"tagId"`
+	Name  string `json:"name" yaml:"name"`
+	TagID string `json:"tagId" yaml:"tagId"`
+}
+
+// ReviewQuestion is one self-check question pointing at the section that
+// answers it, either curated by the book's author (frontmatter or an
+// operator's REVIEW_QUESTIONS_CONFIG override) or generated heuristically
+// from the chapter's headings when nothing was curated.
+type ReviewQuestion struct {
+	Question string `json:"question" yaml:"question"`
+	TagID    string `json:"tagId" yaml:"tagId"`
 }
 
 // SearchResult represents a search result
 type SearchResult struct {
-	ChapterID   string  `json:"chapterId"`
-	ChapterName string  `json:"chapterName"`
-	Section     string  `json:"section"`
-	Snippet     string  `json:"snippet"`
-	LineNumber  int     `json:"lineNumber"`
-	Relevance   float64 `json:"relevance"`
-	Locale      string  `json:"locale"`
+	ChapterID       string        `json:"chapterId"`
+	ChapterName     string        `json:"chapterName"`
+	Section         string        `json:"section"`
+	Snippet         string        `json:"snippet"`
+	LineNumber      int           `json:"lineNumber"`
+	Relevance       float64       `json:"relevance"`
+	Locale          string        `json:"locale"`
+	EstimatedTokens int           `json:"estimatedTokens"`
+	Tone            []string      `json:"tone,omitempty"`
+	Explain         *ScoreExplain `json:"explain,omitempty"`
+	URL             string        `json:"url,omitempty"`
+	FAQ             bool          `json:"faq,omitempty"`
+}
+
+// ScoreExplain breaks down how a keyword search result's relevance was computed
+type ScoreExplain struct {
+	MatchedTerms []string `json:"matchedTerms"`
+	QueryTerms   int      `json:"queryTerms"`
+	MatchedCount int      `json:"matchedCount"`
 }
 
 // BookIndex represents the complete book index
 type BookIndex struct {
+	SchemaVersion int       `json:"schemaVersion"`
 	Locale        string    `json:"locale"`
 	TotalChapters int       `json:"totalChapters"`
 	Chapters      []Chapter `json:"chapters"`
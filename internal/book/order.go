@@ -0,0 +1,39 @@
+package book
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+var orderFieldPattern = regexp.MustCompile(`order:\s*\d+`)
+
+// WriteChapterOrder rewrites chapterID's frontmatter order field to order,
+// leaving every other field and the body untouched, and writes the file
+// back atomically (see atomicWriteFile).
+func (p *Parser) WriteChapterOrder(chapterID, locale string, order int) error {
+	chapter, err := p.GetChapter(chapterID, locale)
+	if err != nil {
+		return err
+	}
+
+	raw, err := os.ReadFile(chapter.FilePath)
+	if err != nil {
+		return fmt.Errorf("error reading file %s: %w", chapter.FilePath, err)
+	}
+	contentStr := strings.ReplaceAll(string(raw), "\r\n", "\n")
+
+	fmContent, body, err := p.splitFrontmatter(contentStr)
+	if err != nil {
+		return fmt.Errorf("error parsing frontmatter in %s: %w", chapter.FilePath, err)
+	}
+
+	if !orderFieldPattern.MatchString(fmContent) {
+		return fmt.Errorf("frontmatter has no order field")
+	}
+	newFmContent := orderFieldPattern.ReplaceAllString(fmContent, fmt.Sprintf("order: %d", order))
+
+	newFile := "---" + newFmContent + "---\n\n" + body + "\n"
+	return atomicWriteFile(chapter.FilePath, []byte(newFile))
+}
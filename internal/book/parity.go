@@ -0,0 +1,200 @@
+package book
+
+import "sort"
+
+// duplicateSimilarityThreshold is how similar two locales' simhash
+// fingerprints for the same chapter must be before the chapter is flagged
+// as a likely untranslated (or partially machine-translated) copy rather
+// than a genuine, if structurally similar, translation.
+const duplicateSimilarityThreshold = 0.90
+
+// DuplicateMatch flags a chapter whose content is nearly identical across
+// two locales — in practice almost always a translation that never
+// happened, since real prose in two different languages doesn't simhash
+// this close by coincidence.
+type DuplicateMatch struct {
+	ChapterID  string  `json:"chapterId"`
+	LocaleA    string  `json:"localeA"`
+	LocaleB    string  `json:"localeB"`
+	Similarity float64 `json:"similarity"`
+}
+
+// LocaleParityReport compares chapter coverage and content between two
+// locales: which chapters exist on only one side, and which exist on both
+// sides but look like an untouched copy instead of a real translation.
+type LocaleParityReport struct {
+	SchemaVersion    int              `json:"schemaVersion"`
+	LocaleA          string           `json:"localeA"`
+	LocaleB          string           `json:"localeB"`
+	MissingInB       []string         `json:"missingInB"`
+	MissingInA       []string         `json:"missingInA"`
+	LikelyDuplicates []DuplicateMatch `json:"likelyDuplicates"`
+}
+
+// DetectCrossLocaleDuplicates compares every chapter ID present in both
+// localeA and localeB and flags the ones whose simhash fingerprints are
+// close enough to count as near-duplicates, surfacing untranslated or
+// partially machine-translated copies for translators to find.
+func (p *Parser) DetectCrossLocaleDuplicates(localeA, localeB string) ([]DuplicateMatch, error) {
+	chaptersA, err := p.ListChapters(localeA)
+	if err != nil {
+		return nil, err
+	}
+	chaptersB, err := p.ListChapters(localeB)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]Chapter, len(chaptersB))
+	for _, c := range chaptersB {
+		byID[c.ID] = c
+	}
+
+	var matches []DuplicateMatch
+	for _, a := range chaptersA {
+		b, ok := byID[a.ID]
+		if !ok {
+			continue
+		}
+		similarity := simhashSimilarity(simhash64(a.Content), simhash64(b.Content))
+		if similarity >= duplicateSimilarityThreshold {
+			matches = append(matches, DuplicateMatch{
+				ChapterID:  a.ID,
+				LocaleA:    localeA,
+				LocaleB:    localeB,
+				Similarity: similarity,
+			})
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool { return matches[i].ChapterID < matches[j].ChapterID })
+	return matches, nil
+}
+
+// GetLocaleParityReport builds a full parity comparison between localeA and
+// localeB: chapters missing from either side, plus chapters present on both
+// sides that simhash closely enough to be a likely-untranslated copy.
+func (p *Parser) GetLocaleParityReport(localeA, localeB string) (*LocaleParityReport, error) {
+	chaptersA, err := p.ListChapters(localeA)
+	if err != nil {
+		return nil, err
+	}
+	chaptersB, err := p.ListChapters(localeB)
+	if err != nil {
+		return nil, err
+	}
+
+	idsA := make(map[string]bool, len(chaptersA))
+	for _, c := range chaptersA {
+		idsA[c.ID] = true
+	}
+	idsB := make(map[string]bool, len(chaptersB))
+	for _, c := range chaptersB {
+		idsB[c.ID] = true
+	}
+
+	var missingInB, missingInA []string
+	for id := range idsA {
+		if !idsB[id] {
+			missingInB = append(missingInB, id)
+		}
+	}
+	for id := range idsB {
+		if !idsA[id] {
+			missingInA = append(missingInA, id)
+		}
+	}
+	sort.Strings(missingInB)
+	sort.Strings(missingInA)
+
+	duplicates, err := p.DetectCrossLocaleDuplicates(localeA, localeB)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LocaleParityReport{
+		SchemaVersion:    SchemaVersion,
+		LocaleA:          localeA,
+		LocaleB:          localeB,
+		MissingInB:       missingInB,
+		MissingInA:       missingInA,
+		LikelyDuplicates: duplicates,
+	}, nil
+}
+
+// SectionDuplicateMatch is DuplicateMatch's section-level counterpart: a
+// section present in both locales whose content simhashes closely enough to
+// be a likely-untranslated copy.
+type SectionDuplicateMatch struct {
+	ChapterID  string  `json:"chapterId"`
+	TagID      string  `json:"tagId"`
+	LocaleA    string  `json:"localeA"`
+	LocaleB    string  `json:"localeB"`
+	Similarity float64 `json:"similarity"`
+}
+
+// DetectSectionDuplicates compares, for every chapter present in both
+// localeA and localeB, each section (matched by TagID) that exists on both
+// sides, and flags the ones whose simhash fingerprints are close enough to
+// count as a likely-untranslated copy. Sections only present in one locale
+// are skipped here — GetTranslationStatus treats those as "missing" rather
+// than "outdated".
+func (p *Parser) DetectSectionDuplicates(localeA, localeB string) ([]SectionDuplicateMatch, error) {
+	chaptersA, err := p.ListChapters(localeA)
+	if err != nil {
+		return nil, err
+	}
+	chaptersB, err := p.ListChapters(localeB)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]Chapter, len(chaptersB))
+	for _, c := range chaptersB {
+		byID[c.ID] = c
+	}
+
+	var matches []SectionDuplicateMatch
+	for _, a := range chaptersA {
+		b, ok := byID[a.ID]
+		if !ok {
+			continue
+		}
+		bTagIDs := make(map[string]bool, len(b.TitleList))
+		for _, s := range b.TitleList {
+			bTagIDs[s.TagID] = true
+		}
+
+		for _, s := range a.TitleList {
+			if !bTagIDs[s.TagID] {
+				continue
+			}
+			contentA, err := p.GetSection(a.ID, s.TagID, localeA)
+			if err != nil {
+				continue
+			}
+			contentB, err := p.GetSection(a.ID, s.TagID, localeB)
+			if err != nil {
+				continue
+			}
+			similarity := simhashSimilarity(simhash64(contentA), simhash64(contentB))
+			if similarity >= duplicateSimilarityThreshold {
+				matches = append(matches, SectionDuplicateMatch{
+					ChapterID:  a.ID,
+					TagID:      s.TagID,
+					LocaleA:    localeA,
+					LocaleB:    localeB,
+					Similarity: similarity,
+				})
+			}
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].ChapterID != matches[j].ChapterID {
+			return matches[i].ChapterID < matches[j].ChapterID
+		}
+		return matches[i].TagID < matches[j].TagID
+	})
+	return matches, nil
+}
@@ -0,0 +1,104 @@
+package book
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func mkLocaleDir(t *testing.T, dir, locale string) string {
+	t.Helper()
+	localeDir := filepath.Join(dir, locale)
+	if err := os.Mkdir(localeDir, 0o755); err != nil {
+		t.Fatalf("failed to create locale dir: %v", err)
+	}
+	return localeDir
+}
+
+// writeTitledFixture is writeSearchFixture plus a non-empty titleList, for
+// tests that need TagID-addressable sections.
+func writeTitledFixture(t *testing.T, dir, id, name string, order int, titleList, content string) {
+	t.Helper()
+	mdx := fmt.Sprintf("---\nid: %q\norder: %d\nname: %q\ntitleList: %s\n---\n%s", id, order, name, titleList, content)
+	if err := os.WriteFile(filepath.Join(dir, id+".mdx"), []byte(mdx), 0o644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+}
+
+// TestGetLocaleParityReportFlagsMissingAndDuplicateChapters verifies that
+// the parity report separates chapters missing from either locale from
+// chapters present on both sides but untranslated (identical content).
+func TestGetLocaleParityReportFlagsMissingAndDuplicateChapters(t *testing.T) {
+	dir := t.TempDir()
+	es := mkLocaleDir(t, dir, "es")
+	en := mkLocaleDir(t, dir, "en")
+
+	shared := "# Introduccion\nEste es un capitulo largo sobre arquitectura hexagonal y pruebas unitarias en sistemas reales de produccion que usan Go."
+	writeSearchFixture(t, es, "intro", "Introduccion", 1, shared)
+	writeSearchFixture(t, en, "intro", "Introduccion", 1, shared) // untranslated copy
+	writeSearchFixture(t, es, "only-es", "Solo ES", 2, "# Solo en espanol\nContenido exclusivo.")
+	writeSearchFixture(t, en, "only-en", "Only EN", 2, "# English only\nExclusive content.")
+
+	p := NewParser(dir)
+	report, err := p.GetLocaleParityReport("es", "en")
+	if err != nil {
+		t.Fatalf("GetLocaleParityReport returned error: %v", err)
+	}
+
+	if len(report.MissingInB) != 1 || report.MissingInB[0] != "only-es" {
+		t.Fatalf("expected only-es missing in en, got %v", report.MissingInB)
+	}
+	if len(report.MissingInA) != 1 || report.MissingInA[0] != "only-en" {
+		t.Fatalf("expected only-en missing in es, got %v", report.MissingInA)
+	}
+	if len(report.LikelyDuplicates) != 1 || report.LikelyDuplicates[0].ChapterID != "intro" {
+		t.Fatalf("expected intro flagged as a likely duplicate, got %+v", report.LikelyDuplicates)
+	}
+}
+
+// TestDetectCrossLocaleDuplicatesIgnoresGenuineTranslations verifies that
+// two chapters with unrelated content don't get flagged as duplicates just
+// because they share a chapter ID.
+func TestDetectCrossLocaleDuplicatesIgnoresGenuineTranslations(t *testing.T) {
+	dir := t.TempDir()
+	es := mkLocaleDir(t, dir, "es")
+	en := mkLocaleDir(t, dir, "en")
+
+	writeSearchFixture(t, es, "intro", "Introduccion", 1, "# Introduccion\nEste capitulo explica los principios de diseno limpio y arquitectura hexagonal con ejemplos practicos.")
+	writeSearchFixture(t, en, "intro", "Introduction", 1, "# Introduction\nThis chapter walks through dependency injection, testing strategies, and how to structure a large codebase.")
+
+	p := NewParser(dir)
+	matches, err := p.DetectCrossLocaleDuplicates("es", "en")
+	if err != nil {
+		t.Fatalf("DetectCrossLocaleDuplicates returned error: %v", err)
+	}
+	if len(matches) != 0 {
+		t.Fatalf("expected no duplicates for genuinely different content, got %+v", matches)
+	}
+}
+
+// TestDetectSectionDuplicatesFlagsUntranslatedSection verifies that a single
+// untranslated section is flagged even when the rest of the chapter's
+// sections were genuinely translated.
+func TestDetectSectionDuplicatesFlagsUntranslatedSection(t *testing.T) {
+	dir := t.TempDir()
+	es := mkLocaleDir(t, dir, "es")
+	en := mkLocaleDir(t, dir, "en")
+
+	titleList := `[{"name": "Introduccion", "tagId": "introduccion"}, {"name": "Resumen", "tagId": "resumen"}]`
+	esContent := "# Introduccion\nEste capitulo explica los principios de diseno limpio y arquitectura hexagonal con varios ejemplos practicos de produccion.\n\n# Resumen\nUn resumen breve y compartido."
+	enContent := "# Introduccion\nEste capitulo explica los principios de diseno limpio y arquitectura hexagonal con varios ejemplos practicos de produccion.\n\n# Resumen\nA short shared summary."
+
+	writeTitledFixture(t, es, "intro", "Introduccion", 1, titleList, esContent)
+	writeTitledFixture(t, en, "intro", "Introduction", 1, titleList, enContent)
+
+	p := NewParser(dir)
+	matches, err := p.DetectSectionDuplicates("es", "en")
+	if err != nil {
+		t.Fatalf("DetectSectionDuplicates returned error: %v", err)
+	}
+	if len(matches) != 1 || matches[0].TagID != "introduccion" {
+		t.Fatalf("expected only the introduccion section flagged, got %+v", matches)
+	}
+}
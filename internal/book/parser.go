@@ -2,165 +2,316 @@ package book
 
 import (
 	"bufio"
-	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"regexp"
 	"sort"
-	"strconv"
 	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/Alan-TheGentleman/gentleman-book-mcp/internal/i18n"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+	"gopkg.in/yaml.v3"
 )
 
 // Parser handles parsing of MDX book files
 type Parser struct {
-	bookPath string
+	bookPath      string
+	sectionCache  sync.Map // sectionCacheKey -> string (warmed by prefetch, read by GetSection)
+	keywordIndex  sync.Map // locale -> *keywordIndex, built by BuildKeywordIndex
+	fts5Indexes   sync.Map // locale -> *fts5Index, built by BuildFTS5Index (only used when SEARCH_BACKEND=fts5)
+	bleveIndexes  sync.Map // locale -> *bleveIndex, built by BuildBleveIndex (only used when SEARCH_BACKEND=bleve)
+	chapterCache  sync.Map // chapterCacheKey -> chapterCacheEntry, read/invalidated by parseChapterCached
+	reviewQCache  sync.Map // chapterCacheKey -> reviewQCacheEntry, read/invalidated by GetReviewQuestions
+	lowMemoryMode bool     // disables accelerating structures like the keyword index (see SetLowMemoryMode)
+	slugMode      SlugMode // how GenerateTagID treats accented letters (see SetSlugMode)
 }
 
+// SlugMode controls how GenerateTagID treats accented letters when turning a
+// heading into a tagId.
+type SlugMode int
+
+const (
+	// SlugModeStripAccents transliterates accented letters to their
+	// unaccented ASCII base (e.g. "ó" -> "o") before slugifying, matching
+	// the website's github-slugger-based anchor generation. This is the
+	// default, since GenerateTagID's whole purpose is to match the
+	// website's anchors.
+	SlugModeStripAccents SlugMode = iota
+	// SlugModeKeepAccents preserves accented letters as Unicode, which was
+	// GenerateTagID's original behavior. Kept for callers that built tagIds
+	// against that scheme and can't re-slugify existing content yet.
+	SlugModeKeepAccents
+)
+
 // NewParser creates a new parser with the book path
 func NewParser(bookPath string) *Parser {
 	return &Parser{bookPath: bookPath}
 }
 
-// frontmatter represents the YAML frontmatter from MDX
+// SetLowMemoryMode toggles whether Parser is allowed to build accelerating
+// structures (currently: the keyword inverted index) that trade memory for
+// search speed. Small devices (e.g. a Raspberry Pi also running Ollama) want
+// this off so BuildKeywordIndex and any future warm-up step are no-ops and
+// SearchExplain always falls back to its plain per-line scan.
+func (p *Parser) SetLowMemoryMode(enabled bool) {
+	p.lowMemoryMode = enabled
+}
+
+// SetSlugMode controls whether GenerateTagID strips accents before
+// slugifying (the default, matching the website) or keeps them.
+func (p *Parser) SetSlugMode(mode SlugMode) {
+	p.slugMode = mode
+}
+
+func sectionCacheKey(chapterID, sectionTagID, locale string) string {
+	return locale + "|" + chapterID + "|" + sectionTagID
+}
+
+// frontmatter represents the YAML frontmatter from MDX. Extra collects any
+// fields the book's authors add that this parser doesn't otherwise model
+// (e.g. experimental metadata), so a new frontmatter key never gets silently
+// dropped or breaks parsing of the fields we do care about.
 type frontmatter struct {
-	ID        string    `json:"id"`
-	Order     int       `json:"order"`
-	Name      string    `json:"name"`
-	TitleList []Section `json:"titleList"`
+	ID              string                 `yaml:"id"`
+	Order           int                    `yaml:"order"`
+	Name            string                 `yaml:"name"`
+	TitleList       []Section              `yaml:"titleList"`
+	Prerequisites   []string               `yaml:"prerequisites"`
+	Tags            []string               `yaml:"tags"`
+	Description     string                 `yaml:"description"`
+	ReviewQuestions []ReviewQuestion       `yaml:"reviewQuestions"`
+	Exercises       []string               `yaml:"exercises"`
+	Extra           map[string]interface{} `yaml:",inline"`
+}
+
+// chapterCacheEntry holds a parsed Chapter alongside the file mtime it was
+// parsed from, so parseChapterCached can tell a still-fresh entry from one
+// that needs re-parsing because the underlying .mdx file changed on disk.
+type chapterCacheEntry struct {
+	chapter *Chapter
+	modTime time.Time
+}
+
+// chapterCacheKey distinguishes ParseChapter's full-body parse from
+// ParseChapterMetadata's frontmatter-only parse, since both cache by
+// filePath but would otherwise collide on the same key.
+func chapterCacheKey(mode, filePath string) string {
+	return mode + "|" + filePath
+}
+
+// parseChapterCached runs parse() only if filePath has no cached entry or
+// its mtime has changed since the cached entry was built, so ListChapters
+// doesn't re-read and re-parse every .mdx file on every call.
+func (p *Parser) parseChapterCached(mode, filePath string, parse func() (*Chapter, error)) (*Chapter, error) {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading file %s: %w", filePath, err)
+	}
+
+	key := chapterCacheKey(mode, filePath)
+	if cached, ok := p.chapterCache.Load(key); ok {
+		entry := cached.(chapterCacheEntry)
+		if entry.modTime.Equal(info.ModTime()) {
+			chapterCopy := *entry.chapter
+			return &chapterCopy, nil
+		}
+	}
+
+	chapter, err := parse()
+	if err != nil {
+		return nil, err
+	}
+	p.chapterCache.Store(key, chapterCacheEntry{chapter: chapter, modTime: info.ModTime()})
+	return chapter, nil
 }
 
 // ParseChapter parses an MDX file and returns a Chapter
 func (p *Parser) ParseChapter(filePath string, locale string) (*Chapter, error) {
+	return p.parseChapterCached("full", filePath, func() (*Chapter, error) {
+		return p.parseChapterUncached(filePath, locale)
+	})
+}
+
+func (p *Parser) parseChapterUncached(filePath string, locale string) (*Chapter, error) {
 	content, err := os.ReadFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("error reading file %s: %w", filePath, err)
 	}
 
-	contentStr := string(content)
+	// Normalize Windows-style line endings so downstream line-based parsing
+	// (frontmatter, section splitting, search) behaves identically on files
+	// checked out with CRLF.
+	contentStr := strings.ReplaceAll(string(content), "\r\n", "\n")
 
 	// Separate frontmatter from content
-	fm, body, err := p.parseFrontmatter(contentStr)
+	fmContent, body, err := p.splitFrontmatter(contentStr)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing frontmatter in %s: %w", filePath, err)
+	}
+	fm := p.parseFrontmatterFields(fmContent)
+
+	return &Chapter{
+		ID:              fm.ID,
+		Order:           fm.Order,
+		Name:            fm.Name,
+		Locale:          locale,
+		TitleList:       fm.TitleList,
+		Content:         body,
+		FilePath:        filePath,
+		Prerequisites:   fm.Prerequisites,
+		Tags:            fm.Tags,
+		Description:     fm.Description,
+		ReviewQuestions: fm.ReviewQuestions,
+		Exercises:       fm.Exercises,
+		sectionOffsets:  p.computeSectionOffsets(body),
+	}, nil
+}
+
+// ParseChapterMetadata parses just the frontmatter of an MDX file, leaving
+// Content empty. Unlike ParseChapter, it reads the file incrementally with
+// a buffered reader and stops as soon as it hits the closing "---", so
+// listing chapters for an index doesn't pull every chapter's full body
+// (often the bulk of the file) into memory just to discard it.
+func (p *Parser) ParseChapterMetadata(filePath string, locale string) (*Chapter, error) {
+	return p.parseChapterCached("metadata", filePath, func() (*Chapter, error) {
+		return p.parseChapterMetadataUncached(filePath, locale)
+	})
+}
+
+func (p *Parser) parseChapterMetadataUncached(filePath string, locale string) (*Chapter, error) {
+	fmContent, err := p.readFrontmatterBlock(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing frontmatter in %s: %w", filePath, err)
 	}
+	fm := p.parseFrontmatterFields(fmContent)
 
 	return &Chapter{
-		ID:        fm.ID,
-		Order:     fm.Order,
-		Name:      fm.Name,
-		Locale:    locale,
-		TitleList: fm.TitleList,
-		Content:   body,
-		FilePath:  filePath,
+		ID:              fm.ID,
+		Order:           fm.Order,
+		Name:            fm.Name,
+		Locale:          locale,
+		TitleList:       fm.TitleList,
+		FilePath:        filePath,
+		Prerequisites:   fm.Prerequisites,
+		Tags:            fm.Tags,
+		Description:     fm.Description,
+		ReviewQuestions: fm.ReviewQuestions,
+		Exercises:       fm.Exercises,
 	}, nil
 }
 
-// parseFrontmatter extracts the YAML frontmatter from MDX content
-func (p *Parser) parseFrontmatter(content string) (*frontmatter, string, error) {
+// readFrontmatterBlock reads filePath with a buffered reader, returning the
+// text between the opening and closing "---" delimiters without reading (or
+// buffering in memory) anything past the closing delimiter.
+func (p *Parser) readFrontmatterBlock(filePath string) (string, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return "", fmt.Errorf("error reading file %s: %w", filePath, err)
+	}
+	defer f.Close()
+
+	reader := bufio.NewReader(f)
+
+	first, err := reader.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("error reading file %s: %w", filePath, err)
+	}
+	if strings.TrimSpace(first) != "---" {
+		return "", fmt.Errorf("no frontmatter found")
+	}
+
+	var block strings.Builder
+	for {
+		line, err := reader.ReadString('\n')
+		if strings.TrimSpace(line) == "---" {
+			return block.String(), nil
+		}
+		if err == io.EOF {
+			return "", fmt.Errorf("frontmatter not closed")
+		}
+		if err != nil {
+			return "", fmt.Errorf("error reading file %s: %w", filePath, err)
+		}
+		block.WriteString(line)
+	}
+}
+
+// splitFrontmatter splits raw MDX content into its frontmatter block (the
+// text between the opening and closing "---") and the body that follows.
+func (p *Parser) splitFrontmatter(content string) (string, string, error) {
 	// Frontmatter is between --- and ---
 	if !strings.HasPrefix(content, "---") {
-		return nil, content, fmt.Errorf("no frontmatter found")
+		return "", content, fmt.Errorf("no frontmatter found")
 	}
 
 	// Find the second ---
 	endIndex := strings.Index(content[3:], "---")
 	if endIndex == -1 {
-		return nil, content, fmt.Errorf("frontmatter not closed")
+		return "", content, fmt.Errorf("frontmatter not closed")
 	}
 
 	fmContent := content[3 : endIndex+3]
 	body := strings.TrimSpace(content[endIndex+6:])
+	return fmContent, body, nil
+}
 
-	// Parse frontmatter manually (it's YAML-like but with JSON arrays)
+// parseFrontmatterFields extracts id/order/name/titleList/prerequisites (and
+// any other declared or unknown fields) out of a frontmatter block's raw
+// YAML text. A frontmatter block that doesn't parse as YAML at all (rare,
+// but authors do hand-edit these files) degrades to an empty frontmatter
+// rather than failing the whole chapter parse.
+func (p *Parser) parseFrontmatterFields(fmContent string) *frontmatter {
 	fm := &frontmatter{}
-
-	// Extract id (supports both quoted and unquoted values)
-	idRegex := regexp.MustCompile(`id:\s*(?:['"]([^'"]+)['"]|([^\s'"]+))`)
-	idMatch := idRegex.FindStringSubmatch(fmContent)
-	if len(idMatch) > 1 {
-		if idMatch[1] != "" {
-			fm.ID = idMatch[1] // quoted value
-		} else if len(idMatch) > 2 {
-			fm.ID = idMatch[2] // unquoted value
-		}
+	if err := yaml.Unmarshal([]byte(fmContent), fm); err != nil {
+		return &frontmatter{}
 	}
+	return fm
+}
 
-	// Extract order
-	orderMatch := regexp.MustCompile(`order:\s*(\d+)`).FindStringSubmatch(fmContent)
-	if len(orderMatch) > 1 {
-		fm.Order, _ = strconv.Atoi(orderMatch[1])
-	}
+// ListChapters lists all chapters for a locale
+func (p *Parser) ListChapters(locale string) ([]Chapter, error) {
+	localePath := filepath.Join(p.bookPath, locale)
 
-	// Extract name (supports both quoted and unquoted values)
-	// For unquoted, capture until end of line
-	nameRegex := regexp.MustCompile(`(?m)^name:\s*(?:['"]([^'"]+)['"]|([^\n]+))`)
-	nameMatch := nameRegex.FindStringSubmatch(fmContent)
-	if len(nameMatch) > 1 {
-		if nameMatch[1] != "" {
-			fm.Name = nameMatch[1] // quoted value
-		} else if len(nameMatch) > 2 {
-			fm.Name = strings.TrimSpace(nameMatch[2]) // unquoted value
-		}
+	entries, err := os.ReadDir(localePath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading directory %s: %w", localePath, err)
 	}
 
-	// Extract titleList (it's a JSON-like array)
-	titleListStart := strings.Index(fmContent, "titleList:")
-	if titleListStart != -1 {
-		// Find the complete array
-		arrayStart := strings.Index(fmContent[titleListStart:], "[")
-		if arrayStart != -1 {
-			bracketCount := 0
-			arrayEnd := -1
-			startPos := titleListStart + arrayStart
-
-			for i := startPos; i < len(fmContent); i++ {
-				if fmContent[i] == '[' {
-					bracketCount++
-				} else if fmContent[i] == ']' {
-					bracketCount--
-					if bracketCount == 0 {
-						arrayEnd = i + 1
-						break
-					}
-				}
-			}
-
-			if arrayEnd != -1 {
-				arrayContent := fmContent[startPos:arrayEnd]
-				// Clean content to make it valid JSON
-				arrayContent = p.cleanArrayToJSON(arrayContent)
+	var chapters []Chapter
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".mdx") {
+			continue
+		}
 
-				var sections []Section
-				if err := json.Unmarshal([]byte(arrayContent), &sections); err == nil {
-					fm.TitleList = sections
-				}
-			}
+		filePath := filepath.Join(localePath, entry.Name())
+		chapter, err := p.ParseChapter(filePath, locale)
+		if err != nil {
+			// Log error but continue with other files
+			fmt.Fprintf(os.Stderr, "Warning: could not parse %s: %v\n", filePath, err)
+			continue
 		}
+		chapters = append(chapters, *chapter)
 	}
 
-	return fm, body, nil
-}
-
-// cleanArrayToJSON cleans YAML-like array to valid JSON
-func (p *Parser) cleanArrayToJSON(content string) string {
-	// Replace single quotes with double quotes
-	content = strings.ReplaceAll(content, "'", "\"")
-
-	// Ensure keys are quoted
-	content = regexp.MustCompile(`(\s)name:`).ReplaceAllString(content, `$1"name":`)
-	content = regexp.MustCompile(`(\s)tagId:`).ReplaceAllString(content, `$1"tagId":`)
-	content = regexp.MustCompile(`{\s*name:`).ReplaceAllString(content, `{"name":`)
-	content = regexp.MustCompile(`{\s*tagId:`).ReplaceAllString(content, `{"tagId":`)
-
-	// Clean extra spaces and newlines
-	content = regexp.MustCompile(`\s+`).ReplaceAllString(content, " ")
+	// Sort by order
+	sort.Slice(chapters, func(i, j int) bool {
+		return chapters[i].Order < chapters[j].Order
+	})
 
-	return content
+	return chapters, nil
 }
 
-// ListChapters lists all chapters for a locale
-func (p *Parser) ListChapters(locale string) ([]Chapter, error) {
+// ListChaptersMetadata lists every chapter's frontmatter for a locale
+// without reading chapter bodies, for callers (chapter listings, the book
+// index) that only need metadata.
+func (p *Parser) ListChaptersMetadata(locale string) ([]Chapter, error) {
 	localePath := filepath.Join(p.bookPath, locale)
 
 	entries, err := os.ReadDir(localePath)
@@ -175,7 +326,7 @@ func (p *Parser) ListChapters(locale string) ([]Chapter, error) {
 		}
 
 		filePath := filepath.Join(localePath, entry.Name())
-		chapter, err := p.ParseChapter(filePath, locale)
+		chapter, err := p.ParseChapterMetadata(filePath, locale)
 		if err != nil {
 			// Log error but continue with other files
 			fmt.Fprintf(os.Stderr, "Warning: could not parse %s: %v\n", filePath, err)
@@ -205,55 +356,109 @@ func (p *Parser) GetChapter(chapterID string, locale string) (*Chapter, error) {
 		}
 	}
 
-	return nil, fmt.Errorf("chapter not found: %s", chapterID)
+	return nil, fmt.Errorf(i18n.T("error.chapter_not_found", "chapter not found: %s"), chapterID)
 }
 
 // GetSection gets a specific section from a chapter
 func (p *Parser) GetSection(chapterID string, sectionTagID string, locale string) (string, error) {
+	key := sectionCacheKey(chapterID, sectionTagID, locale)
+	if cached, ok := p.sectionCache.Load(key); ok {
+		return cached.(string), nil
+	}
+
+	content, err := p.getSectionUncached(chapterID, sectionTagID, locale)
+	if err != nil {
+		return "", err
+	}
+
+	p.sectionCache.Store(key, content)
+	return content, nil
+}
+
+// getSectionUncached does the actual section extraction, bypassing the
+// cache. The chapter's heading offsets were already computed once in
+// ParseChapter, so this is a direct slice of Content rather than a re-split
+// into lines and a regex pass per call.
+func (p *Parser) getSectionUncached(chapterID string, sectionTagID string, locale string) (string, error) {
 	chapter, err := p.GetChapter(chapterID, locale)
 	if err != nil {
 		return "", err
 	}
 
-	// Search for the section in content
-	lines := strings.Split(chapter.Content, "\n")
+	for _, off := range chapter.sectionOffsets {
+		if off.TagID == sectionTagID {
+			return strings.TrimSpace(chapter.Content[off.Start:off.End]), nil
+		}
+	}
 
-	// Find the header that matches the tagId
-	inSection := false
-	var sectionContent strings.Builder
-	headerPattern := regexp.MustCompile(`^#{1,6}\s+(.+)$`)
+	return "", fmt.Errorf(i18n.T("error.section_not_found", "section not found: %s"), sectionTagID)
+}
 
-	for _, line := range lines {
-		if matches := headerPattern.FindStringSubmatch(line); len(matches) > 1 {
-			headerText := matches[1]
-			currentTagID := p.generateTagID(headerText)
+// computeSectionOffsets scans content once for Markdown headings (levels 1-6)
+// and records each heading's byte range, from the heading line itself up to
+// the next heading (or end of content). GetSection then slices Content
+// directly using these offsets instead of re-scanning on every call.
+func (p *Parser) computeSectionOffsets(content string) []sectionOffset {
+	headerPattern := regexp.MustCompile(`(?m)^#{1,6}\s+(.+)$`)
+	matches := headerPattern.FindAllStringSubmatchIndex(content, -1)
+
+	offsets := make([]sectionOffset, 0, len(matches))
+	for i, m := range matches {
+		headerText := content[m[2]:m[3]]
+		end := len(content)
+		if i+1 < len(matches) {
+			end = matches[i+1][0]
+		}
+		offsets = append(offsets, sectionOffset{
+			TagID: p.GenerateTagID(headerText),
+			Name:  headerText,
+			Start: m[0],
+			End:   end,
+		})
+	}
+	return offsets
+}
 
-			if currentTagID == sectionTagID {
-				inSection = true
-				sectionContent.WriteString(line)
-				sectionContent.WriteString("\n")
-				continue
-			} else if inSection {
-				// Reached another section, stop
+// PrefetchAdjacentSections asynchronously warms the section cache for the
+// sections immediately before and after sectionTagID in chapterID's titleList.
+// Agents overwhelmingly read neighboring sections next after a section read, so
+// this makes those follow-up reads come back instantly. It returns immediately;
+// any parse errors are swallowed since prefetching is best-effort.
+func (p *Parser) PrefetchAdjacentSections(chapterID string, sectionTagID string, locale string) {
+	go func() {
+		chapter, err := p.GetChapter(chapterID, locale)
+		if err != nil {
+			return
+		}
+
+		idx := -1
+		for i, s := range chapter.TitleList {
+			if s.TagID == sectionTagID {
+				idx = i
 				break
 			}
 		}
+		if idx == -1 {
+			return
+		}
 
-		if inSection {
-			sectionContent.WriteString(line)
-			sectionContent.WriteString("\n")
+		neighbors := []int{idx - 1, idx + 1}
+		for _, n := range neighbors {
+			if n < 0 || n >= len(chapter.TitleList) {
+				continue
+			}
+			_, _ = p.GetSection(chapterID, chapter.TitleList[n].TagID, locale)
 		}
-	}
+	}()
+}
 
-	if sectionContent.Len() == 0 {
-		return "", fmt.Errorf("section not found: %s", sectionTagID)
+// GenerateTagID generates a tagId from a title, using the same algorithm the
+// website uses to build its heading anchors.
+func (p *Parser) GenerateTagID(title string) string {
+	if p.slugMode != SlugModeKeepAccents {
+		title = stripAccents(title)
 	}
 
-	return strings.TrimSpace(sectionContent.String()), nil
-}
-
-// generateTagID generates a tagId from a title
-func (p *Parser) generateTagID(title string) string {
 	// Convert to lowercase
 	tagID := strings.ToLower(title)
 
@@ -272,18 +477,63 @@ func (p *Parser) generateTagID(title string) string {
 	return tagID
 }
 
+// stripAccentsTransformer decomposes accented letters into base letter +
+// combining mark (NFD), drops the combining marks, then recomposes (NFC) so
+// e.g. "ó" becomes "o" -- the same transliteration a github-slugger-style
+// anchor generator applies before slugifying.
+var stripAccentsTransformer = transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+
+func stripAccents(s string) string {
+	result, _, err := transform.String(stripAccentsTransformer, s)
+	if err != nil {
+		return s
+	}
+	return result
+}
+
 // Search searches content in the book
 func (p *Parser) Search(query string, locale string) ([]SearchResult, error) {
+	return p.SearchExplain(query, locale, false)
+}
+
+// SearchExplain searches content in the book, optionally attaching a ScoreExplain
+// to each result describing which query terms matched.
+func (p *Parser) SearchExplain(query string, locale string, explain bool) ([]SearchResult, error) {
 	chapters, err := p.ListChapters(locale)
 	if err != nil {
 		return nil, err
 	}
 
+	if fts5Enabled() && !p.lowMemoryMode {
+		idx, err := p.fts5IndexFor(locale, chapters)
+		if err != nil {
+			return nil, err
+		}
+		return searchFTS5(idx, chapters, query, locale, explain)
+	}
+
+	if bleveSearchEnabled() && !p.lowMemoryMode {
+		idx, err := p.bleveIndexFor(locale, chapters)
+		if err != nil {
+			return nil, err
+		}
+		return idx.search(chapters, query, locale, explain)
+	}
+
 	var results []SearchResult
 	queryLower := strings.ToLower(query)
 	queryWords := strings.Fields(queryLower)
 
+	var candidates map[string]bool
+	if idx := p.keywordIndexFor(locale, chapters); idx != nil {
+		candidates = idx.candidateChapters(queryWords)
+	}
+
 	for _, chapter := range chapters {
+		if candidates != nil && !candidates[chapter.ID] {
+			continue
+		}
+
 		scanner := bufio.NewScanner(strings.NewReader(chapter.Content))
 		lineNum := 0
 		currentSection := ""
@@ -300,38 +550,54 @@ func (p *Parser) Search(query string, locale string) ([]SearchResult, error) {
 			}
 
 			// Search for matches
-			matchCount := 0
+			var matchedTerms []string
 			for _, word := range queryWords {
 				if strings.Contains(lineLower, word) {
-					matchCount++
+					matchedTerms = append(matchedTerms, word)
 				}
 			}
 
-			if matchCount > 0 {
-				relevance := float64(matchCount) / float64(len(queryWords))
+			if len(matchedTerms) > 0 {
+				relevance := float64(len(matchedTerms)) / float64(len(queryWords))
 
 				// Create snippet with context
-				snippet := line
-				if len(snippet) > 200 {
-					snippet = snippet[:200] + "..."
+				snippet := Truncate(line, 200)
+
+				result := SearchResult{
+					ChapterID:       chapter.ID,
+					ChapterName:     chapter.Name,
+					Section:         currentSection,
+					Snippet:         snippet,
+					LineNumber:      lineNum,
+					Relevance:       relevance,
+					Locale:          locale,
+					EstimatedTokens: EstimateTokens(snippet),
+					Tone:            ToneLabelStrings(ClassifyTone(line)),
 				}
 
-				results = append(results, SearchResult{
-					ChapterID:   chapter.ID,
-					ChapterName: chapter.Name,
-					Section:     currentSection,
-					Snippet:     snippet,
-					LineNumber:  lineNum,
-					Relevance:   relevance,
-					Locale:      locale,
-				})
+				if explain {
+					result.Explain = &ScoreExplain{
+						MatchedTerms: matchedTerms,
+						QueryTerms:   len(queryWords),
+						MatchedCount: len(matchedTerms),
+					}
+				}
+
+				results = append(results, result)
 			}
 		}
 	}
 
-	// Sort by relevance
-	sort.Slice(results, func(i, j int) bool {
-		return results[i].Relevance > results[j].Relevance
+	// Canonical order: relevance descending, tie-broken by chapter ID then
+	// line number so results are reproducible across runs.
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Relevance != results[j].Relevance {
+			return results[i].Relevance > results[j].Relevance
+		}
+		if results[i].ChapterID != results[j].ChapterID {
+			return results[i].ChapterID < results[j].ChapterID
+		}
+		return results[i].LineNumber < results[j].LineNumber
 	})
 
 	// Limit results
@@ -342,19 +608,35 @@ func (p *Parser) Search(query string, locale string) ([]SearchResult, error) {
 	return results, nil
 }
 
+// ActiveSearchBackend reports which backend SearchExplain will actually use
+// right now: "fts5" or "bleve" when SEARCH_BACKEND selects one and
+// low-memory mode isn't forcing the plain scan instead, or "memory" (the
+// scan SearchExplain falls back to either way) otherwise. Callers that
+// offer several search tools (e.g. a combined one that picks semantic
+// search when available) use this to report which mode they actually used.
+func (p *Parser) ActiveSearchBackend() string {
+	if p.lowMemoryMode {
+		return "memory"
+	}
+	switch {
+	case fts5Enabled():
+		return "fts5"
+	case bleveSearchEnabled():
+		return "bleve"
+	default:
+		return "memory"
+	}
+}
+
 // GetBookIndex gets the complete book index
 func (p *Parser) GetBookIndex(locale string) (*BookIndex, error) {
-	chapters, err := p.ListChapters(locale)
+	chapters, err := p.ListChaptersMetadata(locale)
 	if err != nil {
 		return nil, err
 	}
 
-	// Clear content for index (metadata only)
-	for i := range chapters {
-		chapters[i].Content = "" // Don't include full content in index
-	}
-
 	return &BookIndex{
+		SchemaVersion: SchemaVersion,
 		Locale:        locale,
 		TotalChapters: len(chapters),
 		Chapters:      chapters,
@@ -377,3 +659,83 @@ func (p *Parser) GetAvailableLocales() ([]string, error) {
 
 	return locales, nil
 }
+
+// GetPrerequisites returns the chapter IDs declared as prerequisites for
+// chapterID in its frontmatter.
+func (p *Parser) GetPrerequisites(chapterID string, locale string) ([]string, error) {
+	chapter, err := p.GetChapter(chapterID, locale)
+	if err != nil {
+		return nil, err
+	}
+	return chapter.Prerequisites, nil
+}
+
+// LearningOrder topologically sorts locale's chapters by their declared
+// prerequisites, so a chapter never appears before anything it depends on.
+// Chapters with no dependency relationship to each other keep their relative
+// frontmatter Order. A prerequisite cycle is reported as an error rather
+// than silently dropped.
+func (p *Parser) LearningOrder(locale string) ([]Chapter, error) {
+	chapters, err := p.ListChapters(locale)
+	if err != nil {
+		return nil, err
+	}
+	return TopologicalSort(chapters)
+}
+
+// TopologicalSort orders chapters so that every chapter appears after all of
+// its Prerequisites. Chapters with no dependency relationship to each other
+// keep their relative frontmatter Order. A prerequisite cycle is reported as
+// an error rather than silently dropped. Prerequisite IDs not present in
+// chapters are ignored (so an override referencing a chapter in a locale
+// that doesn't have it yet doesn't break sorting for the rest).
+func TopologicalSort(chapters []Chapter) ([]Chapter, error) {
+	byID := make(map[string]Chapter, len(chapters))
+	for _, c := range chapters {
+		byID[c.ID] = c
+	}
+
+	inDegree := make(map[string]int, len(chapters))
+	dependents := make(map[string][]string, len(chapters))
+	for _, c := range chapters {
+		for _, prereq := range c.Prerequisites {
+			if _, ok := byID[prereq]; !ok {
+				continue // prerequisite doesn't exist in this locale; ignore it
+			}
+			inDegree[c.ID]++
+			dependents[prereq] = append(dependents[prereq], c.ID)
+		}
+	}
+
+	var ready []Chapter
+	for _, c := range chapters {
+		if inDegree[c.ID] == 0 {
+			ready = append(ready, c)
+		}
+	}
+	sort.Slice(ready, func(i, j int) bool { return ready[i].Order < ready[j].Order })
+
+	var order []Chapter
+	for len(ready) > 0 {
+		next := ready[0]
+		ready = ready[1:]
+		order = append(order, next)
+
+		var unlocked []Chapter
+		for _, id := range dependents[next.ID] {
+			inDegree[id]--
+			if inDegree[id] == 0 {
+				unlocked = append(unlocked, byID[id])
+			}
+		}
+		sort.Slice(unlocked, func(i, j int) bool { return unlocked[i].Order < unlocked[j].Order })
+		ready = append(ready, unlocked...)
+		sort.Slice(ready, func(i, j int) bool { return ready[i].Order < ready[j].Order })
+	}
+
+	if len(order) != len(chapters) {
+		return nil, fmt.Errorf("prerequisite cycle detected among book chapters")
+	}
+
+	return order, nil
+}
@@ -0,0 +1,177 @@
+package book
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeFixtureChapter writes a minimal MDX chapter file with the given line
+// ending ("\n" or "\r\n") and returns its path.
+func writeFixtureChapter(t *testing.T, dir, lineEnding string) string {
+	t.Helper()
+
+	lines := []string{
+		"---",
+		`id: "crlf-chapter"`,
+		"order: 1",
+		`name: "CRLF Chapter"`,
+		"titleList: []",
+		"---",
+		"# Intro",
+		"First paragraph.",
+		"",
+		"## Second Section",
+		"Second paragraph.",
+	}
+
+	content := ""
+	for _, l := range lines {
+		content += l + lineEnding
+	}
+
+	path := filepath.Join(dir, "crlf-chapter.mdx")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+	return path
+}
+
+// TestParseChapterCRLF verifies that chapters checked out with Windows-style
+// CRLF line endings parse identically to Unix LF files.
+func TestParseChapterCRLF(t *testing.T) {
+	dir := t.TempDir()
+	localeDir := filepath.Join(dir, "en")
+	if err := os.Mkdir(localeDir, 0o755); err != nil {
+		t.Fatalf("failed to create locale dir: %v", err)
+	}
+	path := writeFixtureChapter(t, localeDir, "\r\n")
+
+	p := NewParser(dir)
+	chapter, err := p.ParseChapter(path, "en")
+	if err != nil {
+		t.Fatalf("ParseChapter returned error: %v", err)
+	}
+
+	if chapter.ID != "crlf-chapter" {
+		t.Errorf("expected id %q, got %q", "crlf-chapter", chapter.ID)
+	}
+	if chapter.Name != "CRLF Chapter" {
+		t.Errorf("expected name %q, got %q", "CRLF Chapter", chapter.Name)
+	}
+
+	section, err := p.GetSection("crlf-chapter", "second-section", "en")
+	if err != nil {
+		t.Fatalf("GetSection returned error: %v", err)
+	}
+	if section == "" {
+		t.Error("expected non-empty section content")
+	}
+}
+
+// TestParseChapterLFAndCRLFMatch ensures parsing the same content with LF vs
+// CRLF endings produces the same chapter content.
+func TestParseChapterLFAndCRLFMatch(t *testing.T) {
+	lfDir := t.TempDir()
+	crlfDir := t.TempDir()
+
+	lfPath := writeFixtureChapter(t, lfDir, "\n")
+	crlfPath := writeFixtureChapter(t, crlfDir, "\r\n")
+
+	p := NewParser(lfDir)
+
+	lfChapter, err := p.ParseChapter(lfPath, "en")
+	if err != nil {
+		t.Fatalf("ParseChapter(lf) returned error: %v", err)
+	}
+	crlfChapter, err := p.ParseChapter(crlfPath, "en")
+	if err != nil {
+		t.Fatalf("ParseChapter(crlf) returned error: %v", err)
+	}
+
+	if lfChapter.Content != crlfChapter.Content {
+		t.Errorf("LF and CRLF content differ:\nLF:   %q\nCRLF: %q", lfChapter.Content, crlfChapter.Content)
+	}
+}
+
+// TestParseChapterMetadataOmitsContent verifies that the metadata-only path
+// extracts the same frontmatter fields as ParseChapter while leaving Content
+// empty.
+func TestParseChapterMetadataOmitsContent(t *testing.T) {
+	dir := t.TempDir()
+	localeDir := filepath.Join(dir, "en")
+	if err := os.Mkdir(localeDir, 0o755); err != nil {
+		t.Fatalf("failed to create locale dir: %v", err)
+	}
+	path := writeFixtureChapter(t, localeDir, "\n")
+
+	p := NewParser(dir)
+
+	full, err := p.ParseChapter(path, "en")
+	if err != nil {
+		t.Fatalf("ParseChapter returned error: %v", err)
+	}
+	meta, err := p.ParseChapterMetadata(path, "en")
+	if err != nil {
+		t.Fatalf("ParseChapterMetadata returned error: %v", err)
+	}
+
+	if meta.Content != "" {
+		t.Errorf("expected empty Content from ParseChapterMetadata, got %q", meta.Content)
+	}
+	if meta.ID != full.ID || meta.Order != full.Order || meta.Name != full.Name {
+		t.Errorf("metadata fields differ from full parse: got %+v, want id/order/name from %+v", meta, full)
+	}
+}
+
+// TestListChaptersMetadataOmitsContent verifies the directory-listing
+// variant also leaves Content empty for every chapter.
+func TestListChaptersMetadataOmitsContent(t *testing.T) {
+	dir := t.TempDir()
+	localeDir := filepath.Join(dir, "en")
+	if err := os.Mkdir(localeDir, 0o755); err != nil {
+		t.Fatalf("failed to create locale dir: %v", err)
+	}
+	writeFixtureChapter(t, localeDir, "\n")
+
+	p := NewParser(dir)
+	chapters, err := p.ListChaptersMetadata("en")
+	if err != nil {
+		t.Fatalf("ListChaptersMetadata returned error: %v", err)
+	}
+	if len(chapters) != 1 {
+		t.Fatalf("expected 1 chapter, got %d", len(chapters))
+	}
+	if chapters[0].Content != "" {
+		t.Errorf("expected empty Content, got %q", chapters[0].Content)
+	}
+	if chapters[0].ID != "crlf-chapter" {
+		t.Errorf("expected id %q, got %q", "crlf-chapter", chapters[0].ID)
+	}
+}
+
+// TestGetSectionUsesPrecomputedOffsets verifies that section extraction
+// still returns the right slice of content for sections other than the
+// first, exercising the offsets computed during ParseChapter.
+func TestGetSectionUsesPrecomputedOffsets(t *testing.T) {
+	dir := t.TempDir()
+	localeDir := filepath.Join(dir, "en")
+	if err := os.Mkdir(localeDir, 0o755); err != nil {
+		t.Fatalf("failed to create locale dir: %v", err)
+	}
+	writeFixtureChapter(t, localeDir, "\n")
+
+	p := NewParser(dir)
+
+	second, err := p.GetSection("crlf-chapter", "second-section", "en")
+	if err != nil {
+		t.Fatalf("GetSection returned error: %v", err)
+	}
+	if second != "## Second Section\nSecond paragraph." {
+		t.Errorf("unexpected section content: %q", second)
+	}
+
+	if _, err := p.GetSection("crlf-chapter", "missing-section", "en"); err == nil {
+		t.Error("expected error for missing section")
+	}
+}
@@ -0,0 +1,176 @@
+package book
+
+import (
+	"bufio"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// QuoteMatch is a verbatim passage located by FindQuote, together with its
+// exact provenance and how closely it matched the requested quote.
+type QuoteMatch struct {
+	SchemaVersion int     `json:"schemaVersion"`
+	ChapterID     string  `json:"chapterId"`
+	ChapterName   string  `json:"chapterName"`
+	Section       string  `json:"section"`
+	Text          string  `json:"text"`
+	LineNumber    int     `json:"lineNumber"`
+	Locale        string  `json:"locale"`
+	Similarity    float64 `json:"similarity"`
+}
+
+// quoteMatchThreshold is the minimum similarity score below which FindQuote
+// reports no match, rather than returning an unrelated "closest" line.
+const quoteMatchThreshold = 0.35
+
+var quoteHeaderPattern = regexp.MustCompile(`^#{1,6}\s+(.+)$`)
+
+// normalizeForMatch lowercases s and collapses punctuation into whitespace,
+// so fuzzy comparisons ignore surface differences like quotation marks.
+func normalizeForMatch(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// quoteSimilarity scores how close candidate is to query (both already
+// normalized), combining substring containment, word overlap, and a
+// Levenshtein-based ratio, so a near-exact misquote still scores highly.
+func quoteSimilarity(query, candidate string) float64 {
+	if query == "" || candidate == "" {
+		return 0
+	}
+	if strings.Contains(candidate, query) {
+		return 1.0
+	}
+
+	qWords := strings.Fields(query)
+	cWords := strings.Fields(candidate)
+	cSet := make(map[string]bool, len(cWords))
+	for _, w := range cWords {
+		cSet[w] = true
+	}
+	matched := 0
+	for _, w := range qWords {
+		if cSet[w] {
+			matched++
+		}
+	}
+	overlap := 0.0
+	if len(qWords) > 0 {
+		overlap = float64(matched) / float64(len(qWords))
+	}
+
+	dist := levenshteinDistance(query, candidate)
+	maxLen := len(query)
+	if len(candidate) > maxLen {
+		maxLen = len(candidate)
+	}
+	editRatio := 0.0
+	if maxLen > 0 {
+		editRatio = 1 - float64(dist)/float64(maxLen)
+		if editRatio < 0 {
+			editRatio = 0
+		}
+	}
+
+	if overlap > editRatio {
+		return overlap
+	}
+	return editRatio
+}
+
+// levenshteinDistance computes the classic edit distance between a and b.
+func levenshteinDistance(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	prev := make([]int, len(br)+1)
+	curr := make([]int, len(br)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ar); i++ {
+		curr[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			min := prev[j] + 1 // deletion
+			if v := curr[j-1] + 1; v < min {
+				min = v // insertion
+			}
+			if v := prev[j-1] + cost; v < min {
+				min = v // substitution
+			}
+			curr[j] = min
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(br)]
+}
+
+// FindQuote locates the line in the book that most closely matches an
+// approximate quote, so callers get back the verbatim text with exact
+// chapter/section/line attribution instead of paraphrasing from memory.
+func (p *Parser) FindQuote(quote, locale string) (*QuoteMatch, error) {
+	chapters, err := p.ListChapters(locale)
+	if err != nil {
+		return nil, err
+	}
+
+	normalizedQuote := normalizeForMatch(quote)
+	if normalizedQuote == "" {
+		return nil, fmt.Errorf("quote must not be empty")
+	}
+
+	var best *QuoteMatch
+
+	for _, chapter := range chapters {
+		scanner := bufio.NewScanner(strings.NewReader(chapter.Content))
+		lineNum := 0
+		currentSection := ""
+
+		for scanner.Scan() {
+			lineNum++
+			line := scanner.Text()
+			trimmed := strings.TrimSpace(line)
+			if trimmed == "" {
+				continue
+			}
+
+			if matches := quoteHeaderPattern.FindStringSubmatch(trimmed); len(matches) > 1 {
+				currentSection = matches[1]
+				continue
+			}
+
+			score := quoteSimilarity(normalizedQuote, normalizeForMatch(trimmed))
+			if best == nil || score > best.Similarity {
+				best = &QuoteMatch{
+					SchemaVersion: SchemaVersion,
+					ChapterID:     chapter.ID,
+					ChapterName:   chapter.Name,
+					Section:       currentSection,
+					Text:          trimmed,
+					LineNumber:    lineNum,
+					Locale:        locale,
+					Similarity:    score,
+				}
+			}
+		}
+	}
+
+	if best == nil || best.Similarity < quoteMatchThreshold {
+		return nil, fmt.Errorf("no passage found closely matching that quote")
+	}
+
+	return best, nil
+}
@@ -0,0 +1,64 @@
+package book
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestFindQuoteFuzzyMatch verifies that a slightly misquoted passage still
+// resolves to its exact source line.
+func TestFindQuoteFuzzyMatch(t *testing.T) {
+	dir := t.TempDir()
+	localeDir := filepath.Join(dir, "en")
+	if err := os.Mkdir(localeDir, 0o755); err != nil {
+		t.Fatalf("failed to create locale dir: %v", err)
+	}
+
+	content := "---\n" +
+		`id: "quotes-chapter"` + "\n" +
+		"order: 1\n" +
+		`name: "Quotes Chapter"` + "\n" +
+		"titleList: []\n" +
+		"---\n" +
+		"# Intro\n" +
+		"A true gentleman always ships working code before talking about it.\n"
+
+	path := filepath.Join(localeDir, "quotes-chapter.mdx")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	p := NewParser(dir)
+
+	match, err := p.FindQuote("a true gentleman ships working code before talking about it", "en")
+	if err != nil {
+		t.Fatalf("FindQuote returned error: %v", err)
+	}
+	if match.ChapterID != "quotes-chapter" {
+		t.Errorf("expected chapter %q, got %q", "quotes-chapter", match.ChapterID)
+	}
+	if match.Text != "A true gentleman always ships working code before talking about it." {
+		t.Errorf("unexpected matched text: %q", match.Text)
+	}
+	if match.LineNumber != 2 {
+		t.Errorf("expected line 2, got %d", match.LineNumber)
+	}
+}
+
+// TestFindQuoteNoMatch verifies that an unrelated quote is reported as not
+// found rather than returning an unrelated "closest" line.
+func TestFindQuoteNoMatch(t *testing.T) {
+	dir := t.TempDir()
+	localeDir := filepath.Join(dir, "en")
+	if err := os.Mkdir(localeDir, 0o755); err != nil {
+		t.Fatalf("failed to create locale dir: %v", err)
+	}
+	writeFixtureChapter(t, localeDir, "\n")
+
+	p := NewParser(dir)
+
+	if _, err := p.FindQuote("a completely unrelated sentence about rocket engines", "en"); err == nil {
+		t.Error("expected error for an unrelated quote, got nil")
+	}
+}
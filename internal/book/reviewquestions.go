@@ -0,0 +1,60 @@
+package book
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// generatedReviewQuestions holds a chapter's generated review questions
+// alongside the file mtime they were generated from, mirroring
+// chapterCacheEntry so GetReviewQuestions doesn't regenerate its heuristic
+// set on every call -- only once per chapter until the underlying .mdx file
+// changes.
+type generatedReviewQuestions struct {
+	questions []ReviewQuestion
+	modTime   time.Time
+}
+
+// GetReviewQuestions returns chapterID's review questions: curated ones from
+// frontmatter if the chapter declares any, otherwise a heuristic set
+// generated from the chapter's headings and cached until the file changes.
+func (p *Parser) GetReviewQuestions(chapterID string, locale string) ([]ReviewQuestion, error) {
+	chapter, err := p.GetChapter(chapterID, locale)
+	if err != nil {
+		return nil, err
+	}
+	if len(chapter.ReviewQuestions) > 0 {
+		return chapter.ReviewQuestions, nil
+	}
+
+	info, err := os.Stat(chapter.FilePath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading file %s: %w", chapter.FilePath, err)
+	}
+
+	key := chapterCacheKey("reviewq", chapter.FilePath)
+	if cached, ok := p.reviewQCache.Load(key); ok {
+		entry := cached.(generatedReviewQuestions)
+		if entry.modTime.Equal(info.ModTime()) {
+			return entry.questions, nil
+		}
+	}
+
+	questions := generateReviewQuestions(chapter.sectionOffsets)
+	p.reviewQCache.Store(key, generatedReviewQuestions{questions: questions, modTime: info.ModTime()})
+	return questions, nil
+}
+
+// generateReviewQuestions builds one self-check question per heading,
+// pointing at that heading's tagId, for chapters with no curated questions.
+func generateReviewQuestions(offsets []sectionOffset) []ReviewQuestion {
+	questions := make([]ReviewQuestion, 0, len(offsets))
+	for _, off := range offsets {
+		questions = append(questions, ReviewQuestion{
+			Question: fmt.Sprintf("What does this chapter explain about %q?", off.Name),
+			TagID:    off.TagID,
+		})
+	}
+	return questions
+}
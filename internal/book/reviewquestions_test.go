@@ -0,0 +1,55 @@
+package book
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestGetReviewQuestionsGeneratesFromHeadingsWhenUncurated verifies that a
+// chapter with no frontmatter reviewQuestions gets one generated question
+// per heading, pointing at that heading's tagId.
+func TestGetReviewQuestionsGeneratesFromHeadingsWhenUncurated(t *testing.T) {
+	dir := t.TempDir()
+	localeDir := filepath.Join(dir, "en")
+	if err := os.Mkdir(localeDir, 0o755); err != nil {
+		t.Fatalf("failed to create locale dir: %v", err)
+	}
+	writeSearchFixture(t, localeDir, "ch1", "Chapter One", 1, "# Intro\nHello.\n## Details\nMore.")
+
+	p := NewParser(dir)
+	questions, err := p.GetReviewQuestions("ch1", "en")
+	if err != nil {
+		t.Fatalf("GetReviewQuestions returned error: %v", err)
+	}
+	if len(questions) != 2 {
+		t.Fatalf("expected 2 generated questions, got %d: %+v", len(questions), questions)
+	}
+	if questions[0].TagID != "intro" || questions[1].TagID != "details" {
+		t.Fatalf("unexpected tagIds: %+v", questions)
+	}
+}
+
+// TestGetReviewQuestionsPrefersCuratedFrontmatter verifies that a chapter
+// declaring its own reviewQuestions in frontmatter is returned verbatim
+// instead of a generated set.
+func TestGetReviewQuestionsPrefersCuratedFrontmatter(t *testing.T) {
+	dir := t.TempDir()
+	localeDir := filepath.Join(dir, "en")
+	if err := os.Mkdir(localeDir, 0o755); err != nil {
+		t.Fatalf("failed to create locale dir: %v", err)
+	}
+	mdx := "---\nid: \"ch1\"\norder: 1\nname: \"Chapter One\"\ntitleList: []\nreviewQuestions:\n  - question: \"What is covered?\"\n    tagId: \"intro\"\n---\n# Intro\nHello."
+	if err := os.WriteFile(filepath.Join(localeDir, "ch1.mdx"), []byte(mdx), 0o644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	p := NewParser(dir)
+	questions, err := p.GetReviewQuestions("ch1", "en")
+	if err != nil {
+		t.Fatalf("GetReviewQuestions returned error: %v", err)
+	}
+	if len(questions) != 1 || questions[0].Question != "What is covered?" {
+		t.Fatalf("expected curated question to win, got %+v", questions)
+	}
+}
@@ -0,0 +1,19 @@
+package book
+
+// SchemaVersion is stamped as "schemaVersion" on every object-shaped
+// structured tool result (get_book_index, verify_claim, find_quote, the
+// job/coverage/index-quality reports, and so on) so a client can tell which
+// result shape it's looking at.
+//
+// Compatibility policy: existing fields are never removed or repurposed
+// within a major version -- only added to. A field removal or incompatible
+// type change requires bumping SchemaVersion, so clients already checking
+// it can detect and handle the break instead of silently misreading new
+// data.
+//
+// Tool results that are bare JSON arrays (e.g. search_book, list_chapters)
+// don't carry a top-level schemaVersion field: wrapping a bare array in an
+// envelope object would itself be the shape-breaking change this policy
+// exists to prevent. Those stay compatible the same way -- items are only
+// ever added to, never renamed or removed.
+const SchemaVersion = 1
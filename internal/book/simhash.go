@@ -0,0 +1,78 @@
+package book
+
+import (
+	"hash/fnv"
+	"strings"
+)
+
+// shingleSize is the number of consecutive words hashed together when
+// building a simhash fingerprint. Shingles (rather than single words)
+// make the fingerprint sensitive to word order, so two chapters that
+// happen to share a lot of vocabulary but aren't actually the same text
+// don't collide.
+const shingleSize = 3
+
+// simhash64 computes a 64-bit simhash fingerprint of text's word shingles.
+// Two texts that are the same or near-identical (e.g. an "en" chapter that
+// is really an untouched copy of its "es" source) produce fingerprints with
+// a small Hamming distance; unrelated texts produce fingerprints that are
+// essentially random relative to each other.
+func simhash64(text string) uint64 {
+	words := wordPattern.FindAllString(strings.ToLower(text), -1)
+	if len(words) == 0 {
+		return 0
+	}
+
+	var weights [64]int
+	for _, shingle := range shingles(words, shingleSize) {
+		h := fnv.New64a()
+		h.Write([]byte(shingle))
+		sum := h.Sum64()
+		for bit := 0; bit < 64; bit++ {
+			if sum&(1<<uint(bit)) != 0 {
+				weights[bit]++
+			} else {
+				weights[bit]--
+			}
+		}
+	}
+
+	var fingerprint uint64
+	for bit := 0; bit < 64; bit++ {
+		if weights[bit] > 0 {
+			fingerprint |= 1 << uint(bit)
+		}
+	}
+	return fingerprint
+}
+
+// shingles joins each run of n consecutive words into a single string. If
+// there are fewer than n words, the whole word list is treated as one
+// shingle so short texts still produce a fingerprint.
+func shingles(words []string, n int) []string {
+	if len(words) <= n {
+		return []string{strings.Join(words, " ")}
+	}
+	out := make([]string, 0, len(words)-n+1)
+	for i := 0; i+n <= len(words); i++ {
+		out = append(out, strings.Join(words[i:i+n], " "))
+	}
+	return out
+}
+
+// hammingDistance64 counts the bits that differ between a and b.
+func hammingDistance64(a, b uint64) int {
+	x := a ^ b
+	count := 0
+	for x != 0 {
+		count++
+		x &= x - 1
+	}
+	return count
+}
+
+// simhashSimilarity converts the Hamming distance between two 64-bit
+// fingerprints into a 0..1 similarity score, where 1 means identical.
+func simhashSimilarity(a, b uint64) float64 {
+	return 1 - float64(hammingDistance64(a, b))/64
+}
@@ -0,0 +1,62 @@
+package book
+
+import "strings"
+
+// DefaultSlugPattern is the site URL layout used when a URLConfig doesn't
+// override it: one page per chapter, per locale, with the section anchored
+// as a hash fragment.
+const DefaultSlugPattern = "/{locale}/{chapterId}#{tagId}"
+
+// URLConfig maps book references to their public URLs on the site the book
+// is published to. BaseURL is required; an empty BaseURL means "no site is
+// configured" and callers should leave URL fields blank rather than emit a
+// broken link.
+type URLConfig struct {
+	BaseURL string
+
+	// SlugPattern is a path template with {locale}, {chapterId} and {tagId}
+	// placeholders, applied after BaseURL. Defaults to DefaultSlugPattern.
+	SlugPattern string
+}
+
+// Enabled reports whether c has a base URL to build links against.
+func (c URLConfig) Enabled() bool {
+	return c.BaseURL != ""
+}
+
+// ChapterURL returns the public URL for chapterID in locale, or "" if c
+// isn't enabled.
+func (c URLConfig) ChapterURL(chapterID, locale string) string {
+	return c.buildURL(chapterID, locale, "")
+}
+
+// SectionURL returns the public URL for the tagID section of chapterID in
+// locale, or "" if c isn't enabled.
+func (c URLConfig) SectionURL(chapterID, tagID, locale string) string {
+	return c.buildURL(chapterID, locale, tagID)
+}
+
+func (c URLConfig) buildURL(chapterID, locale, tagID string) string {
+	if !c.Enabled() {
+		return ""
+	}
+
+	pattern := c.SlugPattern
+	if pattern == "" {
+		pattern = DefaultSlugPattern
+	}
+
+	path := strings.NewReplacer(
+		"{locale}", locale,
+		"{chapterId}", chapterID,
+		"{tagId}", tagID,
+	).Replace(pattern)
+
+	if tagID == "" {
+		// Drop a trailing empty hash fragment left over from a pattern like
+		// the default one when no section was requested.
+		path = strings.TrimSuffix(path, "#")
+	}
+
+	return strings.TrimSuffix(c.BaseURL, "/") + path
+}
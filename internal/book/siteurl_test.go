@@ -0,0 +1,34 @@
+package book
+
+import "testing"
+
+func TestURLConfigDisabledWithoutBaseURL(t *testing.T) {
+	var c URLConfig
+	if c.Enabled() {
+		t.Fatal("expected URLConfig with no BaseURL to be disabled")
+	}
+	if got := c.ChapterURL("intro", "en"); got != "" {
+		t.Fatalf("expected empty URL, got %q", got)
+	}
+}
+
+func TestURLConfigDefaultSlugPattern(t *testing.T) {
+	c := URLConfig{BaseURL: "https://example.com/"}
+
+	if got, want := c.ChapterURL("intro", "en"), "https://example.com/en/intro"; got != want {
+		t.Fatalf("ChapterURL = %q, want %q", got, want)
+	}
+	if got, want := c.SectionURL("intro", "deep-dive", "en"), "https://example.com/en/intro#deep-dive"; got != want {
+		t.Fatalf("SectionURL = %q, want %q", got, want)
+	}
+}
+
+func TestURLConfigCustomSlugPattern(t *testing.T) {
+	c := URLConfig{BaseURL: "https://example.com", SlugPattern: "/book/{chapterId}/{locale}?section={tagId}"}
+
+	got := c.SectionURL("intro", "deep-dive", "en")
+	want := "https://example.com/book/intro/en?section=deep-dive"
+	if got != want {
+		t.Fatalf("SectionURL = %q, want %q", got, want)
+	}
+}
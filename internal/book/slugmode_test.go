@@ -0,0 +1,51 @@
+package book
+
+import "testing"
+
+// TestGenerateTagIDStripsAccentsByDefault verifies that GenerateTagID
+// transliterates accented letters to their ASCII base by default, matching
+// the website's github-slugger-based anchor generation.
+func TestGenerateTagIDStripsAccentsByDefault(t *testing.T) {
+	p := NewParser(t.TempDir())
+
+	got := p.GenerateTagID("Inyección de Dependencias")
+	want := "inyeccion-de-dependencias"
+	if got != want {
+		t.Errorf("GenerateTagID(%q) = %q, want %q", "Inyección de Dependencias", got, want)
+	}
+}
+
+// TestGenerateTagIDKeepAccentsMode verifies that SlugModeKeepAccents
+// restores the original accented-letter behavior for callers that need it.
+func TestGenerateTagIDKeepAccentsMode(t *testing.T) {
+	p := NewParser(t.TempDir())
+	p.SetSlugMode(SlugModeKeepAccents)
+
+	got := p.GenerateTagID("Inyección de Dependencias")
+	want := "inyección-de-dependencias"
+	if got != want {
+		t.Errorf("GenerateTagID(%q) = %q, want %q", "Inyección de Dependencias", got, want)
+	}
+}
+
+// TestTitleListDriftFlagsStaleAccentedTagIds verifies that a chapter whose
+// frontmatter still has the old accented-style tagId is flagged as drifted
+// once GenerateTagID starts stripping accents.
+func TestTitleListDriftFlagsStaleAccentedTagIds(t *testing.T) {
+	dir := t.TempDir()
+	localeDir := mkLocaleDir(t, dir, "es")
+	titleList := `[{"name": "Inyección", "tagId": "inyección"}]`
+	writeTitledFixture(t, localeDir, "intro", "Introduccion", 1, titleList, "# Inyección\nBody.")
+
+	p := NewParser(dir)
+	fresh, drifted, err := p.TitleListDrift("intro", "es")
+	if err != nil {
+		t.Fatalf("TitleListDrift returned error: %v", err)
+	}
+	if !drifted {
+		t.Fatalf("expected drift to be detected, got fresh=%+v", fresh)
+	}
+	if len(fresh) != 1 || fresh[0].TagID != "inyeccion" {
+		t.Fatalf("expected regenerated tagId %q, got %+v", "inyeccion", fresh)
+	}
+}
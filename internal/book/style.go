@@ -0,0 +1,188 @@
+package book
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// headingLevels lists the heading level keys in rank order, used whenever a
+// heading count map needs to be walked deterministically.
+var headingLevels = []string{"h1", "h2", "h3", "h4", "h5", "h6"}
+
+var headingLinePattern = regexp.MustCompile(`(?m)^(#{1,6})\s+.+$`)
+var admonitionPattern = regexp.MustCompile(`(?m)^>\s*\*\*([^*]+)\*\*`)
+var secondPersonPattern = regexp.MustCompile(`(?i)\b(tú|usted|you|your|tu|tus)\b`)
+
+// StyleProfile is a machine-readable summary of the writing conventions
+// found across a locale's chapters: heading levels actually in use, which
+// languages code fences are tagged with, which admonition markers appear,
+// and roughly how often the prose addresses the reader directly. check_style
+// lints a draft section against this instead of a hand-maintained style doc,
+// so it can't drift out of sync with what the book actually does.
+type StyleProfile struct {
+	SchemaVersion       int            `json:"schemaVersion"`
+	Locale              string         `json:"locale"`
+	ChaptersAnalyzed    int            `json:"chaptersAnalyzed"`
+	HeadingLevelCounts  map[string]int `json:"headingLevelCounts"`
+	CodeFenceLanguages  map[string]int `json:"codeFenceLanguages"` // "" is the count of untagged fences
+	AdmonitionMarkers   []string       `json:"admonitionMarkers"`
+	SecondPersonPerKilo float64        `json:"secondPersonPerKilo"` // second-person pronoun hits per 1000 words
+}
+
+// ExtractStyleProfile scans every chapter in locale and derives its writing
+// conventions.
+func (p *Parser) ExtractStyleProfile(locale string) (*StyleProfile, error) {
+	chapters, err := p.ListChapters(locale)
+	if err != nil {
+		return nil, err
+	}
+
+	headingCounts := make(map[string]int, len(headingLevels))
+	fenceCounts := make(map[string]int)
+	markerSeen := make(map[string]bool)
+	var totalWords, totalSecondPerson int
+
+	for _, ch := range chapters {
+		for level, count := range countHeadingLevels(ch.Content) {
+			headingCounts[level] += count
+		}
+		for lang, count := range extractCodeFenceLanguages(ch.Content) {
+			fenceCounts[lang] += count
+		}
+		for _, marker := range extractAdmonitionMarkers(ch.Content) {
+			markerSeen[marker] = true
+		}
+		totalWords += len(wordPattern.FindAllString(ch.Content, -1))
+		totalSecondPerson += len(secondPersonPattern.FindAllString(ch.Content, -1))
+	}
+
+	markers := make([]string, 0, len(markerSeen))
+	for m := range markerSeen {
+		markers = append(markers, m)
+	}
+	sort.Strings(markers)
+
+	var secondPersonPerKilo float64
+	if totalWords > 0 {
+		secondPersonPerKilo = float64(totalSecondPerson) / float64(totalWords) * 1000
+	}
+
+	return &StyleProfile{
+		SchemaVersion:       SchemaVersion,
+		Locale:              locale,
+		ChaptersAnalyzed:    len(chapters),
+		HeadingLevelCounts:  headingCounts,
+		CodeFenceLanguages:  fenceCounts,
+		AdmonitionMarkers:   markers,
+		SecondPersonPerKilo: secondPersonPerKilo,
+	}, nil
+}
+
+// countHeadingLevels tallies how many headings of each level appear in content.
+func countHeadingLevels(content string) map[string]int {
+	counts := make(map[string]int, len(headingLevels))
+	for _, line := range headingLinePattern.FindAllString(content, -1) {
+		level := strings.IndexByte(line, ' ') // position of the first space ends the leading #s
+		if level <= 0 || level > 6 {
+			continue
+		}
+		counts[headingLevels[level-1]]++
+	}
+	return counts
+}
+
+// extractCodeFenceLanguages tallies how many fenced code blocks use each
+// language tag, with "" counting untagged fences.
+func extractCodeFenceLanguages(content string) map[string]int {
+	counts := make(map[string]int)
+	inFence := false
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if !inFence {
+			if strings.HasPrefix(trimmed, "```") {
+				lang := strings.TrimSpace(strings.TrimPrefix(trimmed, "```"))
+				counts[lang]++
+				inFence = true
+			}
+			continue
+		}
+		if trimmed == "```" {
+			inFence = false
+		}
+	}
+	return counts
+}
+
+// extractAdmonitionMarkers finds blockquote callouts shaped like
+// "> **Nota:**" or "> **Tip:**" and returns their distinct bolded labels.
+func extractAdmonitionMarkers(content string) []string {
+	matches := admonitionPattern.FindAllStringSubmatch(content, -1)
+	seen := make(map[string]bool, len(matches))
+	var markers []string
+	for _, m := range matches {
+		label := strings.TrimSpace(m[1])
+		if label == "" || seen[label] {
+			continue
+		}
+		seen[label] = true
+		markers = append(markers, label)
+	}
+	return markers
+}
+
+// StyleIssue is one thing check_style noticed about a draft relative to a
+// StyleProfile. Severity is "warning" for conventions the draft likely
+// breaks, "info" for things worth a second look but not necessarily wrong.
+type StyleIssue struct {
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// CheckStyle lints draft against profile, flagging heading levels, code
+// fence tagging, and admonition markers that don't match how the rest of
+// the book actually writes.
+func CheckStyle(profile *StyleProfile, draft string) []StyleIssue {
+	var issues []StyleIssue
+
+	draftHeadings := countHeadingLevels(draft)
+	for _, level := range headingLevels {
+		if draftHeadings[level] > 0 && profile.HeadingLevelCounts[level] == 0 {
+			issues = append(issues, StyleIssue{
+				Severity: "warning",
+				Message:  "draft uses a " + level + " heading, which no existing chapter in this locale uses",
+			})
+		}
+	}
+
+	draftFences := extractCodeFenceLanguages(draft)
+	if draftFences[""] > 0 {
+		var taggedInBook int
+		for lang, count := range profile.CodeFenceLanguages {
+			if lang != "" {
+				taggedInBook += count
+			}
+		}
+		if taggedInBook > profile.CodeFenceLanguages[""] {
+			issues = append(issues, StyleIssue{
+				Severity: "warning",
+				Message:  "draft has untagged code fences, but most of the book's code fences specify a language",
+			})
+		}
+	}
+
+	known := make(map[string]bool, len(profile.AdmonitionMarkers))
+	for _, m := range profile.AdmonitionMarkers {
+		known[m] = true
+	}
+	for _, marker := range extractAdmonitionMarkers(draft) {
+		if !known[marker] {
+			issues = append(issues, StyleIssue{
+				Severity: "info",
+				Message:  "admonition marker \"" + marker + "\" isn't used elsewhere in the book; consider reusing an existing one",
+			})
+		}
+	}
+
+	return issues
+}
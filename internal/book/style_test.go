@@ -0,0 +1,70 @@
+package book
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestExtractStyleProfileCountsConventions verifies the profile tallies
+// heading levels, tagged code fences, and admonition markers across all
+// chapters in a locale.
+func TestExtractStyleProfileCountsConventions(t *testing.T) {
+	dir := t.TempDir()
+	localeDir := filepath.Join(dir, "en")
+	if err := os.Mkdir(localeDir, 0o755); err != nil {
+		t.Fatalf("failed to create locale dir: %v", err)
+	}
+
+	content := "# Intro\n\n> **Tip:** keep functions small.\n\n```go\nfunc main() {}\n```\n\n## Details\nMore words here, you should try this yourself."
+	writeSearchFixture(t, localeDir, "ch1", "Chapter One", 1, content)
+
+	p := NewParser(dir)
+	profile, err := p.ExtractStyleProfile("en")
+	if err != nil {
+		t.Fatalf("ExtractStyleProfile returned error: %v", err)
+	}
+
+	if profile.ChaptersAnalyzed != 1 {
+		t.Fatalf("expected 1 chapter analyzed, got %d", profile.ChaptersAnalyzed)
+	}
+	if profile.HeadingLevelCounts["h1"] != 1 || profile.HeadingLevelCounts["h2"] != 1 {
+		t.Fatalf("expected 1 h1 and 1 h2, got %+v", profile.HeadingLevelCounts)
+	}
+	if profile.CodeFenceLanguages["go"] != 1 {
+		t.Fatalf("expected 1 go-tagged fence, got %+v", profile.CodeFenceLanguages)
+	}
+	if len(profile.AdmonitionMarkers) != 1 || profile.AdmonitionMarkers[0] != "Tip:" {
+		t.Fatalf("expected Tip: admonition marker, got %v", profile.AdmonitionMarkers)
+	}
+}
+
+// TestCheckStyleFlagsUnknownHeadingAndUntaggedFence verifies that a draft
+// breaking the profile's established conventions is flagged.
+func TestCheckStyleFlagsUnknownHeadingAndUntaggedFence(t *testing.T) {
+	profile := &StyleProfile{
+		Locale:             "en",
+		HeadingLevelCounts: map[string]int{"h2": 5},
+		CodeFenceLanguages: map[string]int{"go": 10, "": 1},
+		AdmonitionMarkers:  []string{"Tip:"},
+	}
+
+	draft := "# Top Level Heading\n\n```\nuntagged fence\n```\n\n> **Warning:** something new."
+	issues := CheckStyle(profile, draft)
+
+	var sawHeading, sawFence, sawMarker bool
+	for _, issue := range issues {
+		switch {
+		case issue.Severity == "warning" && strings.Contains(issue.Message, "h1"):
+			sawHeading = true
+		case issue.Severity == "warning" && strings.Contains(issue.Message, "untagged"):
+			sawFence = true
+		case issue.Severity == "info" && strings.Contains(issue.Message, "Warning:"):
+			sawMarker = true
+		}
+	}
+	if !sawHeading || !sawFence || !sawMarker {
+		t.Fatalf("expected heading, fence, and marker issues, got %+v", issues)
+	}
+}
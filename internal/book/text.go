@@ -0,0 +1,18 @@
+package book
+
+// Truncate shortens s to at most maxRunes runes, appending "..." if
+// anything was cut. Unlike slicing a string by byte index, this never
+// splits a multi-byte UTF-8 character (e.g. "á", "ñ", "¿") at the cut point.
+func Truncate(s string, maxRunes int) string {
+	return TruncateWithSuffix(s, maxRunes, "...")
+}
+
+// TruncateWithSuffix is Truncate with a caller-supplied suffix instead of
+// the default "...".
+func TruncateWithSuffix(s string, maxRunes int, suffix string) string {
+	runes := []rune(s)
+	if len(runes) <= maxRunes {
+		return s
+	}
+	return string(runes[:maxRunes]) + suffix
+}
@@ -0,0 +1,23 @@
+package book
+
+import "testing"
+
+func TestTruncateDoesNotSplitMultiByteRunes(t *testing.T) {
+	s := "¿Qué tipo de código escribe un caballero? Siempre limpio, señor."
+
+	truncated := Truncate(s, 10)
+	runes := []rune(truncated)
+	if len(runes) != 13 { // 10 kept runes + "..."
+		t.Fatalf("expected 13 runes (10 + ellipsis), got %d: %q", len(runes), truncated)
+	}
+	if got := string(runes[:10]); got != "¿Qué tipo " {
+		t.Fatalf("truncation cut a multi-byte rune in half: %q", got)
+	}
+}
+
+func TestTruncateLeavesShortStringUntouched(t *testing.T) {
+	s := "ñandú"
+	if got := Truncate(s, 100); got != s {
+		t.Fatalf("expected %q unchanged, got %q", s, got)
+	}
+}
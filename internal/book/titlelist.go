@@ -0,0 +1,167 @@
+package book
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// sectionsFromOffsets converts precomputed heading offsets into the
+// Section shape frontmatter's titleList uses, in heading order.
+func sectionsFromOffsets(offsets []sectionOffset) []Section {
+	sections := make([]Section, 0, len(offsets))
+	for _, off := range offsets {
+		sections = append(sections, Section{Name: off.Name, TagID: off.TagID})
+	}
+	return sections
+}
+
+// RegenerateTitleList scans chapterID's headings and returns what its
+// titleList should be, using the same tagId algorithm GetSection and the
+// site use. It doesn't touch the file — callers decide whether to just show
+// the result or write it back (see WriteTitleList).
+func (p *Parser) RegenerateTitleList(chapterID, locale string) ([]Section, error) {
+	chapter, err := p.GetChapter(chapterID, locale)
+	if err != nil {
+		return nil, err
+	}
+	return sectionsFromOffsets(chapter.sectionOffsets), nil
+}
+
+// TitleListDrift compares chapterID's stored frontmatter titleList against
+// what RegenerateTitleList computes from its actual headings, so the
+// validation tool can flag chapters whose tagIds no longer match the
+// website's anchors (e.g. after GenerateTagID's slug algorithm changes)
+// without requiring a write to find out.
+func (p *Parser) TitleListDrift(chapterID, locale string) (fresh []Section, drifted bool, err error) {
+	chapter, err := p.GetChapter(chapterID, locale)
+	if err != nil {
+		return nil, false, err
+	}
+	fresh = sectionsFromOffsets(chapter.sectionOffsets)
+	return fresh, !sectionsEqual(chapter.TitleList, fresh), nil
+}
+
+func sectionsEqual(a, b []Section) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// renderTitleList serializes sections into the JS-object-literal style the
+// book's MDX frontmatter already uses for titleList arrays.
+func renderTitleList(sections []Section) string {
+	parts := make([]string, 0, len(sections))
+	for _, s := range sections {
+		parts = append(parts, fmt.Sprintf("{ name: %q, tagId: %q }", s.Name, s.TagID))
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
+
+// replaceTitleListField swaps the value of a frontmatter block's titleList:
+// [...] entry for a freshly rendered array, leaving every other field (and
+// the array's own bracket-matching rules, mirroring parseFrontmatterFields)
+// untouched.
+func replaceTitleListField(fmContent string, sections []Section) (string, error) {
+	titleListStart := strings.Index(fmContent, "titleList:")
+	if titleListStart == -1 {
+		return "", fmt.Errorf("frontmatter has no titleList field")
+	}
+	arrayStart := strings.Index(fmContent[titleListStart:], "[")
+	if arrayStart == -1 {
+		return "", fmt.Errorf("titleList field has no array")
+	}
+
+	bracketCount := 0
+	arrayEnd := -1
+	startPos := titleListStart + arrayStart
+	for i := startPos; i < len(fmContent); i++ {
+		if fmContent[i] == '[' {
+			bracketCount++
+		} else if fmContent[i] == ']' {
+			bracketCount--
+			if bracketCount == 0 {
+				arrayEnd = i + 1
+				break
+			}
+		}
+	}
+	if arrayEnd == -1 {
+		return "", fmt.Errorf("titleList array is not closed")
+	}
+
+	return fmContent[:startPos] + renderTitleList(sections) + fmContent[arrayEnd:], nil
+}
+
+// WriteTitleList regenerates chapterID's titleList from its current headings
+// and writes it back to disk atomically (temp file + rename, so a concurrent
+// reader never sees a half-written chapter), leaving id/order/name/
+// prerequisites and the body untouched. It also clears any cached sections
+// for this chapter/locale, since the file on disk just changed underneath
+// them.
+func (p *Parser) WriteTitleList(chapterID, locale string) ([]Section, error) {
+	chapter, err := p.GetChapter(chapterID, locale)
+	if err != nil {
+		return nil, err
+	}
+	sections := sectionsFromOffsets(chapter.sectionOffsets)
+
+	raw, err := os.ReadFile(chapter.FilePath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading file %s: %w", chapter.FilePath, err)
+	}
+	contentStr := strings.ReplaceAll(string(raw), "\r\n", "\n")
+
+	fmContent, body, err := p.splitFrontmatter(contentStr)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing frontmatter in %s: %w", chapter.FilePath, err)
+	}
+
+	newFmContent, err := replaceTitleListField(fmContent, sections)
+	if err != nil {
+		return nil, fmt.Errorf("error updating titleList in %s: %w", chapter.FilePath, err)
+	}
+
+	newFile := "---" + newFmContent + "---\n\n" + body + "\n"
+	if err := atomicWriteFile(chapter.FilePath, []byte(newFile)); err != nil {
+		return nil, err
+	}
+
+	for _, off := range chapter.sectionOffsets {
+		p.sectionCache.Delete(sectionCacheKey(chapterID, off.TagID, locale))
+	}
+
+	return sections, nil
+}
+
+// atomicWriteFile writes data to path by first writing a temp file in the
+// same directory, then renaming it into place, so a reader never observes a
+// partially written chapter file and a crash mid-write can't corrupt it.
+func atomicWriteFile(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, ".tmp-*"+filepath.Ext(path))
+	if err != nil {
+		return fmt.Errorf("error creating temp file for %s: %w", path, err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath) // no-op once the rename below succeeds
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error writing temp file for %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error closing temp file for %s: %w", path, err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("error renaming temp file into %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package book
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestRegenerateTitleListMatchesHeadings verifies that the regenerated
+// titleList reflects the chapter's actual headings and tagIds, regardless
+// of what the frontmatter's stale titleList says.
+func TestRegenerateTitleListMatchesHeadings(t *testing.T) {
+	dir := t.TempDir()
+	localeDir := filepath.Join(dir, "en")
+	if err := os.Mkdir(localeDir, 0o755); err != nil {
+		t.Fatalf("failed to create locale dir: %v", err)
+	}
+	writeSearchFixture(t, localeDir, "ch1", "Chapter One", 1, "# Intro\nHello.\n\n## Deep Dive\nMore.")
+
+	p := NewParser(dir)
+	sections, err := p.RegenerateTitleList("ch1", "en")
+	if err != nil {
+		t.Fatalf("RegenerateTitleList returned error: %v", err)
+	}
+
+	want := []Section{{Name: "Intro", TagID: "intro"}, {Name: "Deep Dive", TagID: "deep-dive"}}
+	if len(sections) != len(want) {
+		t.Fatalf("expected %d sections, got %+v", len(want), sections)
+	}
+	for i := range want {
+		if sections[i] != want[i] {
+			t.Fatalf("section %d: expected %+v, got %+v", i, want[i], sections[i])
+		}
+	}
+}
+
+// TestWriteTitleListPersistsToDisk verifies that WriteTitleList rewrites
+// the frontmatter's titleList on disk while leaving id/order/name/body
+// untouched, and that a fresh parse reflects the new titleList.
+func TestWriteTitleListPersistsToDisk(t *testing.T) {
+	dir := t.TempDir()
+	localeDir := filepath.Join(dir, "en")
+	if err := os.Mkdir(localeDir, 0o755); err != nil {
+		t.Fatalf("failed to create locale dir: %v", err)
+	}
+	writeSearchFixture(t, localeDir, "ch1", "Chapter One", 3, "# Intro\nHello.\n\n## Deep Dive\nMore.")
+
+	p := NewParser(dir)
+	sections, err := p.WriteTitleList("ch1", "en")
+	if err != nil {
+		t.Fatalf("WriteTitleList returned error: %v", err)
+	}
+	if len(sections) != 2 {
+		t.Fatalf("expected 2 sections, got %+v", sections)
+	}
+
+	reparsed := NewParser(dir)
+	chapter, err := reparsed.GetChapter("ch1", "en")
+	if err != nil {
+		t.Fatalf("GetChapter after write returned error: %v", err)
+	}
+	if chapter.Order != 3 {
+		t.Fatalf("expected order to stay 3, got %d", chapter.Order)
+	}
+	if chapter.Name != "Chapter One" {
+		t.Fatalf("expected name to stay Chapter One, got %q", chapter.Name)
+	}
+	if len(chapter.TitleList) != 2 || chapter.TitleList[1].TagID != "deep-dive" {
+		t.Fatalf("expected titleList to be regenerated, got %+v", chapter.TitleList)
+	}
+}
+
+// TestWriteChapterOrderPersistsToDisk verifies that WriteChapterOrder
+// updates only the order field.
+func TestWriteChapterOrderPersistsToDisk(t *testing.T) {
+	dir := t.TempDir()
+	localeDir := filepath.Join(dir, "en")
+	if err := os.Mkdir(localeDir, 0o755); err != nil {
+		t.Fatalf("failed to create locale dir: %v", err)
+	}
+	writeSearchFixture(t, localeDir, "ch1", "Chapter One", 1, "# Intro\nHello.")
+
+	p := NewParser(dir)
+	if err := p.WriteChapterOrder("ch1", "en", 9); err != nil {
+		t.Fatalf("WriteChapterOrder returned error: %v", err)
+	}
+
+	reparsed := NewParser(dir)
+	chapter, err := reparsed.GetChapter("ch1", "en")
+	if err != nil {
+		t.Fatalf("GetChapter after write returned error: %v", err)
+	}
+	if chapter.Order != 9 {
+		t.Fatalf("expected order 9, got %d", chapter.Order)
+	}
+	if chapter.Name != "Chapter One" {
+		t.Fatalf("expected name to stay Chapter One, got %q", chapter.Name)
+	}
+}
@@ -0,0 +1,29 @@
+package book
+
+// avgCharsPerToken approximates how many characters make up one token of
+// English/Spanish prose. It's not a real tokenizer, but it's fast and
+// consistent, which is what matters for an agent budgeting context before
+// requesting full chapters.
+const avgCharsPerToken = 4
+
+// EstimateTokens approximates the token count of s using a fixed
+// chars-per-token ratio. The server's chapter chunker (chapterContentBlocks)
+// uses the same ratio to size content blocks, so the two stay consistent.
+func EstimateTokens(s string) int {
+	if s == "" {
+		return 0
+	}
+	tokens := len(s) / avgCharsPerToken
+	if tokens == 0 {
+		tokens = 1
+	}
+	return tokens
+}
+
+// CharsForTokens converts a token budget back into an approximate character
+// count using the same ratio as EstimateTokens, so callers that size things
+// in characters (e.g. the semantic indexer's chunk splitter) can still be
+// configured in tokens.
+func CharsForTokens(tokens int) int {
+	return tokens * avgCharsPerToken
+}
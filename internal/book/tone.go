@@ -0,0 +1,54 @@
+package book
+
+import "strings"
+
+// ToneLabel classifies the intent of a line of book content, so search
+// results from the soft-skills/agile chapters can be filtered to, say,
+// "only the actionable checklists about retrospectives".
+type ToneLabel string
+
+const (
+	ToneAdvice    ToneLabel = "advice"
+	ToneAnecdote  ToneLabel = "anecdote"
+	ToneWarning   ToneLabel = "warning"
+	ToneChecklist ToneLabel = "checklist"
+)
+
+// toneKeywords are simple substring cues (English and Spanish) for each
+// label. This is a heuristic, not NLP, but it's fast enough to run on every
+// search hit instead of needing a separate offline analysis pass.
+var toneKeywords = map[ToneLabel][]string{
+	ToneChecklist: {"- [ ]", "- [x]", "checklist", "lista de verificación"},
+	ToneWarning:   {"warning:", "cuidado", "atención:", "nunca ", "never ", "evita ", "avoid "},
+	ToneAnecdote:  {"i remember", "recuerdo que", "once, ", "una vez", "years ago", "hace años", "when i worked", "cuando trabajaba"},
+	ToneAdvice:    {"you should", "deberías", "i recommend", "te recomiendo", "my advice", "mi consejo", "try to ", "intenta "},
+}
+
+// ClassifyTone returns the tone labels that match line.
+func ClassifyTone(line string) []ToneLabel {
+	lower := strings.ToLower(line)
+
+	var labels []ToneLabel
+	for _, label := range []ToneLabel{ToneChecklist, ToneWarning, ToneAnecdote, ToneAdvice} {
+		for _, kw := range toneKeywords[label] {
+			if strings.Contains(lower, kw) {
+				labels = append(labels, label)
+				break
+			}
+		}
+	}
+	return labels
+}
+
+// ToneLabelStrings converts labels to plain strings for embedding in a
+// SearchResult's JSON.
+func ToneLabelStrings(labels []ToneLabel) []string {
+	if len(labels) == 0 {
+		return nil
+	}
+	out := make([]string, len(labels))
+	for i, l := range labels {
+		out[i] = string(l)
+	}
+	return out
+}
@@ -0,0 +1,94 @@
+package book
+
+import "strings"
+
+// ClaimVerdict is the outcome of checking a claim against the book's
+// content. It is necessarily a heuristic, not a semantic proof: "supported"
+// means strong keyword evidence was found with no negation mismatch,
+// "contradicted" means the best evidence negates the claim (or vice versa),
+// and "not_found" means the book doesn't say enough about it either way.
+type ClaimVerdict string
+
+const (
+	VerdictSupported    ClaimVerdict = "supported"
+	VerdictContradicted ClaimVerdict = "contradicted"
+	VerdictNotFound     ClaimVerdict = "not_found"
+)
+
+// claimSupportThreshold is the minimum top-result relevance (fraction of
+// query words matched) required to call a claim "supported" rather than
+// "not_found".
+const claimSupportThreshold = 0.6
+
+// negationWords are used to flag candidate contradictions: if the claim and
+// its best evidence disagree on negation, the evidence likely says the
+// opposite of the claim.
+var negationWords = map[string]bool{
+	"not": true, "never": true, "no": true, "cannot": true, "can't": true,
+	"don't": true, "doesn't": true, "isn't": true, "won't": true, "shouldn't": true,
+	"nunca": true, "jamás": true, "tampoco": true,
+}
+
+func hasNegation(words []string) bool {
+	for _, w := range words {
+		if negationWords[strings.Trim(w, ".,!?;:")] {
+			return true
+		}
+	}
+	return false
+}
+
+// ClaimVerification is the result of verifying a claim against the book,
+// so clients can double-check a generated answer against its source.
+type ClaimVerification struct {
+	SchemaVersion int            `json:"schemaVersion"`
+	Claim         string         `json:"claim"`
+	Verdict       ClaimVerdict   `json:"verdict"`
+	Evidence      []SearchResult `json:"evidence"`
+}
+
+// maxClaimEvidence caps how many supporting excerpts are returned alongside
+// the verdict.
+const maxClaimEvidence = 5
+
+// VerifyClaim retrieves the passages most relevant to claim and returns a
+// supported/contradicted/not_found verdict with evidence excerpts. The
+// verdict is a keyword-overlap heuristic, not a semantic judgement — callers
+// should still read the evidence themselves before trusting it.
+func (p *Parser) VerifyClaim(claim, locale string) (*ClaimVerification, error) {
+	results, err := p.SearchExplain(claim, locale, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(results) > maxClaimEvidence {
+		results = results[:maxClaimEvidence]
+	}
+
+	verification := &ClaimVerification{
+		SchemaVersion: SchemaVersion,
+		Claim:         claim,
+		Verdict:       VerdictNotFound,
+		Evidence:      results,
+	}
+
+	if len(results) == 0 {
+		return verification, nil
+	}
+
+	top := results[0]
+	if top.Relevance < claimSupportThreshold {
+		return verification, nil
+	}
+
+	claimNegated := hasNegation(strings.Fields(strings.ToLower(claim)))
+	evidenceNegated := hasNegation(strings.Fields(strings.ToLower(top.Snippet)))
+
+	if claimNegated != evidenceNegated {
+		verification.Verdict = VerdictContradicted
+	} else {
+		verification.Verdict = VerdictSupported
+	}
+
+	return verification, nil
+}
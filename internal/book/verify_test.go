@@ -0,0 +1,71 @@
+package book
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeVerifyFixture(t *testing.T, dir string) {
+	t.Helper()
+	localeDir := filepath.Join(dir, "en")
+	if err := os.Mkdir(localeDir, 0o755); err != nil {
+		t.Fatalf("failed to create locale dir: %v", err)
+	}
+
+	content := "---\n" +
+		`id: "verify-chapter"` + "\n" +
+		"order: 1\n" +
+		`name: "Verify Chapter"` + "\n" +
+		"titleList: []\n" +
+		"---\n" +
+		"# Intro\n" +
+		"A code review should never skip the tests.\n"
+
+	path := filepath.Join(localeDir, "verify-chapter.mdx")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+}
+
+func TestVerifyClaimSupported(t *testing.T) {
+	dir := t.TempDir()
+	writeVerifyFixture(t, dir)
+	p := NewParser(dir)
+
+	v, err := p.VerifyClaim("a code review should never skip the tests", "en")
+	if err != nil {
+		t.Fatalf("VerifyClaim returned error: %v", err)
+	}
+	if v.Verdict != VerdictSupported {
+		t.Errorf("expected verdict %q, got %q", VerdictSupported, v.Verdict)
+	}
+}
+
+func TestVerifyClaimContradicted(t *testing.T) {
+	dir := t.TempDir()
+	writeVerifyFixture(t, dir)
+	p := NewParser(dir)
+
+	v, err := p.VerifyClaim("a code review should always skip the tests", "en")
+	if err != nil {
+		t.Fatalf("VerifyClaim returned error: %v", err)
+	}
+	if v.Verdict != VerdictContradicted {
+		t.Errorf("expected verdict %q, got %q", VerdictContradicted, v.Verdict)
+	}
+}
+
+func TestVerifyClaimNotFound(t *testing.T) {
+	dir := t.TempDir()
+	writeVerifyFixture(t, dir)
+	p := NewParser(dir)
+
+	v, err := p.VerifyClaim("rocket engines require liquid oxygen", "en")
+	if err != nil {
+		t.Fatalf("VerifyClaim returned error: %v", err)
+	}
+	if v.Verdict != VerdictNotFound {
+		t.Errorf("expected verdict %q, got %q", VerdictNotFound, v.Verdict)
+	}
+}
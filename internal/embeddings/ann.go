@@ -0,0 +1,183 @@
+package embeddings
+
+import (
+	"math/rand"
+	"os"
+	"sort"
+	"sync"
+)
+
+// annIndexEnvVar selects the approximate-nearest-neighbor index, same
+// direct-env-var convention as LOW_MEMORY_PROFILE/OFFLINE_MODE. "hnsw" is
+// the only recognized value today; anything else (including unset) keeps
+// VectorStore's brute-force scan.
+const annIndexEnvVar = "ANN_INDEX"
+
+func annIndexEnabled() bool {
+	return os.Getenv(annIndexEnvVar) == "hnsw"
+}
+
+// annMaxNeighbors (M) is how many neighbors each node keeps in the graph.
+const annMaxNeighbors = 16
+
+// annSearchWidth (ef) is how many candidates a greedy walk gathers before
+// the caller truncates to what it actually asked for -- high enough that
+// semantic_search's maxTopK of 100 is comfortably covered.
+const annSearchWidth = 256
+
+// annNumEntryPoints is how many random nodes a walk starts from. More
+// entry points trade a little extra work for better recall on a graph
+// that hasn't fully converged yet (small shards, recent inserts).
+const annNumEntryPoints = 4
+
+// annCandidate is one node visited during a graph walk, with its distance
+// to whatever's being searched for (lower is closer).
+type annCandidate struct {
+	id   int
+	dist float64
+}
+
+// annGraph is a simplified single-layer navigable-small-world graph -- the
+// base layer of HNSW, without its hierarchical upper layers -- used as an
+// approximate nearest-neighbor index so a locale shard's Search stays
+// roughly flat-latency as chunk count grows, instead of paying VectorStore's
+// O(n) brute-force scan per query. Nodes are indices into the owning
+// localeShard's chunks/vectors32 slices, inserted in the same order chunks
+// are appended.
+type annGraph struct {
+	mu        sync.RWMutex
+	neighbors [][]int // node -> up to annMaxNeighbors nearest known neighbor node IDs
+}
+
+func newANNGraph() *annGraph {
+	return &annGraph{}
+}
+
+// insert adds a new node (assumed to be the next sequential node ID, i.e.
+// len(neighbors) before this call) to the graph: it's connected to its
+// annMaxNeighbors nearest already-present neighbors, found via a greedy
+// walk, and each of those neighbors' own lists is updated symmetrically
+// (pruned back down to annMaxNeighbors by distance if one overflows). dist
+// computes the distance between any two existing node IDs (the new node's
+// vector must already be in place before insert is called, since dist
+// needs to be able to look it up).
+func (g *annGraph) insert(dist func(a, b int) float64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	node := len(g.neighbors)
+	g.neighbors = append(g.neighbors, nil)
+	if node == 0 {
+		return
+	}
+
+	distFromNode := func(other int) float64 { return dist(node, other) }
+	candidates := g.greedySearchLocked(distFromNode, annSearchWidth)
+	if len(candidates) > annMaxNeighbors {
+		candidates = candidates[:annMaxNeighbors]
+	}
+
+	for _, c := range candidates {
+		g.neighbors[node] = append(g.neighbors[node], c.id)
+		g.neighbors[c.id] = g.pruneLocked(append(g.neighbors[c.id], node), c.id, dist)
+	}
+}
+
+// pruneLocked keeps only owner's annMaxNeighbors closest entries in
+// neighbors, dropping the rest.
+func (g *annGraph) pruneLocked(neighbors []int, owner int, dist func(a, b int) float64) []int {
+	if len(neighbors) <= annMaxNeighbors {
+		return neighbors
+	}
+	sort.Slice(neighbors, func(i, j int) bool { return dist(owner, neighbors[i]) < dist(owner, neighbors[j]) })
+	return neighbors[:annMaxNeighbors]
+}
+
+// Query returns up to topK approximate nearest nodes to distTo, ranked by
+// distance ascending. The graph is walked greedily from a handful of
+// random entry points (see annNumEntryPoints/annSearchWidth), then the
+// nearest candidates found by exact distance are returned -- recall is
+// approximate (a walk can miss a true nearest neighbor that's poorly
+// connected), but distances reported for whatever is returned are exact.
+func (g *annGraph) Query(distTo func(other int) float64, topK int) []annCandidate {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	candidates := g.greedySearchLocked(distTo, annSearchWidth)
+	if len(candidates) > topK {
+		candidates = candidates[:topK]
+	}
+	return candidates
+}
+
+// greedySearchLocked walks the graph from annNumEntryPoints random entry
+// points, at each step moving to the unvisited neighbor closest to distTo
+// until no neighbor improves on the current node, collecting every node
+// visited along the way. Callers must hold g.mu (read or write).
+func (g *annGraph) greedySearchLocked(distTo func(other int) float64, width int) []annCandidate {
+	total := len(g.neighbors)
+	if total == 0 {
+		return nil
+	}
+
+	visited := make(map[int]bool)
+	var candidates []annCandidate
+
+	for _, entry := range annEntryPoints(total, annNumEntryPoints) {
+		current := entry
+		for {
+			if visited[current] {
+				break
+			}
+			visited[current] = true
+			candidates = append(candidates, annCandidate{id: current, dist: distTo(current)})
+
+			best, bestDist, improved := current, distTo(current), false
+			for _, n := range g.neighbors[current] {
+				if visited[n] {
+					continue
+				}
+				d := distTo(n)
+				visited[n] = true
+				candidates = append(candidates, annCandidate{id: n, dist: d})
+				if d < bestDist {
+					best, bestDist, improved = n, d, true
+				}
+			}
+			if !improved {
+				break
+			}
+			current = best
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].dist < candidates[j].dist })
+	if len(candidates) > width {
+		candidates = candidates[:width]
+	}
+	return candidates
+}
+
+// annEntryPoints picks up to n distinct random node IDs out of total (or
+// every node, if there are fewer than n).
+func annEntryPoints(total, n int) []int {
+	if total <= n {
+		points := make([]int, total)
+		for i := range points {
+			points[i] = i
+		}
+		return points
+	}
+
+	seen := make(map[int]bool, n)
+	points := make([]int, 0, n)
+	for len(points) < n {
+		p := rand.Intn(total)
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+		points = append(points, p)
+	}
+	return points
+}
@@ -0,0 +1,70 @@
+package embeddings
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestVectorStoreUsesANNIndexWhenEnabled(t *testing.T) {
+	t.Setenv(annIndexEnvVar, "hnsw")
+
+	store := NewVectorStore()
+	for i := 0; i < 40; i++ {
+		// Spread points around the unit circle so there's a clear nearest
+		// neighbor for any query angle.
+		angle := float64(i) / 40
+		store.Add(Chunk{
+			ID:        fmt.Sprintf("c%d", i),
+			ChapterID: fmt.Sprintf("ch%d", i),
+			Locale:    "en",
+			Embedding: []float64{angle, 1 - angle},
+		})
+	}
+
+	shard := store.shards["en"]
+	if shard.ann == nil {
+		t.Fatal("expected ANN_INDEX=hnsw to build a graph for the shard")
+	}
+
+	results := store.Search([]float64{0.5, 0.5}, "en", 3)
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+	for i := 1; i < len(results); i++ {
+		if results[i].Score > results[i-1].Score {
+			t.Fatalf("expected results sorted by score descending, got %v then %v", results[i-1].Score, results[i].Score)
+		}
+	}
+}
+
+func TestVectorStoreSkipsANNIndexByDefault(t *testing.T) {
+	store := NewVectorStore()
+	store.Add(Chunk{ID: "a", ChapterID: "ch1", Locale: "en", Embedding: []float64{1, 0}})
+
+	if store.shards["en"].ann != nil {
+		t.Fatal("expected no ANN graph without ANN_INDEX=hnsw")
+	}
+}
+
+func TestVectorStoreRemoveChapterRebuildsANNIndex(t *testing.T) {
+	t.Setenv(annIndexEnvVar, "hnsw")
+
+	store := NewVectorStore()
+	store.AddBatch([]Chunk{
+		{ID: "a", ChapterID: "ch1", Locale: "en", Embedding: []float64{1, 0}},
+		{ID: "b", ChapterID: "ch2", Locale: "en", Embedding: []float64{0, 1}},
+		{ID: "c", ChapterID: "ch1", Locale: "en", Embedding: []float64{0.9, 0.1}},
+	})
+
+	store.RemoveChapter("ch1", "en")
+
+	shard := store.shards["en"]
+	if len(shard.ann.neighbors) != len(shard.chunks) {
+		t.Fatalf("expected ANN graph to have %d nodes after removal, got %d", len(shard.chunks), len(shard.ann.neighbors))
+	}
+
+	results := store.Search([]float64{0, 1}, "en", 5)
+	if len(results) != 1 || results[0].ChapterID != "ch2" {
+		t.Fatalf("expected only ch2's chunk to remain searchable, got %+v", results)
+	}
+}
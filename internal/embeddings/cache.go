@@ -0,0 +1,158 @@
+package embeddings
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ResultCache is the interface QueryCache implements, so a shared external
+// cache (e.g. Redis) can stand in for it across replicas without touching
+// SemanticEngine's call sites.
+type ResultCache interface {
+	Key(provider, model, query, locale string, topK int) string
+	Get(key string) ([]SemanticResult, bool)
+	Set(key string, results []SemanticResult)
+	Clear()
+	Size() int
+}
+
+// redisCacheAddr returns REDIS_ADDR if CACHE_BACKEND=redis is usable, or ""
+// if it's missing (in which case the caller should log and fall back).
+func redisCacheAddr() string {
+	return os.Getenv("REDIS_ADDR")
+}
+
+// NewResultCache creates the query-result cache to use, honoring
+// CACHE_BACKEND ("memory" by default). "redis" talks to a real Redis
+// instance at REDIS_ADDR (optionally authenticating with REDIS_PASSWORD),
+// sharing cached results across replicas instead of each keeping its own;
+// it's disabled under OFFLINE_MODE like every other network-reaching
+// backend in this package, falling back to the in-memory QueryCache.
+func NewResultCache(maxSize int, ttl time.Duration) ResultCache {
+	switch os.Getenv("CACHE_BACKEND") {
+	case "", "memory":
+	case "redis":
+		if offlineModeEnabled() {
+			log.Printf("OFFLINE_MODE is set; CACHE_BACKEND=redis requires network access and is disabled. Falling back to the in-memory query cache.")
+			break
+		}
+		if addr := redisCacheAddr(); addr != "" {
+			return NewRedisResultCache(addr, os.Getenv("REDIS_PASSWORD"), ttl)
+		}
+		log.Printf("CACHE_BACKEND=redis requires REDIS_ADDR; falling back to the in-memory query cache.")
+	default:
+		log.Printf("Unknown CACHE_BACKEND %q; falling back to the in-memory query cache.", os.Getenv("CACHE_BACKEND"))
+	}
+	return NewQueryCache(maxSize, ttl)
+}
+
+// queryCacheEntry holds a cached value along with its expiration time.
+type queryCacheEntry struct {
+	results   []SemanticResult
+	expiresAt time.Time
+}
+
+// QueryCache is a small LRU cache with TTL for semantic search results, keyed by
+// provider+model+query+filters so identical or near-identical queries issued in
+// agent loops don't pay a provider round trip.
+type QueryCache struct {
+	mu      sync.Mutex
+	entries map[string]*queryCacheEntry
+	order   []string // least-recently-used first
+	maxSize int
+	ttl     time.Duration
+}
+
+// NewQueryCache creates a query cache with the given capacity and TTL.
+func NewQueryCache(maxSize int, ttl time.Duration) *QueryCache {
+	if maxSize <= 0 {
+		maxSize = 100
+	}
+	return &QueryCache{
+		entries: make(map[string]*queryCacheEntry),
+		maxSize: maxSize,
+		ttl:     ttl,
+	}
+}
+
+// Key builds a cache key from the provider, model and search parameters.
+func (c *QueryCache) Key(provider, model, query, locale string, topK int) string {
+	return provider + "|" + model + "|" + locale + "|" + strconv.Itoa(topK) + "|" + query
+}
+
+// Get returns the cached results for key, if present and not expired.
+func (c *QueryCache) Get(key string) ([]SemanticResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(c.entries, key)
+		c.removeFromOrder(key)
+		return nil, false
+	}
+
+	c.touch(key)
+	return entry.results, true
+}
+
+// Set stores results under key, evicting the least-recently-used entry if the
+// cache is full.
+func (c *QueryCache) Set(key string, results []SemanticResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, exists := c.entries[key]; !exists && len(c.entries) >= c.maxSize {
+		c.evictOldest()
+	}
+
+	c.entries[key] = &queryCacheEntry{
+		results:   results,
+		expiresAt: time.Now().Add(c.ttl),
+	}
+	c.touch(key)
+}
+
+// Clear empties the cache.
+func (c *QueryCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]*queryCacheEntry)
+	c.order = nil
+}
+
+// Size returns the number of cached entries.
+func (c *QueryCache) Size() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+func (c *QueryCache) touch(key string) {
+	c.removeFromOrder(key)
+	c.order = append(c.order, key)
+}
+
+func (c *QueryCache) removeFromOrder(key string) {
+	for i, k := range c.order {
+		if k == key {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			return
+		}
+	}
+}
+
+func (c *QueryCache) evictOldest() {
+	if len(c.order) == 0 {
+		return
+	}
+	oldest := c.order[0]
+	c.order = c.order[1:]
+	delete(c.entries, oldest)
+}
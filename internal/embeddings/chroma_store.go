@@ -0,0 +1,387 @@
+package embeddings
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ChromaStore is a VectorBackend backed by a real Chroma server, so a team
+// already running Chroma for other RAG data can have the book index live in
+// the same place instead of a second, process-local store. It talks to
+// Chroma's v1 HTTP API directly rather than vendoring a client library.
+//
+// Collections are partitioned by book id + locale + embedding model (one
+// Chroma collection per locale), so several books, servers, or embedding
+// models can share the same Chroma cluster without their chunks colliding.
+type ChromaStore struct {
+	baseURL    string
+	bookID     string
+	model      string
+	httpClient *http.Client
+
+	mu sync.Mutex
+	// collectionIDs caches locale -> resolved Chroma collection ID, since
+	// Chroma addresses collections by ID, not by the human-readable name
+	// we derive them from.
+	collectionIDs map[string]string
+	// dimensions caches locale -> the embedding dimension first seen for
+	// that locale's collection in this process, as a lightweight schema
+	// check: a later batch with a different dimension (e.g. the embedding
+	// model changed) is logged instead of silently corrupting the index.
+	dimensions map[string]int
+}
+
+// NewChromaStore creates a ChromaStore talking to baseURL (CHROMA_URL,
+// defaulting to "http://localhost:8000"), partitioning collections under
+// bookID and model.
+func NewChromaStore(baseURL, bookID, model string) *ChromaStore {
+	if baseURL == "" {
+		baseURL = "http://localhost:8000"
+	}
+	return &ChromaStore{
+		baseURL:       baseURL,
+		bookID:        bookID,
+		model:         model,
+		httpClient:    newHTTPClientWithVCR("chroma", 30*time.Second),
+		collectionIDs: make(map[string]string),
+		dimensions:    make(map[string]int),
+	}
+}
+
+type chromaCollection struct {
+	ID string `json:"id"`
+}
+
+var chromaNameSanitizer = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// collectionName derives the Chroma collection name for locale (or "all"
+// for an unscoped, cross-locale collection), namespaced by book id and
+// embedding model.
+func (c *ChromaStore) collectionName(locale string) string {
+	if locale == "" {
+		locale = "all"
+	}
+	name := fmt.Sprintf("gentleman-book_%s_%s_%s", c.bookID, locale, c.model)
+	return chromaNameSanitizer.ReplaceAllString(name, "_")
+}
+
+// ensureCollection resolves (creating if necessary) the Chroma collection ID
+// for locale, caching it for subsequent calls.
+func (c *ChromaStore) ensureCollection(ctx context.Context, locale string) (string, error) {
+	c.mu.Lock()
+	if id, ok := c.collectionIDs[locale]; ok {
+		c.mu.Unlock()
+		return id, nil
+	}
+	c.mu.Unlock()
+
+	name := c.collectionName(locale)
+	body, _ := json.Marshal(map[string]any{
+		"name":          name,
+		"get_or_create": true,
+		"metadata": map[string]any{
+			"bookId": c.bookID,
+			"locale": locale,
+			"model":  c.model,
+		},
+	})
+	var col chromaCollection
+	if err := c.do(ctx, "POST", "/api/v1/collections", body, &col); err != nil {
+		return "", fmt.Errorf("resolving chroma collection %q: %w", name, err)
+	}
+
+	c.mu.Lock()
+	c.collectionIDs[locale] = col.ID
+	c.mu.Unlock()
+	return col.ID, nil
+}
+
+// checkDimension logs a warning the first time a locale's collection sees a
+// batch whose embedding dimension differs from one it already accepted in
+// this process -- a cheap proxy for a schema check without Chroma itself
+// enforcing one.
+func (c *ChromaStore) checkDimension(locale string, dim int) {
+	if dim == 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if existing, ok := c.dimensions[locale]; ok {
+		if existing != dim {
+			log.Printf("chroma: collection %q previously saw %d-dimensional embeddings, now seeing %d; is the embedding model consistent?",
+				c.collectionName(locale), existing, dim)
+		}
+		return
+	}
+	c.dimensions[locale] = dim
+}
+
+func (c *ChromaStore) do(ctx context.Context, method, path string, body []byte, out any) error {
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("chroma returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(respBody, out)
+}
+
+// AddBatch upserts chunks into their per-locale Chroma collection, using
+// each chunk's ID as the Chroma document ID and its chapter fields as
+// metadata. Chunks are grouped by locale first since each locale is a
+// separate collection.
+func (c *ChromaStore) AddBatch(chunks []Chunk) {
+	byLocale := make(map[string][]Chunk)
+	for _, chunk := range chunks {
+		byLocale[chunk.Locale] = append(byLocale[chunk.Locale], chunk)
+	}
+
+	ctx := context.Background()
+	for locale, localeChunks := range byLocale {
+		collectionID, err := c.ensureCollection(ctx, locale)
+		if err != nil {
+			continue
+		}
+		if len(localeChunks[0].Embedding) > 0 {
+			c.checkDimension(locale, len(localeChunks[0].Embedding))
+		}
+
+		ids := make([]string, len(localeChunks))
+		embeddings := make([][]float64, len(localeChunks))
+		documents := make([]string, len(localeChunks))
+		metadatas := make([]map[string]any, len(localeChunks))
+		for i, chunk := range localeChunks {
+			ids[i] = chunk.ID
+			embeddings[i] = chunk.Embedding
+			documents[i] = chunk.Content
+			metadatas[i] = map[string]any{
+				"chapterId":   chunk.ChapterID,
+				"chapterName": chunk.ChapterName,
+				"section":     chunk.Section,
+				"locale":      chunk.Locale,
+				"startLine":   chunk.StartLine,
+				"endLine":     chunk.EndLine,
+			}
+		}
+
+		body, _ := json.Marshal(map[string]any{
+			"ids":        ids,
+			"embeddings": embeddings,
+			"documents":  documents,
+			"metadatas":  metadatas,
+		})
+		_ = c.do(ctx, "POST", fmt.Sprintf("/api/v1/collections/%s/upsert", collectionID), body, nil)
+	}
+}
+
+// knownLocales returns every locale this process has resolved a collection
+// for, so a locale-less Count/Chunks/SearchExplain call can fan out across
+// all of them.
+func (c *ChromaStore) knownLocales() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	locales := make([]string, 0, len(c.collectionIDs))
+	for locale := range c.collectionIDs {
+		locales = append(locales, locale)
+	}
+	return locales
+}
+
+type chromaQueryResponse struct {
+	IDs       [][]string         `json:"ids"`
+	Documents [][]string         `json:"documents"`
+	Metadatas [][]map[string]any `json:"metadatas"`
+	Distances [][]float64        `json:"distances"`
+}
+
+// SearchExplain queries Chroma for the topK nearest chunks to
+// queryEmbedding. With a locale given, only that locale's collection is
+// queried; with no locale, every collection this process knows about is
+// queried and the results are merged and re-sorted. Chroma scores by
+// distance (lower is better); we convert to the same 0..1-ish
+// cosine-similarity convention VectorStore uses (1 - distance) so callers
+// can't tell which backend produced a result.
+func (c *ChromaStore) SearchExplain(queryEmbedding []float64, locale string, topK int, explain bool) []SemanticResult {
+	locales := []string{locale}
+	if locale == "" {
+		locales = c.knownLocales()
+	}
+
+	ctx := context.Background()
+	var results []SemanticResult
+	for _, lc := range locales {
+		collectionID, err := c.ensureCollection(ctx, lc)
+		if err != nil {
+			continue
+		}
+
+		query := map[string]any{
+			"query_embeddings": [][]float64{queryEmbedding},
+			"n_results":        topK,
+			"include":          []string{"documents", "metadatas", "distances"},
+		}
+		body, _ := json.Marshal(query)
+
+		var resp chromaQueryResponse
+		if err := c.do(ctx, "POST", fmt.Sprintf("/api/v1/collections/%s/query", collectionID), body, &resp); err != nil {
+			continue
+		}
+		if len(resp.IDs) == 0 {
+			continue
+		}
+
+		for i := range resp.IDs[0] {
+			meta := map[string]any{}
+			if len(resp.Metadatas) > 0 && i < len(resp.Metadatas[0]) {
+				meta = resp.Metadatas[0][i]
+			}
+			score := 1 - resp.Distances[0][i]
+
+			result := SemanticResult{
+				ChapterID:   stringField(meta, "chapterId"),
+				ChapterName: stringField(meta, "chapterName"),
+				Section:     stringField(meta, "section"),
+				Content:     resp.Documents[0][i],
+				Score:       score,
+				Locale:      stringField(meta, "locale"),
+				StartLine:   intField(meta, "startLine"),
+				EndLine:     intField(meta, "endLine"),
+			}
+			if explain {
+				result.Explain = &ScoreExplain{CosineScore: score}
+			}
+			results = append(results, result)
+		}
+	}
+
+	if len(locales) > 1 {
+		sort.Slice(results, func(i, j int) bool { return results[i].Score > results[j].Score })
+		if topK > 0 && len(results) > topK {
+			results = results[:topK]
+		}
+	}
+	return results
+}
+
+// Count returns the number of chunks stored across every collection this
+// process knows about (one per locale).
+func (c *ChromaStore) Count() int {
+	ctx := context.Background()
+	total := 0
+	for _, locale := range c.knownLocales() {
+		collectionID, err := c.ensureCollection(ctx, locale)
+		if err != nil {
+			continue
+		}
+		var count int
+		if err := c.do(ctx, "GET", fmt.Sprintf("/api/v1/collections/%s/count", collectionID), nil, &count); err == nil {
+			total += count
+		}
+	}
+	return total
+}
+
+// Chunks fetches every chunk stored across every collection this process
+// knows about (one per locale), for read-only diagnostic/export use
+// (index_quality_report, export_index).
+func (c *ChromaStore) Chunks() []Chunk {
+	ctx := context.Background()
+	var all []Chunk
+	for _, locale := range c.knownLocales() {
+		collectionID, err := c.ensureCollection(ctx, locale)
+		if err != nil {
+			continue
+		}
+
+		body, _ := json.Marshal(map[string]any{
+			"include": []string{"documents", "metadatas", "embeddings"},
+		})
+		var resp struct {
+			IDs        []string         `json:"ids"`
+			Documents  []string         `json:"documents"`
+			Metadatas  []map[string]any `json:"metadatas"`
+			Embeddings [][]float64      `json:"embeddings"`
+		}
+		if err := c.do(ctx, "POST", fmt.Sprintf("/api/v1/collections/%s/get", collectionID), body, &resp); err != nil {
+			continue
+		}
+
+		for i, id := range resp.IDs {
+			meta := resp.Metadatas[i]
+			chunk := Chunk{
+				ID:          id,
+				ChapterID:   stringField(meta, "chapterId"),
+				ChapterName: stringField(meta, "chapterName"),
+				Section:     stringField(meta, "section"),
+				Content:     resp.Documents[i],
+				Locale:      stringField(meta, "locale"),
+				StartLine:   intField(meta, "startLine"),
+				EndLine:     intField(meta, "endLine"),
+			}
+			if i < len(resp.Embeddings) {
+				chunk.Embedding = resp.Embeddings[i]
+			}
+			all = append(all, chunk)
+		}
+	}
+	return all
+}
+
+// RemoveChapter deletes every document tagged with chapterId==chapterID from
+// locale's collection, via Chroma's delete-by-where endpoint.
+func (c *ChromaStore) RemoveChapter(chapterID, locale string) {
+	ctx := context.Background()
+	collectionID, err := c.ensureCollection(ctx, locale)
+	if err != nil {
+		return
+	}
+
+	body, _ := json.Marshal(map[string]any{
+		"where": map[string]any{"chapterId": chapterID},
+	})
+	_ = c.do(ctx, "POST", fmt.Sprintf("/api/v1/collections/%s/delete", collectionID), body, nil)
+}
+
+func stringField(m map[string]any, key string) string {
+	if v, ok := m[key].(string); ok {
+		return v
+	}
+	return ""
+}
+
+func intField(m map[string]any, key string) int {
+	if v, ok := m[key].(float64); ok {
+		return int(v)
+	}
+	return 0
+}
@@ -0,0 +1,99 @@
+package embeddings
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestChromaStorePartitionsCollectionsByLocale(t *testing.T) {
+	var createdNames []string
+	type collection struct {
+		ids        []string
+		embeddings [][]float64
+		documents  []string
+		metadatas  []map[string]any
+	}
+	collections := make(map[string]*collection) // collection ID -> data
+	nameToID := make(map[string]string)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/api/v1/collections":
+			var req struct {
+				Name string `json:"name"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			id, ok := nameToID[req.Name]
+			if !ok {
+				id = req.Name + "-id"
+				nameToID[req.Name] = id
+				collections[id] = &collection{}
+				createdNames = append(createdNames, req.Name)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]string{"id": id})
+		case r.Method == "POST" && strings.HasSuffix(r.URL.Path, "/upsert"):
+			id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/v1/collections/"), "/upsert")
+			var body struct {
+				IDs        []string         `json:"ids"`
+				Embeddings [][]float64      `json:"embeddings"`
+				Documents  []string         `json:"documents"`
+				Metadatas  []map[string]any `json:"metadatas"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			col := collections[id]
+			col.ids = append(col.ids, body.IDs...)
+			col.embeddings = append(col.embeddings, body.Embeddings...)
+			col.documents = append(col.documents, body.Documents...)
+			col.metadatas = append(col.metadatas, body.Metadatas...)
+			w.WriteHeader(http.StatusOK)
+		case r.Method == "GET" && strings.HasSuffix(r.URL.Path, "/count"):
+			id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/v1/collections/"), "/count")
+			_ = json.NewEncoder(w).Encode(len(collections[id].ids))
+		case r.Method == "POST" && strings.HasSuffix(r.URL.Path, "/query"):
+			id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/v1/collections/"), "/query")
+			col := collections[id]
+			resp := chromaQueryResponse{
+				IDs:       [][]string{col.ids},
+				Documents: [][]string{col.documents},
+				Metadatas: [][]map[string]any{col.metadatas},
+				Distances: [][]float64{make([]float64, len(col.ids))},
+			}
+			_ = json.NewEncoder(w).Encode(resp)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	store := NewChromaStore(server.URL, "book-a", "fake")
+	store.AddBatch([]Chunk{
+		{ID: "c1", ChapterID: "ch1", Locale: "en", Content: "hello", Embedding: []float64{0.1, 0.2}},
+		{ID: "c2", ChapterID: "ch1", Locale: "es", Content: "hola", Embedding: []float64{0.3, 0.4}},
+	})
+
+	if len(createdNames) != 2 {
+		t.Fatalf("expected 2 collections created (one per locale), got %v", createdNames)
+	}
+	for _, name := range createdNames {
+		if !strings.Contains(name, "book-a") || !strings.Contains(name, "fake") {
+			t.Errorf("collection name %q does not look namespaced by book id + model", name)
+		}
+	}
+
+	if count := store.Count(); count != 2 {
+		t.Fatalf("expected Count() == 2 across both locale collections, got %d", count)
+	}
+
+	enResults := store.SearchExplain([]float64{0.1, 0.2}, "en", 5, false)
+	if len(enResults) != 1 || enResults[0].Locale != "en" {
+		t.Fatalf("expected 1 result scoped to locale en, got %+v", enResults)
+	}
+
+	all := store.SearchExplain([]float64{0.1, 0.2}, "", 5, false)
+	if len(all) != 2 {
+		t.Fatalf("expected 2 results when searching across all known locales, got %d", len(all))
+	}
+}
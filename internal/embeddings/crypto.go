@@ -0,0 +1,71 @@
+package embeddings
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+)
+
+// loadEncryptionKey decodes a base64-encoded AES-128/192/256 key from the
+// environment variable envVar. An unset or empty variable returns a nil key
+// (the caller should treat that as "encryption disabled"), not an error.
+func loadEncryptionKey(envVar string) ([]byte, error) {
+	encoded := os.Getenv(envVar)
+	if encoded == "" {
+		return nil, nil
+	}
+
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not valid base64: %w", envVar, err)
+	}
+	switch len(key) {
+	case 16, 24, 32:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("%s must decode to a 16, 24, or 32-byte AES key, got %d bytes", envVar, len(key))
+	}
+}
+
+// encryptBytes encrypts plaintext with AES-GCM under key, prepending the
+// random nonce to the returned ciphertext so decryptBytes doesn't need it
+// passed separately.
+func encryptBytes(plaintext, key []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// decryptBytes reverses encryptBytes.
+func decryptBytes(ciphertext, key []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, fmt.Errorf("ciphertext is shorter than the AES-GCM nonce")
+	}
+	nonce, sealed := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, sealed, nil)
+}
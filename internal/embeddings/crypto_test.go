@@ -0,0 +1,62 @@
+package embeddings
+
+import (
+	"encoding/base64"
+	"testing"
+)
+
+func TestEncryptDecryptBytesRoundTrips(t *testing.T) {
+	key := make([]byte, 32)
+	for i := range key {
+		key[i] = byte(i)
+	}
+
+	plaintext := []byte(`{"id":"c1","content":"a true gentleman encrypts his index"}`)
+	ciphertext, err := encryptBytes(plaintext, key)
+	if err != nil {
+		t.Fatalf("encryptBytes failed: %v", err)
+	}
+	if string(ciphertext) == string(plaintext) {
+		t.Fatal("ciphertext should not equal plaintext")
+	}
+
+	decrypted, err := decryptBytes(ciphertext, key)
+	if err != nil {
+		t.Fatalf("decryptBytes failed: %v", err)
+	}
+	if string(decrypted) != string(plaintext) {
+		t.Fatalf("decrypted data does not match original: %q", decrypted)
+	}
+}
+
+func TestDecryptBytesRejectsTamperedCiphertext(t *testing.T) {
+	key := make([]byte, 32)
+	ciphertext, err := encryptBytes([]byte("hello"), key)
+	if err != nil {
+		t.Fatalf("encryptBytes failed: %v", err)
+	}
+	ciphertext[len(ciphertext)-1] ^= 0xFF
+
+	if _, err := decryptBytes(ciphertext, key); err == nil {
+		t.Fatal("expected tampered ciphertext to fail to decrypt")
+	}
+}
+
+func TestLoadEncryptionKeyValidatesLength(t *testing.T) {
+	t.Setenv("TEST_ENCRYPTION_KEY", "")
+	key, err := loadEncryptionKey("TEST_ENCRYPTION_KEY")
+	if err != nil || key != nil {
+		t.Fatalf("expected a nil key and no error for an unset env var, got key=%v err=%v", key, err)
+	}
+
+	t.Setenv("TEST_ENCRYPTION_KEY", base64.StdEncoding.EncodeToString(make([]byte, 32)))
+	key, err = loadEncryptionKey("TEST_ENCRYPTION_KEY")
+	if err != nil || len(key) != 32 {
+		t.Fatalf("expected a 32-byte key, got key=%v err=%v", key, err)
+	}
+
+	t.Setenv("TEST_ENCRYPTION_KEY", base64.StdEncoding.EncodeToString(make([]byte, 7)))
+	if _, err := loadEncryptionKey("TEST_ENCRYPTION_KEY"); err == nil {
+		t.Fatal("expected an error for a key of invalid length")
+	}
+}
@@ -0,0 +1,157 @@
+package embeddings
+
+import (
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EmbeddingCacheBackend is the interface EmbeddingCache implements, so a
+// shared external cache (e.g. Redis) can stand in for it across replicas,
+// the same role ResultCache plays for search results.
+type EmbeddingCacheBackend interface {
+	Key(provider, model, query string) string
+	Get(key string) ([]float64, bool)
+	Set(key string, vector []float64)
+	Size() int
+}
+
+// NewEmbeddingCacheBackend creates the query-embedding cache to use,
+// honoring CACHE_BACKEND the same way NewResultCache does: "redis" talks to
+// REDIS_ADDR (disabled under OFFLINE_MODE), anything else keeps the
+// in-memory EmbeddingCache.
+func NewEmbeddingCacheBackend(maxSize int, ttl time.Duration) EmbeddingCacheBackend {
+	switch os.Getenv("CACHE_BACKEND") {
+	case "", "memory":
+	case "redis":
+		if offlineModeEnabled() {
+			log.Printf("OFFLINE_MODE is set; CACHE_BACKEND=redis requires network access and is disabled. Falling back to the in-memory embedding cache.")
+			break
+		}
+		if addr := redisCacheAddr(); addr != "" {
+			return NewRedisEmbeddingCache(addr, os.Getenv("REDIS_PASSWORD"), ttl)
+		}
+		log.Printf("CACHE_BACKEND=redis requires REDIS_ADDR; falling back to the in-memory embedding cache.")
+	default:
+		log.Printf("Unknown CACHE_BACKEND %q; falling back to the in-memory embedding cache.", os.Getenv("CACHE_BACKEND"))
+	}
+	return NewEmbeddingCache(maxSize, ttl)
+}
+
+// normalizeQuery lowercases, trims, and collapses internal whitespace so
+// near-identical queries from agent retries ("Search for TDD", " search for
+// tdd ") share the same embedding cache entry.
+func normalizeQuery(query string) string {
+	return strings.Join(strings.Fields(strings.ToLower(query)), " ")
+}
+
+// defaultEmbeddingCacheTTL is how long a cached query embedding stays warm.
+const defaultEmbeddingCacheTTL = 10 * time.Minute
+
+// defaultEmbeddingCacheSize is the maximum number of distinct normalized
+// queries kept warm.
+const defaultEmbeddingCacheSize = 200
+
+// embeddingCacheEntry holds a cached embedding along with its expiration time.
+type embeddingCacheEntry struct {
+	vector    []float64
+	expiresAt time.Time
+}
+
+// EmbeddingCache is a small LRU cache with TTL for query embeddings, keyed
+// by provider+model+normalized query. It's separate from SemanticEngine's
+// result cache: an embedding only depends on the query text and the
+// embedding model, not on locale/topK/explain, so it's reusable across
+// searches that a result-cache hit would otherwise treat as distinct.
+type EmbeddingCache struct {
+	mu      sync.Mutex
+	entries map[string]*embeddingCacheEntry
+	order   []string // least-recently-used first
+	maxSize int
+	ttl     time.Duration
+}
+
+// NewEmbeddingCache creates an embedding cache with the given capacity and TTL.
+func NewEmbeddingCache(maxSize int, ttl time.Duration) *EmbeddingCache {
+	if maxSize <= 0 {
+		maxSize = 100
+	}
+	return &EmbeddingCache{
+		entries: make(map[string]*embeddingCacheEntry),
+		maxSize: maxSize,
+		ttl:     ttl,
+	}
+}
+
+// Key builds a cache key from the provider, model and normalized query.
+func (c *EmbeddingCache) Key(provider, model, query string) string {
+	return provider + "|" + model + "|" + normalizeQuery(query)
+}
+
+// Get returns the cached embedding for key, if present and not expired.
+func (c *EmbeddingCache) Get(key string) ([]float64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(c.entries, key)
+		c.removeFromOrder(key)
+		return nil, false
+	}
+
+	c.touch(key)
+	return entry.vector, true
+}
+
+// Set stores vector under key, evicting the least-recently-used entry if the
+// cache is full.
+func (c *EmbeddingCache) Set(key string, vector []float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, exists := c.entries[key]; !exists && len(c.entries) >= c.maxSize {
+		c.evictOldest()
+	}
+
+	c.entries[key] = &embeddingCacheEntry{
+		vector:    vector,
+		expiresAt: time.Now().Add(c.ttl),
+	}
+	c.touch(key)
+}
+
+// Size returns the number of cached entries.
+func (c *EmbeddingCache) Size() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+func (c *EmbeddingCache) touch(key string) {
+	c.removeFromOrder(key)
+	c.order = append(c.order, key)
+}
+
+func (c *EmbeddingCache) removeFromOrder(key string) {
+	for i, k := range c.order {
+		if k == key {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			return
+		}
+	}
+}
+
+func (c *EmbeddingCache) evictOldest() {
+	if len(c.order) == 0 {
+		return
+	}
+	oldest := c.order[0]
+	c.order = c.order[1:]
+	delete(c.entries, oldest)
+}
@@ -0,0 +1,48 @@
+package embeddings
+
+import (
+	"context"
+	"testing"
+)
+
+// TestSearchExplainReusesEmbeddingForNormalizedQuery verifies that two
+// queries differing only by case and whitespace share a cached embedding,
+// even when explain=true bypasses the result cache.
+func TestSearchExplainReusesEmbeddingForNormalizedQuery(t *testing.T) {
+	engine, err := NewSemanticEngine(ProviderFake)
+	if err != nil {
+		t.Fatalf("NewSemanticEngine failed: %v", err)
+	}
+
+	chunks := []Chunk{
+		{ID: "c1", ChapterID: "ch1", Locale: "en", Content: "A true gentleman ships working code."},
+	}
+	if err := engine.IndexChunks(context.Background(), chunks); err != nil {
+		t.Fatalf("IndexChunks failed: %v", err)
+	}
+
+	if _, err := engine.SearchExplain(context.Background(), "Search for TDD", "en", 1, true); err != nil {
+		t.Fatalf("first SearchExplain failed: %v", err)
+	}
+	if got := engine.CachedEmbeddingCount(); got != 1 {
+		t.Fatalf("expected 1 cached embedding, got %d", got)
+	}
+
+	if _, err := engine.SearchExplain(context.Background(), " search for tdd ", "en", 1, true); err != nil {
+		t.Fatalf("second SearchExplain failed: %v", err)
+	}
+	if got := engine.CachedEmbeddingCount(); got != 1 {
+		t.Fatalf("expected normalization-equivalent query to reuse the cached embedding, got %d entries", got)
+	}
+}
+
+// TestEmbeddingCacheKeyIgnoresWhitespaceAndCase verifies the cache key
+// itself normalizes before hashing.
+func TestEmbeddingCacheKeyIgnoresWhitespaceAndCase(t *testing.T) {
+	cache := NewEmbeddingCache(defaultEmbeddingCacheSize, defaultEmbeddingCacheTTL)
+	a := cache.Key("fake", "", "Search for TDD")
+	b := cache.Key("fake", "", " search   for   tdd ")
+	if a != b {
+		t.Fatalf("expected normalized keys to match, got %q vs %q", a, b)
+	}
+}
@@ -0,0 +1,147 @@
+package embeddings
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ExportFormat identifies how ExportChunks serializes the index.
+type ExportFormat string
+
+const (
+	ExportFormatJSONL      ExportFormat = "jsonl"
+	ExportFormatCSV        ExportFormat = "csv"
+	ExportFormatLlamaIndex ExportFormat = "llamaindex"
+
+	// ExportFormatParquet is recognized but not implemented yet: Parquet is
+	// a binary columnar format and would require vendoring a Parquet writer
+	// we don't currently depend on. Callers asking for it fall back to
+	// JSONL instead of failing outright.
+	ExportFormatParquet ExportFormat = "parquet"
+)
+
+// exportRecord is one exported chunk in the JSONL export.
+type exportRecord struct {
+	ID          string    `json:"id"`
+	ChapterID   string    `json:"chapterId"`
+	ChapterName string    `json:"chapterName"`
+	Section     string    `json:"section"`
+	Content     string    `json:"content"`
+	Locale      string    `json:"locale"`
+	StartLine   int       `json:"startLine"`
+	EndLine     int       `json:"endLine"`
+	Embedding   []float64 `json:"embedding"`
+}
+
+// ExportChunks serializes every indexed chunk (optionally filtered to one
+// locale) in the requested format, so the index can be loaded into an
+// external RAG stack instead of only being queryable through this server.
+func (e *SemanticEngine) ExportChunks(locale string, format ExportFormat) (string, error) {
+	chunks := e.store.Chunks()
+	if locale != "" {
+		filtered := make([]Chunk, 0, len(chunks))
+		for _, c := range chunks {
+			if c.Locale == locale {
+				filtered = append(filtered, c)
+			}
+		}
+		chunks = filtered
+	}
+
+	switch format {
+	case ExportFormatCSV:
+		return exportCSV(chunks)
+	case ExportFormatLlamaIndex:
+		return exportLlamaIndex(chunks)
+	case ExportFormatParquet, ExportFormatJSONL, "":
+		return exportJSONL(chunks)
+	default:
+		return "", fmt.Errorf("unknown export format: %s", format)
+	}
+}
+
+func exportJSONL(chunks []Chunk) (string, error) {
+	var b strings.Builder
+	for _, c := range chunks {
+		line, err := json.Marshal(exportRecord{
+			ID:          c.ID,
+			ChapterID:   c.ChapterID,
+			ChapterName: c.ChapterName,
+			Section:     c.Section,
+			Content:     c.Content,
+			Locale:      c.Locale,
+			StartLine:   c.StartLine,
+			EndLine:     c.EndLine,
+			Embedding:   c.Embedding,
+		})
+		if err != nil {
+			return "", err
+		}
+		b.Write(line)
+		b.WriteByte('\n')
+	}
+	return b.String(), nil
+}
+
+func exportCSV(chunks []Chunk) (string, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	if err := w.Write([]string{"id", "chapterId", "chapterName", "section", "locale", "startLine", "endLine", "content", "embedding"}); err != nil {
+		return "", err
+	}
+	for _, c := range chunks {
+		embeddingParts := make([]string, len(c.Embedding))
+		for i, v := range c.Embedding {
+			embeddingParts[i] = strconv.FormatFloat(v, 'f', -1, 64)
+		}
+		row := []string{
+			c.ID, c.ChapterID, c.ChapterName, c.Section, c.Locale,
+			strconv.Itoa(c.StartLine), strconv.Itoa(c.EndLine),
+			c.Content, strings.Join(embeddingParts, ";"),
+		}
+		if err := w.Write(row); err != nil {
+			return "", err
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// llamaIndexNode mirrors the shape LlamaIndex's TextNode/JSONReader expect:
+// text plus metadata plus an optional precomputed embedding.
+type llamaIndexNode struct {
+	Text      string                 `json:"text"`
+	Metadata  map[string]interface{} `json:"metadata"`
+	Embedding []float64              `json:"embedding,omitempty"`
+}
+
+func exportLlamaIndex(chunks []Chunk) (string, error) {
+	nodes := make([]llamaIndexNode, 0, len(chunks))
+	for _, c := range chunks {
+		nodes = append(nodes, llamaIndexNode{
+			Text: c.Content,
+			Metadata: map[string]interface{}{
+				"chunkId":     c.ID,
+				"chapterId":   c.ChapterID,
+				"chapterName": c.ChapterName,
+				"section":     c.Section,
+				"locale":      c.Locale,
+				"startLine":   c.StartLine,
+				"endLine":     c.EndLine,
+			},
+			Embedding: c.Embedding,
+		})
+	}
+
+	out, err := json.MarshalIndent(nodes, "", "  ")
+	return string(out), err
+}
@@ -0,0 +1,81 @@
+package embeddings
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func newIndexedEngineForExport(t *testing.T) *SemanticEngine {
+	engine, err := NewSemanticEngine(ProviderFake)
+	if err != nil {
+		t.Fatalf("NewSemanticEngine failed: %v", err)
+	}
+	chunks := []Chunk{
+		{ID: "c1", ChapterID: "ch1", ChapterName: "Chapter One", Locale: "en", Content: "A true gentleman ships working code."},
+		{ID: "c2", ChapterID: "ch1", ChapterName: "Chapter One", Locale: "es", Content: "Un verdadero caballero revisa su código."},
+	}
+	if err := engine.IndexChunks(context.Background(), chunks); err != nil {
+		t.Fatalf("IndexChunks failed: %v", err)
+	}
+	return engine
+}
+
+func TestExportChunksJSONL(t *testing.T) {
+	engine := newIndexedEngineForExport(t)
+
+	out, err := engine.ExportChunks("en", ExportFormatJSONL)
+	if err != nil {
+		t.Fatalf("ExportChunks failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 exported line for locale en, got %d", len(lines))
+	}
+
+	var rec exportRecord
+	if err := json.Unmarshal([]byte(lines[0]), &rec); err != nil {
+		t.Fatalf("exported line is not valid JSON: %v", err)
+	}
+	if rec.ID != "c1" || len(rec.Embedding) == 0 {
+		t.Fatalf("unexpected exported record: %+v", rec)
+	}
+}
+
+func TestExportChunksCSV(t *testing.T) {
+	engine := newIndexedEngineForExport(t)
+
+	out, err := engine.ExportChunks("", ExportFormatCSV)
+	if err != nil {
+		t.Fatalf("ExportChunks failed: %v", err)
+	}
+
+	records, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	if err != nil {
+		t.Fatalf("exported CSV did not parse: %v", err)
+	}
+	if len(records) != 3 { // header + 2 chunks
+		t.Fatalf("expected 3 CSV rows (header + 2 chunks), got %d", len(records))
+	}
+}
+
+func TestExportChunksParquetFallsBackToJSONL(t *testing.T) {
+	engine := newIndexedEngineForExport(t)
+
+	out, err := engine.ExportChunks("", ExportFormatParquet)
+	if err != nil {
+		t.Fatalf("ExportChunks failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 exported lines, got %d", len(lines))
+	}
+	var rec exportRecord
+	if err := json.Unmarshal([]byte(lines[0]), &rec); err != nil {
+		t.Fatalf("parquet fallback did not produce JSONL: %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+package embeddings
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"math"
+)
+
+// fakeEmbeddingDims is the vector length produced by FakeClient. It doesn't
+// need to match any real provider's dimensionality since fake vectors are
+// never compared against real ones.
+const fakeEmbeddingDims = 32
+
+// FakeClient is a deterministic EmbeddingClient with no network dependency:
+// each text hashes to the same vector every time, so integration tests,
+// demos, and downstream-client CI can exercise the full semantic search path
+// (indexing, similarity ranking, caching) without an API key or Ollama.
+type FakeClient struct{}
+
+// NewFakeClient creates a deterministic, hash-based embedding client.
+func NewFakeClient() *FakeClient {
+	return &FakeClient{}
+}
+
+// Embed hashes text into a fixed-length unit vector. Same text in, same
+// vector out, every time, in every process.
+func (c *FakeClient) Embed(ctx context.Context, text string) ([]float64, error) {
+	sum := sha256.Sum256([]byte(text))
+
+	vec := make([]float64, fakeEmbeddingDims)
+	for i := 0; i < fakeEmbeddingDims; i++ {
+		// Reuse the 32-byte digest cyclically, 4 bytes at a time, to fill
+		// vectors longer than the digest itself.
+		offset := (i * 4) % (len(sum) - 3)
+		bits := binary.BigEndian.Uint32(sum[offset : offset+4])
+		vec[i] = float64(bits)/float64(^uint32(0)) - 0.5
+	}
+
+	normalize(vec)
+	return vec, nil
+}
+
+// EmbedBatch embeds each text independently; there's no batching win to
+// fake since there's no real request to coalesce.
+func (c *FakeClient) EmbedBatch(ctx context.Context, texts []string) ([][]float64, error) {
+	out := make([][]float64, len(texts))
+	for i, text := range texts {
+		vec, _ := c.Embed(ctx, text)
+		out[i] = vec
+	}
+	return out, nil
+}
+
+// normalize scales vec to unit length in place, so fake vectors behave like
+// real embeddings under cosine similarity.
+func normalize(vec []float64) {
+	var sumSquares float64
+	for _, v := range vec {
+		sumSquares += v * v
+	}
+	if sumSquares == 0 {
+		return
+	}
+	mag := math.Sqrt(sumSquares)
+	for i := range vec {
+		vec[i] /= mag
+	}
+}
@@ -0,0 +1,47 @@
+package embeddings
+
+import (
+	"context"
+	"testing"
+)
+
+// TestFakeClientDeterministic verifies that the same text always embeds to
+// the same vector, and that different texts embed to different vectors.
+func TestFakeClientDeterministic(t *testing.T) {
+	c := NewFakeClient()
+	ctx := context.Background()
+
+	a1, err := c.Embed(ctx, "retrospectives should be blameless")
+	if err != nil {
+		t.Fatalf("Embed returned error: %v", err)
+	}
+	a2, err := c.Embed(ctx, "retrospectives should be blameless")
+	if err != nil {
+		t.Fatalf("Embed returned error: %v", err)
+	}
+	b, err := c.Embed(ctx, "pair programming builds shared context")
+	if err != nil {
+		t.Fatalf("Embed returned error: %v", err)
+	}
+
+	if len(a1) != fakeEmbeddingDims {
+		t.Errorf("expected %d dims, got %d", fakeEmbeddingDims, len(a1))
+	}
+
+	for i := range a1 {
+		if a1[i] != a2[i] {
+			t.Fatalf("same text produced different vectors at index %d: %v vs %v", i, a1[i], a2[i])
+		}
+	}
+
+	identical := true
+	for i := range a1 {
+		if a1[i] != b[i] {
+			identical = false
+			break
+		}
+	}
+	if identical {
+		t.Error("different texts produced identical vectors")
+	}
+}
@@ -0,0 +1,121 @@
+package embeddings
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ImportChunks parses data (in the same shape ExportChunks produces) and
+// loads it directly into the vector store, skipping re-embedding entirely.
+// This is how a pre-built index -- produced once in CI, say -- gets shared
+// across every developer's local server instead of each one re-embedding
+// the whole book on their own machine.
+func (e *SemanticEngine) ImportChunks(data string, format ExportFormat) (int, error) {
+	var chunks []Chunk
+	var err error
+
+	switch format {
+	case ExportFormatCSV:
+		chunks, err = parseCSVChunks(data)
+	case ExportFormatJSONL, ExportFormatLlamaIndex, ExportFormatParquet, "":
+		// llamaindex and parquet aren't implemented as import sources yet;
+		// fall back to JSONL parsing, which fails with a clear error for
+		// anything that isn't actually JSONL rather than silently
+		// misreading it.
+		chunks, err = parseJSONLChunks(data)
+	default:
+		return 0, fmt.Errorf("unknown import format: %s", format)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	e.indexMutex.Lock()
+	defer e.indexMutex.Unlock()
+	e.store.AddBatch(chunks)
+	e.isIndexed = true
+
+	return len(chunks), nil
+}
+
+func parseJSONLChunks(data string) ([]Chunk, error) {
+	var chunks []Chunk
+	for i, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		var rec exportRecord
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		chunks = append(chunks, Chunk{
+			ID:          rec.ID,
+			ChapterID:   rec.ChapterID,
+			ChapterName: rec.ChapterName,
+			Section:     rec.Section,
+			Content:     rec.Content,
+			Embedding:   rec.Embedding,
+			Locale:      rec.Locale,
+			StartLine:   rec.StartLine,
+			EndLine:     rec.EndLine,
+		})
+	}
+	if len(chunks) == 0 {
+		return nil, fmt.Errorf("no chunks found in import data")
+	}
+	return chunks, nil
+}
+
+func parseCSVChunks(data string) ([]Chunk, error) {
+	rows, err := csv.NewReader(strings.NewReader(data)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) < 2 {
+		return nil, fmt.Errorf("no chunks found in import data")
+	}
+
+	col := make(map[string]int, len(rows[0]))
+	for i, h := range rows[0] {
+		col[h] = i
+	}
+	for _, required := range []string{"id", "chapterId", "chapterName", "section", "locale", "startLine", "endLine", "content", "embedding"} {
+		if _, ok := col[required]; !ok {
+			return nil, fmt.Errorf("missing required column %q", required)
+		}
+	}
+
+	var chunks []Chunk
+	for _, row := range rows[1:] {
+		startLine, _ := strconv.Atoi(row[col["startLine"]])
+		endLine, _ := strconv.Atoi(row[col["endLine"]])
+
+		var embedding []float64
+		if raw := row[col["embedding"]]; raw != "" {
+			for _, part := range strings.Split(raw, ";") {
+				v, err := strconv.ParseFloat(part, 64)
+				if err != nil {
+					return nil, fmt.Errorf("invalid embedding value %q: %w", part, err)
+				}
+				embedding = append(embedding, v)
+			}
+		}
+
+		chunks = append(chunks, Chunk{
+			ID:          row[col["id"]],
+			ChapterID:   row[col["chapterId"]],
+			ChapterName: row[col["chapterName"]],
+			Section:     row[col["section"]],
+			Locale:      row[col["locale"]],
+			StartLine:   startLine,
+			EndLine:     endLine,
+			Content:     row[col["content"]],
+			Embedding:   embedding,
+		})
+	}
+	return chunks, nil
+}
@@ -0,0 +1,85 @@
+package embeddings
+
+import (
+	"context"
+	"testing"
+)
+
+func TestImportChunksRoundTripsThroughJSONL(t *testing.T) {
+	source, err := NewSemanticEngine(ProviderFake)
+	if err != nil {
+		t.Fatalf("NewSemanticEngine failed: %v", err)
+	}
+	if err := source.IndexChunks(context.Background(), []Chunk{
+		{ID: "c1", ChapterID: "ch1", ChapterName: "Chapter One", Locale: "en", Content: "A true gentleman ships working code."},
+	}); err != nil {
+		t.Fatalf("IndexChunks failed: %v", err)
+	}
+
+	exported, err := source.ExportChunks("", ExportFormatJSONL)
+	if err != nil {
+		t.Fatalf("ExportChunks failed: %v", err)
+	}
+
+	dest, err := NewSemanticEngine(ProviderFake)
+	if err != nil {
+		t.Fatalf("NewSemanticEngine failed: %v", err)
+	}
+	count, err := dest.ImportChunks(exported, ExportFormatJSONL)
+	if err != nil {
+		t.Fatalf("ImportChunks failed: %v", err)
+	}
+	if count != 1 {
+		t.Fatalf("expected 1 imported chunk, got %d", count)
+	}
+	if !dest.IsIndexed() {
+		t.Fatal("expected engine to be marked indexed after import")
+	}
+
+	results, err := dest.Search(context.Background(), "gentleman", "en", 1)
+	if err != nil {
+		t.Fatalf("Search after import failed: %v", err)
+	}
+	if len(results) != 1 || results[0].ChapterID != "ch1" {
+		t.Fatalf("unexpected search results after import: %+v", results)
+	}
+}
+
+func TestImportChunksRoundTripsThroughCSV(t *testing.T) {
+	source, err := NewSemanticEngine(ProviderFake)
+	if err != nil {
+		t.Fatalf("NewSemanticEngine failed: %v", err)
+	}
+	if err := source.IndexChunks(context.Background(), []Chunk{
+		{ID: "c1", ChapterID: "ch1", ChapterName: "Chapter One", Locale: "en", Content: "A true gentleman ships working code."},
+	}); err != nil {
+		t.Fatalf("IndexChunks failed: %v", err)
+	}
+
+	exported, err := source.ExportChunks("", ExportFormatCSV)
+	if err != nil {
+		t.Fatalf("ExportChunks failed: %v", err)
+	}
+
+	dest, err := NewSemanticEngine(ProviderFake)
+	if err != nil {
+		t.Fatalf("NewSemanticEngine failed: %v", err)
+	}
+	count, err := dest.ImportChunks(exported, ExportFormatCSV)
+	if err != nil {
+		t.Fatalf("ImportChunks failed: %v", err)
+	}
+	if count != 1 || dest.ChunkCount() != 1 {
+		t.Fatalf("expected 1 imported chunk, got count=%d chunkCount=%d", count, dest.ChunkCount())
+	}
+}
+
+func TestImportChunksRejectsEmptyInput(t *testing.T) {
+	engine, err := NewSemanticEngine(ProviderFake)
+	if err != nil {
+		t.Fatalf("NewSemanticEngine failed: %v", err)
+	}
+	if _, err := engine.ImportChunks("", ExportFormatJSONL); err == nil {
+		t.Fatal("expected an error importing empty data")
+	}
+}
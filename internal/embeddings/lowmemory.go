@@ -0,0 +1,23 @@
+package embeddings
+
+import "os"
+
+// lowMemoryProfileEnvVar selects the low-memory profile this package and
+// cmd/server both read directly (same convention as OFFLINE_MODE): no
+// threading a config struct around, just check the env var wherever a
+// memory/speed tradeoff is made. Aimed at small devices -- a Raspberry Pi
+// running Ollama alongside this server -- that can't spare the RAM a full
+// keyword index and float64 vector store want.
+const lowMemoryProfileEnvVar = "LOW_MEMORY_PROFILE"
+
+func lowMemoryModeEnabled() bool {
+	return os.Getenv(lowMemoryProfileEnvVar) == "true"
+}
+
+// LowMemoryModeEnabled reports whether the low-memory profile is active, for
+// other packages (cmd/server) that fold this into their own config
+// decisions -- e.g. skipping the keyword index, disabling adjacent-section
+// prefetch.
+func LowMemoryModeEnabled() bool {
+	return lowMemoryModeEnabled()
+}
@@ -0,0 +1,57 @@
+package embeddings
+
+import "testing"
+
+func TestVectorStoreQuantizesEmbeddingsUnderLowMemoryProfile(t *testing.T) {
+	t.Setenv(lowMemoryProfileEnvVar, "true")
+
+	store := NewVectorStore()
+	store.Add(Chunk{ID: "a", Locale: "en", Embedding: []float64{1, 0, 0}})
+
+	shard := store.shards["en"]
+	if shard.chunks[0].Embedding != nil {
+		t.Fatalf("expected quantized store to clear Chunk.Embedding, got %v", shard.chunks[0].Embedding)
+	}
+	if len(shard.vectors32) != 1 {
+		t.Fatalf("expected 1 quantized vector, got %d", len(shard.vectors32))
+	}
+
+	results := store.Search([]float64{1, 0, 0}, "en", 1)
+	if len(results) != 1 {
+		t.Fatalf("expected 1 search result, got %d", len(results))
+	}
+	if results[0].Score < 0.99 {
+		t.Errorf("expected near-identical vectors to score ~1, got %v", results[0].Score)
+	}
+}
+
+func TestVectorStoreKeepsFullPrecisionByDefault(t *testing.T) {
+	t.Setenv(lowMemoryProfileEnvVar, "")
+
+	store := NewVectorStore()
+	store.Add(Chunk{ID: "a", Locale: "en", Embedding: []float64{1, 0, 0}})
+
+	shard := store.shards["en"]
+	if shard.chunks[0].Embedding == nil {
+		t.Fatal("expected Chunk.Embedding to stay populated outside low-memory mode")
+	}
+	if len(shard.vectors32) != 0 {
+		t.Fatalf("expected no quantized vectors outside low-memory mode, got %d", len(shard.vectors32))
+	}
+}
+
+func TestNewSemanticEngineUsesSmallerCacheUnderLowMemoryProfile(t *testing.T) {
+	t.Setenv(lowMemoryProfileEnvVar, "true")
+
+	engine, err := NewSemanticEngine(ProviderFake)
+	if err != nil {
+		t.Fatalf("NewSemanticEngine returned error: %v", err)
+	}
+	cache, ok := engine.cache.(*QueryCache)
+	if !ok {
+		t.Fatalf("expected *QueryCache, got %T", engine.cache)
+	}
+	if cache.maxSize != lowMemoryQueryCacheSize {
+		t.Errorf("expected maxSize %d, got %d", lowMemoryQueryCacheSize, cache.maxSize)
+	}
+}
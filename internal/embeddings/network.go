@@ -0,0 +1,66 @@
+package embeddings
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// offlineModeEnabled reports whether OFFLINE_MODE is set, guaranteeing no
+// outbound network calls for air-gapped deployments. Every code path in
+// this package that reaches the network (OpenAI, a non-localhost Ollama,
+// Chroma/REST vector stores, pre-built index downloads) checks this and
+// fails loudly rather than silently trying and timing out.
+func offlineModeEnabled() bool {
+	return os.Getenv("OFFLINE_MODE") == "true"
+}
+
+// OfflineModeEnabled is the exported form of offlineModeEnabled, for other
+// packages (e.g. cmd/server's webhook publisher) that need to honor the
+// same air-gapped guarantee without duplicating the env var check.
+func OfflineModeEnabled() bool {
+	return offlineModeEnabled()
+}
+
+// errOffline is returned (wrapped with context) whenever a network-bound
+// feature is invoked under OFFLINE_MODE.
+func errOffline(feature string) error {
+	return fmt.Errorf("OFFLINE_MODE is set; %s requires network access and is disabled", feature)
+}
+
+// isLocalhost reports whether rawURL's host is loopback, the only outbound
+// destination OFFLINE_MODE still permits (a local Ollama daemon).
+func isLocalhost(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	host := u.Hostname()
+	return host == "localhost" || host == "127.0.0.1" || host == "::1"
+}
+
+// enforceOfflineOllamaHost restricts baseURL to localhost under
+// OFFLINE_MODE, overriding any other configured host rather than letting a
+// misconfigured OLLAMA_BASE_URL punch a hole in the offline guarantee.
+func enforceOfflineOllamaHost(baseURL string) string {
+	if !offlineModeEnabled() || isLocalhost(baseURL) {
+		return baseURL
+	}
+	return "http://localhost:11434"
+}
+
+// OfflineModeDescription is a one-line summary of what OFFLINE_MODE
+// restricts, for server_info/setup_status output. Returns "" when
+// OFFLINE_MODE is not set.
+func OfflineModeDescription() string {
+	if !offlineModeEnabled() {
+		return ""
+	}
+	return strings.Join([]string{
+		"OpenAI embeddings disabled",
+		"Ollama restricted to localhost",
+		"Chroma/REST vector stores disabled",
+		"pre-built index downloads disabled",
+	}, "; ")
+}
@@ -0,0 +1,51 @@
+package embeddings
+
+import "testing"
+
+func TestEnforceOfflineOllamaHostRestrictsToLocalhost(t *testing.T) {
+	t.Setenv("OFFLINE_MODE", "true")
+
+	if got := enforceOfflineOllamaHost("http://ollama.internal:11434"); got != "http://localhost:11434" {
+		t.Fatalf("expected remote host to be restricted to localhost, got %q", got)
+	}
+	if got := enforceOfflineOllamaHost("http://127.0.0.1:11434"); got != "http://127.0.0.1:11434" {
+		t.Fatalf("expected loopback host to pass through unchanged, got %q", got)
+	}
+}
+
+func TestEnforceOfflineOllamaHostNoopWhenDisabled(t *testing.T) {
+	t.Setenv("OFFLINE_MODE", "")
+
+	if got := enforceOfflineOllamaHost("http://ollama.internal:11434"); got != "http://ollama.internal:11434" {
+		t.Fatalf("expected host to pass through unchanged when offline mode is off, got %q", got)
+	}
+}
+
+func TestNewSemanticEngineRejectsOpenAIWhenOffline(t *testing.T) {
+	t.Setenv("OFFLINE_MODE", "true")
+	t.Setenv("OPENAI_API_KEY", "sk-test")
+
+	if _, err := NewSemanticEngine(ProviderOpenAI); err == nil {
+		t.Fatal("expected NewSemanticEngine(ProviderOpenAI) to fail under OFFLINE_MODE")
+	}
+}
+
+func TestNewSemanticEngineRejectsCloudProvidersWhenOffline(t *testing.T) {
+	t.Setenv("OFFLINE_MODE", "true")
+	t.Setenv("COHERE_API_KEY", "test")
+	t.Setenv("VOYAGE_API_KEY", "test")
+	t.Setenv("GEMINI_API_KEY", "test")
+
+	for _, provider := range []Provider{ProviderCohere, ProviderVoyage, ProviderGemini} {
+		if _, err := NewSemanticEngine(provider); err == nil {
+			t.Fatalf("expected NewSemanticEngine(%s) to fail under OFFLINE_MODE", provider)
+		}
+	}
+}
+
+func TestOfflineModeDescriptionEmptyWhenDisabled(t *testing.T) {
+	t.Setenv("OFFLINE_MODE", "")
+	if desc := OfflineModeDescription(); desc != "" {
+		t.Fatalf("expected empty description when offline mode is off, got %q", desc)
+	}
+}
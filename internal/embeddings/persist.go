@@ -0,0 +1,68 @@
+package embeddings
+
+import (
+	"fmt"
+	"os"
+)
+
+// indexEncryptionKeyEnvVar holds the base64-encoded AES key used to encrypt
+// a persisted index file at rest. Unset means "store in plaintext" -- this
+// server has no KMS integration, so operators who need envelope encryption
+// or key rotation are expected to inject the key through their own
+// secrets manager into this env var rather than a built-in KMS client.
+//
+// Note: the embedding query result cache and any notes/progress tracking in
+// this server are process-local and in-memory only -- there is nothing on
+// disk for either of them to encrypt. Only the semantic index, which can be
+// persisted via SaveToFile below, touches disk.
+const indexEncryptionKeyEnvVar = "INDEX_ENCRYPTION_KEY"
+
+// SaveToFile persists the engine's indexed chunks (in the JSONL shape
+// ExportChunks produces) to path. If INDEX_ENCRYPTION_KEY is set, the file
+// is encrypted with AES-GCM; otherwise it's written in plaintext, which is
+// fine for a public handbook but not for a private one.
+func (e *SemanticEngine) SaveToFile(path string) error {
+	data, err := e.ExportChunks("", ExportFormatJSONL)
+	if err != nil {
+		return err
+	}
+
+	key, err := loadEncryptionKey(indexEncryptionKeyEnvVar)
+	if err != nil {
+		return err
+	}
+
+	payload := []byte(data)
+	if key != nil {
+		payload, err = encryptBytes(payload, key)
+		if err != nil {
+			return fmt.Errorf("encrypting index for %s: %w", path, err)
+		}
+	}
+
+	return os.WriteFile(path, payload, 0o600)
+}
+
+// LoadFromFile loads an index previously written by SaveToFile, decrypting
+// it first if INDEX_ENCRYPTION_KEY is set. A file written in plaintext is
+// loaded unchanged; a file written while the key was set can only be read
+// back with the same key.
+func (e *SemanticEngine) LoadFromFile(path string) (int, error) {
+	payload, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+
+	key, err := loadEncryptionKey(indexEncryptionKeyEnvVar)
+	if err != nil {
+		return 0, err
+	}
+	if key != nil {
+		payload, err = decryptBytes(payload, key)
+		if err != nil {
+			return 0, fmt.Errorf("decrypting index from %s: %w", path, err)
+		}
+	}
+
+	return e.ImportChunks(string(payload), ExportFormatJSONL)
+}
@@ -0,0 +1,81 @@
+package embeddings
+
+import (
+	"context"
+	"encoding/base64"
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveAndLoadFromFileRoundTripsPlaintext(t *testing.T) {
+	source, err := NewSemanticEngine(ProviderFake)
+	if err != nil {
+		t.Fatalf("NewSemanticEngine failed: %v", err)
+	}
+	if err := source.IndexChunks(context.Background(), []Chunk{
+		{ID: "c1", ChapterID: "ch1", Locale: "en", Content: "A true gentleman persists his index."},
+	}); err != nil {
+		t.Fatalf("IndexChunks failed: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "index.jsonl")
+	if err := source.SaveToFile(path); err != nil {
+		t.Fatalf("SaveToFile failed: %v", err)
+	}
+
+	dest, err := NewSemanticEngine(ProviderFake)
+	if err != nil {
+		t.Fatalf("NewSemanticEngine failed: %v", err)
+	}
+	count, err := dest.LoadFromFile(path)
+	if err != nil {
+		t.Fatalf("LoadFromFile failed: %v", err)
+	}
+	if count != 1 || dest.ChunkCount() != 1 {
+		t.Fatalf("expected 1 loaded chunk, got count=%d chunkCount=%d", count, dest.ChunkCount())
+	}
+}
+
+func TestSaveAndLoadFromFileRoundTripsEncrypted(t *testing.T) {
+	key := make([]byte, 32)
+	for i := range key {
+		key[i] = byte(i * 3)
+	}
+	t.Setenv("INDEX_ENCRYPTION_KEY", base64.StdEncoding.EncodeToString(key))
+
+	source, err := NewSemanticEngine(ProviderFake)
+	if err != nil {
+		t.Fatalf("NewSemanticEngine failed: %v", err)
+	}
+	if err := source.IndexChunks(context.Background(), []Chunk{
+		{ID: "c1", ChapterID: "ch1", Locale: "en", Content: "A private handbook's index should not be world-readable."},
+	}); err != nil {
+		t.Fatalf("IndexChunks failed: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "index.enc")
+	if err := source.SaveToFile(path); err != nil {
+		t.Fatalf("SaveToFile failed: %v", err)
+	}
+
+	dest, err := NewSemanticEngine(ProviderFake)
+	if err != nil {
+		t.Fatalf("NewSemanticEngine failed: %v", err)
+	}
+	count, err := dest.LoadFromFile(path)
+	if err != nil {
+		t.Fatalf("LoadFromFile failed: %v", err)
+	}
+	if count != 1 {
+		t.Fatalf("expected 1 loaded chunk, got %d", count)
+	}
+
+	t.Setenv("INDEX_ENCRYPTION_KEY", "")
+	wrongKeyEngine, err := NewSemanticEngine(ProviderFake)
+	if err != nil {
+		t.Fatalf("NewSemanticEngine failed: %v", err)
+	}
+	if _, err := wrongKeyEngine.LoadFromFile(path); err == nil {
+		t.Fatal("expected loading an encrypted index without the key to fail")
+	}
+}
@@ -0,0 +1,291 @@
+package embeddings
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// pgConn is a minimal PostgreSQL wire-protocol (v3) connection, just enough
+// to run simple queries against a pgvector-enabled database: no prepared
+// statements, no binary result format, and -- like redisClient's RESP
+// client -- no external driver dependency. Only "trust" and cleartext
+// password authentication are supported; PostgreSQL's default since v10,
+// SCRAM-SHA-256, is not, so PGVECTOR_DSN connections need
+// `password_encryption = md5` (or trust auth) in pg_hba.conf. TLS is not
+// negotiated either, so this is meant for a database reachable over a
+// private network, not the open internet.
+type pgConn struct {
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+// pgRow is one row of a query result, column values in the order requested.
+// Every value comes back as text (pgvector's vector type included), which
+// is all pgVectorStore needs: it only ever re-parses floats/ints it wrote
+// itself.
+type pgRow []string
+
+func connectPG(addr, user, password, database string, timeout time.Duration) (*pgConn, error) {
+	conn, err := net.DialTimeout("tcp", addr, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("dialing postgres at %s: %w", addr, err)
+	}
+	c := &pgConn{conn: conn, r: bufio.NewReader(conn)}
+
+	if err := c.startup(user, database); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if err := c.authenticate(user, password); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if err := c.waitForReady(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *pgConn) close() {
+	c.writeMessage('X', nil)
+	c.conn.Close()
+}
+
+// startup sends the unframed StartupMessage: int32 length, protocol version
+// 3.0, then null-terminated key/value pairs, then a final zero byte. Unlike
+// every other frontend message it carries no leading type byte.
+func (c *pgConn) startup(user, database string) error {
+	var body []byte
+	body = append(body, 0, 3, 0, 0) // protocol version 3.0
+	body = appendCString(body, "user")
+	body = appendCString(body, user)
+	body = appendCString(body, "database")
+	body = appendCString(body, database)
+	body = appendCString(body, "client_encoding")
+	body = appendCString(body, "UTF8")
+	body = append(body, 0)
+
+	var header [4]byte
+	binary.BigEndian.PutUint32(header[:], uint32(len(body)+4))
+	if _, err := c.conn.Write(header[:]); err != nil {
+		return err
+	}
+	_, err := c.conn.Write(body)
+	return err
+}
+
+func (c *pgConn) writeMessage(msgType byte, body []byte) error {
+	var header [5]byte
+	header[0] = msgType
+	binary.BigEndian.PutUint32(header[1:5], uint32(len(body)+4))
+	if _, err := c.conn.Write(header[:]); err != nil {
+		return err
+	}
+	_, err := c.conn.Write(body)
+	return err
+}
+
+// readMessage reads one typed backend message (everything after startup is
+// typed): a 1-byte type, a 4-byte length (including itself), then the body.
+func (c *pgConn) readMessage() (byte, []byte, error) {
+	msgType, err := c.r.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+	var lenBuf [4]byte
+	if _, err := fullRead(c.r, lenBuf[:]); err != nil {
+		return 0, nil, err
+	}
+	length := int(binary.BigEndian.Uint32(lenBuf[:])) - 4
+	if length < 0 {
+		return 0, nil, fmt.Errorf("postgres: negative message length")
+	}
+	body := make([]byte, length)
+	if _, err := fullRead(c.r, body); err != nil {
+		return 0, nil, err
+	}
+	return msgType, body, nil
+}
+
+func fullRead(r *bufio.Reader, buf []byte) (int, error) {
+	total := 0
+	for total < len(buf) {
+		n, err := r.Read(buf[total:])
+		total += n
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// authenticate consumes the AuthenticationX message the server sends after
+// startup and responds to it. AuthenticationOk (0) needs no response;
+// AuthenticationCleartextPassword (3) is answered with a PasswordMessage.
+// Any other method (MD5, SASL/SCRAM, ...) is reported as unsupported rather
+// than silently misbehaving.
+func (c *pgConn) authenticate(user, password string) error {
+	msgType, body, err := c.readMessage()
+	if err != nil {
+		return fmt.Errorf("postgres: reading authentication request: %w", err)
+	}
+	if msgType == 'E' {
+		return fmt.Errorf("postgres: %s", parsePGError(body))
+	}
+	if msgType != 'R' || len(body) < 4 {
+		return fmt.Errorf("postgres: expected an authentication request, got message type %q", msgType)
+	}
+	authType := binary.BigEndian.Uint32(body[:4])
+	switch authType {
+	case 0: // AuthenticationOk
+		return nil
+	case 3: // AuthenticationCleartextPassword
+		pw := append([]byte(password), 0)
+		if err := c.writeMessage('p', pw); err != nil {
+			return err
+		}
+		return c.consumeAuthResult()
+	default:
+		return fmt.Errorf("postgres: authentication method %d is not supported (only trust and cleartext password auth are implemented); "+
+			"set password_encryption=md5 won't help either -- use pg_hba.conf trust or password auth for PGVECTOR_DSN", authType)
+	}
+}
+
+// consumeAuthResult reads the AuthenticationOk that should follow a
+// PasswordMessage, surfacing an ErrorResponse (e.g. bad password) instead.
+func (c *pgConn) consumeAuthResult() error {
+	msgType, body, err := c.readMessage()
+	if err != nil {
+		return err
+	}
+	if msgType == 'E' {
+		return fmt.Errorf("postgres: %s", parsePGError(body))
+	}
+	if msgType != 'R' || len(body) < 4 || binary.BigEndian.Uint32(body[:4]) != 0 {
+		return fmt.Errorf("postgres: authentication failed")
+	}
+	return nil
+}
+
+// waitForReady drains ParameterStatus/BackendKeyData/NoticeResponse messages
+// until ReadyForQuery, which marks the connection usable.
+func (c *pgConn) waitForReady() error {
+	for {
+		msgType, body, err := c.readMessage()
+		if err != nil {
+			return err
+		}
+		switch msgType {
+		case 'Z':
+			return nil
+		case 'E':
+			return fmt.Errorf("postgres: %s", parsePGError(body))
+		case 'S', 'K', 'N':
+			// ParameterStatus, BackendKeyData, NoticeResponse: not needed here.
+		default:
+			// Unexpected but non-fatal message; keep draining toward ReadyForQuery.
+		}
+	}
+}
+
+// exec runs sql via the simple query protocol and discards any result rows,
+// for DDL/DML (CREATE TABLE, INSERT, DELETE).
+func (c *pgConn) exec(sql string) error {
+	_, err := c.query(sql)
+	return err
+}
+
+// query runs sql via the simple query protocol and returns its result rows
+// (nil if the statement produced none, e.g. DDL).
+func (c *pgConn) query(sql string) ([]pgRow, error) {
+	if err := c.writeMessage('Q', append([]byte(sql), 0)); err != nil {
+		return nil, err
+	}
+
+	var rows []pgRow
+	var queryErr error
+	for {
+		msgType, body, err := c.readMessage()
+		if err != nil {
+			return nil, err
+		}
+		switch msgType {
+		case 'T': // RowDescription
+		case 'D': // DataRow
+			rows = append(rows, parseDataRow(body))
+		case 'C': // CommandComplete
+		case 'E': // ErrorResponse
+			queryErr = fmt.Errorf("postgres: %s", parsePGError(body))
+		case 'Z': // ReadyForQuery -- end of this query's response
+			return rows, queryErr
+		case 'N': // NoticeResponse
+		default:
+			// EmptyQueryResponse, ParseComplete, etc.: nothing to extract.
+		}
+	}
+}
+
+// parseDataRow decodes a DataRow message body: int16 field count, then per
+// field an int32 length (-1 for NULL) followed by that many bytes of text.
+func parseDataRow(body []byte) pgRow {
+	if len(body) < 2 {
+		return nil
+	}
+	count := int(binary.BigEndian.Uint16(body[:2]))
+	row := make(pgRow, count)
+	pos := 2
+	for i := 0; i < count; i++ {
+		if pos+4 > len(body) {
+			break
+		}
+		length := int32(binary.BigEndian.Uint32(body[pos : pos+4]))
+		pos += 4
+		if length < 0 {
+			row[i] = ""
+			continue
+		}
+		row[i] = string(body[pos : pos+int(length)])
+		pos += int(length)
+	}
+	return row
+}
+
+// parsePGError extracts the human-readable message from an ErrorResponse
+// body (a sequence of 1-byte field code + null-terminated string, ending in
+// a zero byte), falling back to the raw fields if no "M" (Message) field is
+// present.
+func parsePGError(body []byte) string {
+	fields := map[byte]string{}
+	i := 0
+	for i < len(body) && body[i] != 0 {
+		code := body[i]
+		i++
+		start := i
+		for i < len(body) && body[i] != 0 {
+			i++
+		}
+		fields[code] = string(body[start:i])
+		i++ // skip the field's terminating zero
+	}
+	if msg, ok := fields['M']; ok {
+		if detail, ok := fields['D']; ok {
+			return msg + ": " + detail
+		}
+		return msg
+	}
+	var parts []string
+	for _, v := range fields {
+		parts = append(parts, v)
+	}
+	return strings.Join(parts, "; ")
+}
+
+func appendCString(dst []byte, s string) []byte {
+	dst = append(dst, s...)
+	return append(dst, 0)
+}
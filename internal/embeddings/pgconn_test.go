@@ -0,0 +1,211 @@
+package embeddings
+
+import (
+	"bufio"
+	"encoding/binary"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakePGServer is a tiny in-process server implementing just enough of the
+// Postgres wire protocol (startup, cleartext auth, simple query) to
+// exercise pgConn/PGVectorStore against a real TCP connection, without
+// requiring an actual Postgres binary in the test environment. It answers
+// every query with a single canned row so callers can check the rows made
+// it through the framing intact.
+type fakePGServer struct {
+	ln       net.Listener
+	password string // "" means trust auth (AuthenticationOk immediately)
+}
+
+func newFakePGServer(t *testing.T, password string) *fakePGServer {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("starting fake postgres server: %v", err)
+	}
+	s := &fakePGServer{ln: ln, password: password}
+	go s.serve()
+	t.Cleanup(func() { ln.Close() })
+	return s
+}
+
+func (s *fakePGServer) addr() string { return s.ln.Addr().String() }
+
+func (s *fakePGServer) serve() {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		go s.handleConn(conn)
+	}
+}
+
+func writeFakeMessage(conn net.Conn, msgType byte, body []byte) {
+	header := make([]byte, 5)
+	header[0] = msgType
+	binary.BigEndian.PutUint32(header[1:], uint32(len(body)+4))
+	conn.Write(header)
+	conn.Write(body)
+}
+
+func (s *fakePGServer) handleConn(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+
+	// Startup message: no type byte, just length + body.
+	var lenBuf [4]byte
+	if _, err := r.Read(lenBuf[:]); err != nil {
+		return
+	}
+	length := int(binary.BigEndian.Uint32(lenBuf[:])) - 4
+	startupBody := make([]byte, length)
+	readFull(r, startupBody)
+
+	if s.password == "" {
+		writeFakeMessage(conn, 'R', []byte{0, 0, 0, 0}) // AuthenticationOk
+	} else {
+		writeFakeMessage(conn, 'R', []byte{0, 0, 0, 3}) // AuthenticationCleartextPassword
+		msgType, body := readFakeMessage(r)
+		if msgType != 'p' || strings.TrimSuffix(string(body), "\x00") != s.password {
+			writeFakeMessage(conn, 'E', pgErrorBody("28P01", "password authentication failed"))
+			return
+		}
+		writeFakeMessage(conn, 'R', []byte{0, 0, 0, 0})
+	}
+	writeFakeMessage(conn, 'Z', []byte{'I'})
+
+	for {
+		msgType, body := readFakeMessage(r)
+		if msgType == 0 {
+			return
+		}
+		if msgType != 'Q' {
+			continue
+		}
+		sql := strings.TrimSuffix(string(body), "\x00")
+		upper := strings.ToUpper(sql)
+		switch {
+		case strings.Contains(upper, "COUNT(*)"):
+			writeFakeMessage(conn, 'T', nil)
+			writeFakeMessage(conn, 'D', fakeDataRow("7"))
+		case strings.Contains(upper, "SELECT CHAPTER_ID, CHAPTER_NAME"):
+			// SearchExplain's column order: chapter_id, chapter_name,
+			// section, content, locale, start_line, end_line, score.
+			writeFakeMessage(conn, 'T', nil)
+			writeFakeMessage(conn, 'D', fakeDataRow("ch1", "Intro", "s1", "it's a test", "en", "1", "5", "0.9"))
+		case strings.Contains(upper, "SELECT"):
+			writeFakeMessage(conn, 'T', nil)
+			writeFakeMessage(conn, 'D', fakeDataRow("hello"))
+		}
+		writeFakeMessage(conn, 'C', []byte("OK\x00"))
+		writeFakeMessage(conn, 'Z', []byte{'I'})
+	}
+}
+
+// fakeDataRow builds a DataRow message body for the given text field values.
+func fakeDataRow(fields ...string) []byte {
+	var row []byte
+	var count [2]byte
+	binary.BigEndian.PutUint16(count[:], uint16(len(fields)))
+	row = append(row, count[:]...)
+	for _, f := range fields {
+		var lenField [4]byte
+		binary.BigEndian.PutUint32(lenField[:], uint32(len(f)))
+		row = append(row, lenField[:]...)
+		row = append(row, f...)
+	}
+	return row
+}
+
+func pgErrorBody(code, message string) []byte {
+	var b []byte
+	b = append(b, 'C')
+	b = append(b, code...)
+	b = append(b, 0)
+	b = append(b, 'M')
+	b = append(b, message...)
+	b = append(b, 0)
+	b = append(b, 0)
+	return b
+}
+
+func readFakeMessage(r *bufio.Reader) (byte, []byte) {
+	msgType, err := r.ReadByte()
+	if err != nil {
+		return 0, nil
+	}
+	var lenBuf [4]byte
+	if _, err := readFull(r, lenBuf[:]); err != nil {
+		return 0, nil
+	}
+	length := int(binary.BigEndian.Uint32(lenBuf[:])) - 4
+	body := make([]byte, length)
+	readFull(r, body)
+	return msgType, body
+}
+
+func readFull(r *bufio.Reader, buf []byte) (int, error) {
+	total := 0
+	for total < len(buf) {
+		n, err := r.Read(buf[total:])
+		total += n
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+func TestConnectPGTrustAuth(t *testing.T) {
+	server := newFakePGServer(t, "")
+	conn, err := connectPG(server.addr(), "alice", "", "booksdb", 2*time.Second)
+	if err != nil {
+		t.Fatalf("connectPG: %v", err)
+	}
+	defer conn.close()
+
+	rows, err := conn.query("SELECT 1")
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	if len(rows) != 1 || len(rows[0]) != 1 || rows[0][0] != "hello" {
+		t.Fatalf("unexpected rows: %+v", rows)
+	}
+}
+
+func TestConnectPGCleartextAuth(t *testing.T) {
+	server := newFakePGServer(t, "s3cret")
+
+	if _, err := connectPG(server.addr(), "alice", "wrong", "booksdb", 2*time.Second); err == nil {
+		t.Fatal("expected an error with the wrong password")
+	}
+
+	conn, err := connectPG(server.addr(), "alice", "s3cret", "booksdb", 2*time.Second)
+	if err != nil {
+		t.Fatalf("connectPG: %v", err)
+	}
+	defer conn.close()
+
+	if err := conn.exec("CREATE TABLE whatever (id TEXT)"); err != nil {
+		t.Fatalf("exec: %v", err)
+	}
+}
+
+func TestPGVectorStoreRoundTrip(t *testing.T) {
+	server := newFakePGServer(t, "")
+	store := NewPGVectorStore("postgres://alice@"+server.addr()+"/booksdb", "book1", "openai")
+
+	store.AddBatch([]Chunk{{ID: "c1", ChapterID: "ch1", ChapterName: "Intro", Locale: "en", Content: "it's a test", Embedding: []float64{0.1, 0.2}}})
+
+	results := store.SearchExplain([]float64{0.1, 0.2}, "en", 5, false)
+	if len(results) != 1 || results[0].ChapterID != "ch1" || results[0].EndLine != 5 || results[0].Score != 0.9 {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+
+	if n := store.Count(); n != 7 {
+		t.Fatalf("expected Count() == 7, got %d", n)
+	}
+}
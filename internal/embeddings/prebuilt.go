@@ -0,0 +1,119 @@
+package embeddings
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// PrebuiltIndexManifest describes a published pre-built index artifact:
+// where to download it, its checksum, and what book commit and embedding
+// model it was built against. The commit/model fields let a server tell
+// whether a published index is actually compatible with the book it's
+// currently serving before loading it blindly.
+type PrebuiltIndexManifest struct {
+	IndexURL       string       `json:"indexUrl"`
+	SHA256         string       `json:"sha256"`
+	Format         ExportFormat `json:"format"`
+	BookCommit     string       `json:"bookCommit"`
+	EmbeddingModel string       `json:"embeddingModel"`
+	BuiltAt        time.Time    `json:"builtAt"`
+}
+
+// prebuiltIndexHTTPClient is shared by manifest and artifact downloads so
+// both go through the same VCR-capable transport the embedding clients use,
+// letting tests record/replay a fixture release instead of hitting the
+// network.
+var prebuiltIndexHTTPClient = newHTTPClientWithVCR("prebuilt-index", 60*time.Second)
+
+// FetchPrebuiltManifest downloads and parses a manifest describing a
+// published pre-built index (e.g. a GitHub release asset URL).
+func FetchPrebuiltManifest(ctx context.Context, manifestURL string) (*PrebuiltIndexManifest, error) {
+	body, err := httpGetBytes(ctx, manifestURL)
+	if err != nil {
+		return nil, fmt.Errorf("fetching manifest: %w", err)
+	}
+
+	var manifest PrebuiltIndexManifest
+	if err := json.Unmarshal(body, &manifest); err != nil {
+		return nil, fmt.Errorf("parsing manifest: %w", err)
+	}
+	if manifest.IndexURL == "" {
+		return nil, fmt.Errorf("manifest is missing indexUrl")
+	}
+	if manifest.SHA256 == "" {
+		return nil, fmt.Errorf("manifest is missing sha256")
+	}
+	return &manifest, nil
+}
+
+// DownloadPrebuiltIndex downloads the index artifact described by manifest
+// and verifies its SHA-256 checksum before returning its contents, so a
+// corrupted or tampered-with download never gets loaded as if it were real.
+func DownloadPrebuiltIndex(ctx context.Context, manifest *PrebuiltIndexManifest) (string, error) {
+	body, err := httpGetBytes(ctx, manifest.IndexURL)
+	if err != nil {
+		return "", fmt.Errorf("downloading index artifact: %w", err)
+	}
+
+	sum := sha256.Sum256(body)
+	got := hex.EncodeToString(sum[:])
+	if got != manifest.SHA256 {
+		return "", fmt.Errorf("checksum mismatch: manifest says %s, downloaded artifact is %s", manifest.SHA256, got)
+	}
+
+	return string(body), nil
+}
+
+func httpGetBytes(ctx context.Context, url string) ([]byte, error) {
+	if offlineModeEnabled() {
+		return nil, errOffline("pre-built index downloads")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := prebuiltIndexHTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// PrebuiltIndexCompatibility reports whether a manifest's book commit and
+// embedding model match what the caller expects. Neither mismatch is
+// treated as fatal here -- it's up to the caller (the fetch_prebuilt_index
+// tool) to decide whether to refuse an incompatible index or load it anyway
+// when explicitly forced.
+type PrebuiltIndexCompatibility struct {
+	BookCommitMatches      bool   `json:"bookCommitMatches"`
+	EmbeddingModelMatches  bool   `json:"embeddingModelMatches"`
+	ManifestBookCommit     string `json:"manifestBookCommit"`
+	ManifestEmbeddingModel string `json:"manifestEmbeddingModel"`
+}
+
+// CheckPrebuiltIndexCompatibility compares manifest against the book commit
+// and embedding model the caller is currently running. An empty expected
+// value (e.g. the book isn't in a git checkout, or no provider is
+// configured yet) is treated as "can't tell, don't block on it".
+func CheckPrebuiltIndexCompatibility(manifest *PrebuiltIndexManifest, currentBookCommit, currentEmbeddingModel string) PrebuiltIndexCompatibility {
+	return PrebuiltIndexCompatibility{
+		BookCommitMatches:      currentBookCommit == "" || manifest.BookCommit == "" || manifest.BookCommit == currentBookCommit,
+		EmbeddingModelMatches:  currentEmbeddingModel == "" || manifest.EmbeddingModel == "" || manifest.EmbeddingModel == currentEmbeddingModel,
+		ManifestBookCommit:     manifest.BookCommit,
+		ManifestEmbeddingModel: manifest.EmbeddingModel,
+	}
+}
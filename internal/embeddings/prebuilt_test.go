@@ -0,0 +1,78 @@
+package embeddings
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFetchPrebuiltManifestAndDownload(t *testing.T) {
+	artifact := `{"id":"c1","chapterId":"ch1","chapterName":"Chapter One","locale":"en","content":"hello","embedding":[0.1,0.2]}` + "\n"
+	sum := sha256.Sum256([]byte(artifact))
+	checksum := hex.EncodeToString(sum[:])
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/manifest.json":
+			manifest := PrebuiltIndexManifest{
+				IndexURL:       fmt.Sprintf("http://%s/index.jsonl", r.Host),
+				SHA256:         checksum,
+				Format:         ExportFormatJSONL,
+				BookCommit:     "abc123",
+				EmbeddingModel: "fake",
+			}
+			_ = json.NewEncoder(w).Encode(manifest)
+		case "/index.jsonl":
+			_, _ = w.Write([]byte(artifact))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	manifest, err := FetchPrebuiltManifest(context.Background(), server.URL+"/manifest.json")
+	if err != nil {
+		t.Fatalf("FetchPrebuiltManifest failed: %v", err)
+	}
+
+	compat := CheckPrebuiltIndexCompatibility(manifest, "abc123", "fake")
+	if !compat.BookCommitMatches || !compat.EmbeddingModelMatches {
+		t.Fatalf("expected manifest to be reported compatible, got %+v", compat)
+	}
+
+	data, err := DownloadPrebuiltIndex(context.Background(), manifest)
+	if err != nil {
+		t.Fatalf("DownloadPrebuiltIndex failed: %v", err)
+	}
+	if data != artifact {
+		t.Fatalf("downloaded data does not match artifact: %q", data)
+	}
+}
+
+func TestDownloadPrebuiltIndexRejectsBadChecksum(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("tampered content"))
+	}))
+	defer server.Close()
+
+	manifest := &PrebuiltIndexManifest{IndexURL: server.URL, SHA256: "0000000000000000000000000000000000000000000000000000000000000"}
+	if _, err := DownloadPrebuiltIndex(context.Background(), manifest); err == nil {
+		t.Fatal("expected a checksum mismatch error")
+	}
+}
+
+func TestCheckPrebuiltIndexCompatibilityFlagsMismatch(t *testing.T) {
+	manifest := &PrebuiltIndexManifest{BookCommit: "old-commit", EmbeddingModel: "text-embedding-3-small"}
+	compat := CheckPrebuiltIndexCompatibility(manifest, "new-commit", "text-embedding-3-small")
+	if compat.BookCommitMatches {
+		t.Fatal("expected book commit mismatch to be flagged")
+	}
+	if !compat.EmbeddingModelMatches {
+		t.Fatal("expected embedding model to match")
+	}
+}
@@ -0,0 +1,335 @@
+package embeddings
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+)
+
+// ============================================
+// COHERE CLIENT
+// ============================================
+
+type CohereClient struct {
+	apiKey     string
+	model      string
+	httpClient *http.Client
+}
+
+type cohereRequest struct {
+	Texts     []string `json:"texts"`
+	Model     string   `json:"model"`
+	InputType string   `json:"input_type"`
+}
+
+type cohereResponse struct {
+	Embeddings [][]float64 `json:"embeddings"`
+	Message    string      `json:"message,omitempty"`
+}
+
+// NewCohereClient creates a Cohere client. model defaults to
+// COHERE_EMBEDDING_MODEL, then "embed-english-v3.0".
+func NewCohereClient(apiKey string, model string) *CohereClient {
+	if apiKey == "" {
+		apiKey = os.Getenv("COHERE_API_KEY")
+	}
+	if model == "" {
+		model = os.Getenv("COHERE_EMBEDDING_MODEL")
+		if model == "" {
+			model = "embed-english-v3.0"
+		}
+	}
+	return &CohereClient{
+		apiKey:     apiKey,
+		model:      model,
+		httpClient: newHTTPClientWithVCR("cohere", 30*time.Second),
+	}
+}
+
+func (c *CohereClient) Embed(ctx context.Context, text string) ([]float64, error) {
+	embeddings, err := c.EmbedBatch(ctx, []string{text})
+	if err != nil {
+		return nil, err
+	}
+	if len(embeddings) == 0 {
+		return nil, fmt.Errorf("no embeddings returned")
+	}
+	return embeddings[0], nil
+}
+
+func (c *CohereClient) EmbedBatch(ctx context.Context, texts []string) ([][]float64, error) {
+	if c.apiKey == "" {
+		return nil, fmt.Errorf("Cohere API key not set")
+	}
+
+	reqBody := cohereRequest{
+		Texts:     texts,
+		Model:     c.model,
+		InputType: "search_document",
+	}
+
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.cohere.com/v1/embed", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var cohereResp cohereResponse
+	if err := json.Unmarshal(respBody, &cohereResp); err != nil {
+		return nil, err
+	}
+
+	if len(cohereResp.Embeddings) == 0 && cohereResp.Message != "" {
+		return nil, fmt.Errorf("Cohere error: %s", cohereResp.Message)
+	}
+
+	return cohereResp.Embeddings, nil
+}
+
+// ============================================
+// VOYAGE AI CLIENT
+// ============================================
+
+type VoyageClient struct {
+	apiKey     string
+	model      string
+	httpClient *http.Client
+}
+
+type voyageRequest struct {
+	Input []string `json:"input"`
+	Model string   `json:"model"`
+}
+
+type voyageResponse struct {
+	Data []struct {
+		Embedding []float64 `json:"embedding"`
+		Index     int       `json:"index"`
+	} `json:"data"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// NewVoyageClient creates a Voyage AI client. model defaults to
+// VOYAGE_EMBEDDING_MODEL, then "voyage-2".
+func NewVoyageClient(apiKey string, model string) *VoyageClient {
+	if apiKey == "" {
+		apiKey = os.Getenv("VOYAGE_API_KEY")
+	}
+	if model == "" {
+		model = os.Getenv("VOYAGE_EMBEDDING_MODEL")
+		if model == "" {
+			model = "voyage-2"
+		}
+	}
+	return &VoyageClient{
+		apiKey:     apiKey,
+		model:      model,
+		httpClient: newHTTPClientWithVCR("voyage", 30*time.Second),
+	}
+}
+
+func (c *VoyageClient) Embed(ctx context.Context, text string) ([]float64, error) {
+	embeddings, err := c.EmbedBatch(ctx, []string{text})
+	if err != nil {
+		return nil, err
+	}
+	if len(embeddings) == 0 {
+		return nil, fmt.Errorf("no embeddings returned")
+	}
+	return embeddings[0], nil
+}
+
+func (c *VoyageClient) EmbedBatch(ctx context.Context, texts []string) ([][]float64, error) {
+	if c.apiKey == "" {
+		return nil, fmt.Errorf("Voyage API key not set")
+	}
+
+	reqBody := voyageRequest{
+		Input: texts,
+		Model: c.model,
+	}
+
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.voyageai.com/v1/embeddings", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var voyageResp voyageResponse
+	if err := json.Unmarshal(respBody, &voyageResp); err != nil {
+		return nil, err
+	}
+
+	if len(voyageResp.Data) == 0 && voyageResp.Detail != "" {
+		return nil, fmt.Errorf("Voyage error: %s", voyageResp.Detail)
+	}
+
+	embeddings := make([][]float64, len(texts))
+	for _, d := range voyageResp.Data {
+		embeddings[d.Index] = d.Embedding
+	}
+
+	return embeddings, nil
+}
+
+// ============================================
+// GOOGLE GEMINI CLIENT
+// ============================================
+
+type GeminiClient struct {
+	apiKey     string
+	model      string
+	httpClient *http.Client
+}
+
+type geminiBatchRequest struct {
+	Requests []geminiEmbedRequest `json:"requests"`
+}
+
+type geminiEmbedRequest struct {
+	Model   string        `json:"model"`
+	Content geminiContent `json:"content"`
+}
+
+type geminiContent struct {
+	Parts []geminiPart `json:"parts"`
+}
+
+type geminiPart struct {
+	Text string `json:"text"`
+}
+
+type geminiBatchResponse struct {
+	Embeddings []struct {
+		Values []float64 `json:"values"`
+	} `json:"embeddings"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// NewGeminiClient creates a Google Gemini embedding client. model defaults
+// to GEMINI_EMBEDDING_MODEL, then "embedding-001".
+func NewGeminiClient(apiKey string, model string) *GeminiClient {
+	if apiKey == "" {
+		apiKey = os.Getenv("GEMINI_API_KEY")
+	}
+	if model == "" {
+		model = os.Getenv("GEMINI_EMBEDDING_MODEL")
+		if model == "" {
+			model = "embedding-001"
+		}
+	}
+	return &GeminiClient{
+		apiKey:     apiKey,
+		model:      model,
+		httpClient: newHTTPClientWithVCR("gemini", 30*time.Second),
+	}
+}
+
+func (c *GeminiClient) Embed(ctx context.Context, text string) ([]float64, error) {
+	embeddings, err := c.EmbedBatch(ctx, []string{text})
+	if err != nil {
+		return nil, err
+	}
+	if len(embeddings) == 0 {
+		return nil, fmt.Errorf("no embeddings returned")
+	}
+	return embeddings[0], nil
+}
+
+func (c *GeminiClient) EmbedBatch(ctx context.Context, texts []string) ([][]float64, error) {
+	if c.apiKey == "" {
+		return nil, fmt.Errorf("Gemini API key not set")
+	}
+
+	requests := make([]geminiEmbedRequest, len(texts))
+	for i, text := range texts {
+		requests[i] = geminiEmbedRequest{
+			Model:   "models/" + c.model,
+			Content: geminiContent{Parts: []geminiPart{{Text: text}}},
+		}
+	}
+	reqBody := geminiBatchRequest{Requests: requests}
+
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("https://generativelanguage.googleapis.com/v1beta/models/%s:batchEmbedContents?key=%s", c.model, c.apiKey)
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var geminiResp geminiBatchResponse
+	if err := json.Unmarshal(respBody, &geminiResp); err != nil {
+		return nil, err
+	}
+
+	if geminiResp.Error != nil {
+		return nil, fmt.Errorf("Gemini error: %s", geminiResp.Error.Message)
+	}
+
+	embeddings := make([][]float64, len(geminiResp.Embeddings))
+	for i, e := range geminiResp.Embeddings {
+		embeddings[i] = e.Values
+	}
+
+	return embeddings, nil
+}
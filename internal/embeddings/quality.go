@@ -0,0 +1,178 @@
+package embeddings
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/Alan-TheGentleman/gentleman-book-mcp/internal/book"
+)
+
+// outlierNearestNeighborThreshold is the cosine similarity below which a
+// chunk's best match among its peers is considered suspiciously distant,
+// usually a sign that the chunk is JSX/MDX boilerplate noise rather than
+// real prose.
+const outlierNearestNeighborThreshold = 0.15
+
+// ChapterEmbeddingStats summarizes the chunks indexed for one chapter/locale
+// pair, to help spot chunking problems (e.g. a chapter whose chunks are far
+// too short, or whose embedding norms are unusually spread out).
+type ChapterEmbeddingStats struct {
+	ChapterID      string  `json:"chapterId"`
+	Locale         string  `json:"locale"`
+	ChunkCount     int     `json:"chunkCount"`
+	AvgChunkLength float64 `json:"avgChunkLength"`
+	AvgNorm        float64 `json:"avgNorm"`
+	NormStdDev     float64 `json:"normStdDev"`
+}
+
+// OutlierChunk is a chunk whose nearest neighbor in the index is
+// suspiciously distant, flagged so a maintainer can inspect it for garbage
+// content.
+type OutlierChunk struct {
+	ChunkID              string  `json:"chunkId"`
+	ChapterID            string  `json:"chapterId"`
+	Locale               string  `json:"locale"`
+	Section              string  `json:"section"`
+	ContentPreview       string  `json:"contentPreview"`
+	NearestNeighborScore float64 `json:"nearestNeighborScore"`
+}
+
+// QualityReport is the result of IndexQualityReport: per-chapter embedding
+// diagnostics plus a list of chunks that look like noise.
+type QualityReport struct {
+	SchemaVersion int                     `json:"schemaVersion"`
+	TotalChunks   int                     `json:"totalChunks"`
+	ChapterStats  []ChapterEmbeddingStats `json:"chapterStats"`
+	Outliers      []OutlierChunk          `json:"outliers"`
+}
+
+// IndexQualityReport computes per-chapter chunk-length/embedding-norm
+// statistics and flags chunks whose nearest neighbor is suspiciously
+// distant from everything else in the index. It requires the index to
+// already be built.
+func (e *SemanticEngine) IndexQualityReport() (*QualityReport, error) {
+	if !e.isIndexed {
+		return nil, fmt.Errorf("index not built, call IndexChunks first")
+	}
+
+	chunks := e.store.Chunks()
+
+	type statAccumulator struct {
+		chapterID string
+		locale    string
+		lengths   []int
+		norms     []float64
+	}
+
+	order := make([]string, 0)
+	accumulators := make(map[string]*statAccumulator)
+	for _, c := range chunks {
+		key := c.ChapterID + "\x00" + c.Locale
+		acc, ok := accumulators[key]
+		if !ok {
+			acc = &statAccumulator{chapterID: c.ChapterID, locale: c.Locale}
+			accumulators[key] = acc
+			order = append(order, key)
+		}
+		acc.lengths = append(acc.lengths, len(c.Content))
+		acc.norms = append(acc.norms, vectorNorm(c.Embedding))
+	}
+
+	stats := make([]ChapterEmbeddingStats, 0, len(order))
+	for _, key := range order {
+		acc := accumulators[key]
+		avgLength := mean(intsToFloats(acc.lengths))
+		avgNorm := mean(acc.norms)
+		stats = append(stats, ChapterEmbeddingStats{
+			ChapterID:      acc.chapterID,
+			Locale:         acc.locale,
+			ChunkCount:     len(acc.lengths),
+			AvgChunkLength: avgLength,
+			AvgNorm:        avgNorm,
+			NormStdDev:     stdDev(acc.norms, avgNorm),
+		})
+	}
+
+	var outliers []OutlierChunk
+	for i, c := range chunks {
+		hasPeer := false
+		best := math.Inf(-1)
+		for j, other := range chunks {
+			if i == j || other.Locale != c.Locale {
+				continue
+			}
+			hasPeer = true
+			if score := cosineSimilarity(c.Embedding, other.Embedding); score > best {
+				best = score
+			}
+		}
+		if hasPeer && best < outlierNearestNeighborThreshold {
+			outliers = append(outliers, OutlierChunk{
+				ChunkID:              c.ID,
+				ChapterID:            c.ChapterID,
+				Locale:               c.Locale,
+				Section:              c.Section,
+				ContentPreview:       book.Truncate(c.Content, 120),
+				NearestNeighborScore: best,
+			})
+		}
+	}
+
+	return &QualityReport{
+		SchemaVersion: book.SchemaVersion,
+		TotalChunks:   len(chunks),
+		ChapterStats:  stats,
+		Outliers:      outliers,
+	}, nil
+}
+
+// Chunks returns a snapshot copy of every chunk currently in the store,
+// across every locale shard.
+func (v *VectorStore) Chunks() []Chunk {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	var out []Chunk
+	for _, shard := range v.shards {
+		out = append(out, shard.chunks...)
+	}
+	return out
+}
+
+func vectorNorm(vec []float64) float64 {
+	var sum float64
+	for _, x := range vec {
+		sum += x * x
+	}
+	return math.Sqrt(sum)
+}
+
+func mean(xs []float64) float64 {
+	if len(xs) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, x := range xs {
+		sum += x
+	}
+	return sum / float64(len(xs))
+}
+
+func stdDev(xs []float64, avg float64) float64 {
+	if len(xs) == 0 {
+		return 0
+	}
+	var sumSq float64
+	for _, x := range xs {
+		d := x - avg
+		sumSq += d * d
+	}
+	return math.Sqrt(sumSq / float64(len(xs)))
+}
+
+func intsToFloats(xs []int) []float64 {
+	out := make([]float64, len(xs))
+	for i, x := range xs {
+		out[i] = float64(x)
+	}
+	return out
+}
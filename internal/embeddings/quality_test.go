@@ -0,0 +1,70 @@
+package embeddings
+
+import (
+	"context"
+	"testing"
+)
+
+// TestIndexQualityReportFlagsOutlier verifies that a chunk embedded far from
+// every other chunk in its locale shows up in the outliers list, while
+// ordinary chunks don't.
+func TestIndexQualityReportFlagsOutlier(t *testing.T) {
+	engine, err := NewSemanticEngine(ProviderFake)
+	if err != nil {
+		t.Fatalf("NewSemanticEngine failed: %v", err)
+	}
+
+	chunks := []Chunk{
+		{ID: "c1", ChapterID: "ch1", Locale: "en", Content: "A true gentleman always ships working code."},
+		{ID: "c2", ChapterID: "ch1", Locale: "en", Content: "A true gentleman always reviews his own diffs first."},
+		{ID: "c3", ChapterID: "ch1", Locale: "en", Content: "import Foo from './components/Foo.jsx'; export default Foo;"},
+	}
+
+	if err := engine.IndexChunks(context.Background(), chunks); err != nil {
+		t.Fatalf("IndexChunks failed: %v", err)
+	}
+
+	// Force the third chunk's embedding to point opposite its neighbor so
+	// it's a guaranteed outlier regardless of the fake client's hash-based
+	// output.
+	store := engine.store.(*VectorStore)
+	store.mu.Lock()
+	shard := store.shards["en"]
+	var negated []float64
+	for i := range shard.chunks {
+		if shard.chunks[i].ID == "c1" {
+			negated = make([]float64, len(shard.chunks[i].Embedding))
+			for j, x := range shard.chunks[i].Embedding {
+				negated[j] = -x
+			}
+		}
+	}
+	for i := range shard.chunks {
+		if shard.chunks[i].ID == "c3" {
+			shard.chunks[i].Embedding = negated
+		}
+	}
+	store.mu.Unlock()
+
+	report, err := engine.IndexQualityReport()
+	if err != nil {
+		t.Fatalf("IndexQualityReport failed: %v", err)
+	}
+
+	if report.TotalChunks != 3 {
+		t.Fatalf("expected 3 total chunks, got %d", report.TotalChunks)
+	}
+	if len(report.ChapterStats) != 1 {
+		t.Fatalf("expected 1 chapter stats entry, got %d", len(report.ChapterStats))
+	}
+
+	found := false
+	for _, o := range report.Outliers {
+		if o.ChunkID == "c3" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected chunk c3 to be flagged as an outlier, got %+v", report.Outliers)
+	}
+}
@@ -0,0 +1,141 @@
+package embeddings
+
+import (
+	"encoding/json"
+	"log"
+	"strconv"
+	"time"
+)
+
+// redisQueryCacheKeyPrefix namespaces this cache's keys within a shared
+// Redis instance, so other tenants of that instance don't collide with --
+// or get scanned/deleted by -- this server's entries.
+const redisQueryCacheKeyPrefix = "gentleman:querycache:"
+
+// RedisResultCache is a ResultCache backed by Redis, so cached search
+// results are shared across replicas instead of each keeping its own warm
+// set. A cache is best-effort: any Redis error is logged and treated as a
+// miss/no-op rather than failing the search that triggered it.
+type RedisResultCache struct {
+	client *redisClient
+	ttl    time.Duration
+}
+
+// NewRedisResultCache creates a RedisResultCache talking to addr (host:port),
+// authenticating with password if set.
+func NewRedisResultCache(addr, password string, ttl time.Duration) *RedisResultCache {
+	return &RedisResultCache{client: newRedisClient(addr, password), ttl: ttl}
+}
+
+func (c *RedisResultCache) Key(provider, model, query, locale string, topK int) string {
+	return provider + "|" + model + "|" + locale + "|" + strconv.Itoa(topK) + "|" + query
+}
+
+func (c *RedisResultCache) Get(key string) ([]SemanticResult, bool) {
+	reply, err := c.client.do("GET", redisQueryCacheKeyPrefix+key)
+	if err != nil {
+		log.Printf("redis result cache: GET: %v", err)
+		return nil, false
+	}
+	raw, ok := reply.(string)
+	if !ok {
+		return nil, false
+	}
+	var results []SemanticResult
+	if err := json.Unmarshal([]byte(raw), &results); err != nil {
+		log.Printf("redis result cache: decoding cached value: %v", err)
+		return nil, false
+	}
+	return results, true
+}
+
+func (c *RedisResultCache) Set(key string, results []SemanticResult) {
+	data, err := json.Marshal(results)
+	if err != nil {
+		log.Printf("redis result cache: encoding results: %v", err)
+		return
+	}
+	if _, err := c.client.do("SET", redisQueryCacheKeyPrefix+key, string(data), "EX", strconv.Itoa(int(c.ttl.Seconds()))); err != nil {
+		log.Printf("redis result cache: SET: %v", err)
+	}
+}
+
+func (c *RedisResultCache) Clear() {
+	keys, err := c.client.scanKeys(redisQueryCacheKeyPrefix)
+	if err != nil {
+		log.Printf("redis result cache: Clear: %v", err)
+		return
+	}
+	if err := c.client.deleteKeys(keys); err != nil {
+		log.Printf("redis result cache: Clear: %v", err)
+	}
+}
+
+func (c *RedisResultCache) Size() int {
+	keys, err := c.client.scanKeys(redisQueryCacheKeyPrefix)
+	if err != nil {
+		log.Printf("redis result cache: Size: %v", err)
+		return 0
+	}
+	return len(keys)
+}
+
+// redisEmbedCacheKeyPrefix namespaces RedisEmbeddingCache's keys, parallel
+// to redisQueryCacheKeyPrefix.
+const redisEmbedCacheKeyPrefix = "gentleman:embedcache:"
+
+// RedisEmbeddingCache is an EmbeddingCacheBackend backed by Redis, so query
+// embeddings are shared across replicas instead of each re-embedding the
+// same queries against the provider.
+type RedisEmbeddingCache struct {
+	client *redisClient
+	ttl    time.Duration
+}
+
+// NewRedisEmbeddingCache creates a RedisEmbeddingCache talking to addr
+// (host:port), authenticating with password if set.
+func NewRedisEmbeddingCache(addr, password string, ttl time.Duration) *RedisEmbeddingCache {
+	return &RedisEmbeddingCache{client: newRedisClient(addr, password), ttl: ttl}
+}
+
+func (c *RedisEmbeddingCache) Key(provider, model, query string) string {
+	return provider + "|" + model + "|" + normalizeQuery(query)
+}
+
+func (c *RedisEmbeddingCache) Get(key string) ([]float64, bool) {
+	reply, err := c.client.do("GET", redisEmbedCacheKeyPrefix+key)
+	if err != nil {
+		log.Printf("redis embedding cache: GET: %v", err)
+		return nil, false
+	}
+	raw, ok := reply.(string)
+	if !ok {
+		return nil, false
+	}
+	var vector []float64
+	if err := json.Unmarshal([]byte(raw), &vector); err != nil {
+		log.Printf("redis embedding cache: decoding cached value: %v", err)
+		return nil, false
+	}
+	return vector, true
+}
+
+func (c *RedisEmbeddingCache) Set(key string, vector []float64) {
+	data, err := json.Marshal(vector)
+	if err != nil {
+		log.Printf("redis embedding cache: encoding vector: %v", err)
+		return
+	}
+	if _, err := c.client.do("SET", redisEmbedCacheKeyPrefix+key, string(data), "EX", strconv.Itoa(int(c.ttl.Seconds()))); err != nil {
+		log.Printf("redis embedding cache: SET: %v", err)
+	}
+}
+
+func (c *RedisEmbeddingCache) Size() int {
+	keys, err := c.client.scanKeys(redisEmbedCacheKeyPrefix)
+	if err != nil {
+		log.Printf("redis embedding cache: Size: %v", err)
+		return 0
+	}
+	return len(keys)
+}
@@ -0,0 +1,184 @@
+package embeddings
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeRedisServer is a tiny in-process server implementing just enough of
+// RESP and the commands redisClient issues (GET/SET/SCAN/DEL) to exercise
+// RedisResultCache/RedisEmbeddingCache against a real TCP connection,
+// without requiring an actual Redis binary in the test environment.
+type fakeRedisServer struct {
+	mu   sync.Mutex
+	data map[string]string
+	ln   net.Listener
+}
+
+func newFakeRedisServer(t *testing.T) *fakeRedisServer {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("starting fake redis server: %v", err)
+	}
+	s := &fakeRedisServer{data: make(map[string]string), ln: ln}
+	go s.serve()
+	t.Cleanup(func() { ln.Close() })
+	return s
+}
+
+func (s *fakeRedisServer) addr() string { return s.ln.Addr().String() }
+
+func (s *fakeRedisServer) serve() {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		go s.handleConn(conn)
+	}
+}
+
+func (s *fakeRedisServer) handleConn(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	for {
+		reply, err := readRESPReply(r)
+		if err != nil {
+			return
+		}
+		items, ok := reply.([]interface{})
+		if !ok || len(items) == 0 {
+			return
+		}
+		args := make([]string, len(items))
+		for i, v := range items {
+			args[i], _ = v.(string)
+		}
+		io.WriteString(conn, s.handleCommand(args))
+	}
+}
+
+func (s *fakeRedisServer) handleCommand(args []string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch strings.ToUpper(args[0]) {
+	case "GET":
+		v, ok := s.data[args[1]]
+		if !ok {
+			return "$-1\r\n"
+		}
+		return fmt.Sprintf("$%d\r\n%s\r\n", len(v), v)
+	case "SET":
+		s.data[args[1]] = args[2]
+		return "+OK\r\n"
+	case "DEL":
+		n := 0
+		for _, k := range args[1:] {
+			if _, ok := s.data[k]; ok {
+				delete(s.data, k)
+				n++
+			}
+		}
+		return fmt.Sprintf(":%d\r\n", n)
+	case "SCAN":
+		var prefix string
+		for i := 2; i < len(args)-1; i++ {
+			if strings.ToUpper(args[i]) == "MATCH" {
+				prefix = strings.TrimSuffix(args[i+1], "*")
+			}
+		}
+		var keys []string
+		for k := range s.data {
+			if strings.HasPrefix(k, prefix) {
+				keys = append(keys, k)
+			}
+		}
+		var b strings.Builder
+		b.WriteString("*2\r\n$1\r\n0\r\n")
+		fmt.Fprintf(&b, "*%d\r\n", len(keys))
+		for _, k := range keys {
+			fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(k), k)
+		}
+		return b.String()
+	default:
+		return "-ERR unknown command\r\n"
+	}
+}
+
+func TestRedisResultCacheRoundTrip(t *testing.T) {
+	server := newFakeRedisServer(t)
+	cache := NewRedisResultCache(server.addr(), "", time.Minute)
+
+	key := cache.Key("openai", "text-embedding-3-small", "what is TDD", "es", 5)
+	if _, ok := cache.Get(key); ok {
+		t.Fatal("expected a miss before Set")
+	}
+
+	results := []SemanticResult{{ChapterID: "ch1", ChapterName: "Intro", Section: "s1"}}
+	cache.Set(key, results)
+
+	got, ok := cache.Get(key)
+	if !ok || len(got) != 1 || got[0].ChapterID != "ch1" {
+		t.Fatalf("expected cached results back, got %+v, ok=%v", got, ok)
+	}
+
+	if n := cache.Size(); n != 1 {
+		t.Fatalf("expected Size() == 1, got %d", n)
+	}
+
+	cache.Clear()
+	if _, ok := cache.Get(key); ok {
+		t.Fatal("expected a miss after Clear")
+	}
+}
+
+func TestRedisEmbeddingCacheRoundTrip(t *testing.T) {
+	server := newFakeRedisServer(t)
+	cache := NewRedisEmbeddingCache(server.addr(), "", time.Minute)
+
+	key := cache.Key("openai", "text-embedding-3-small", "What is TDD?")
+	if _, ok := cache.Get(key); ok {
+		t.Fatal("expected a miss before Set")
+	}
+
+	vector := []float64{0.1, 0.2, 0.3}
+	cache.Set(key, vector)
+
+	got, ok := cache.Get(key)
+	if !ok || len(got) != 3 || got[1] != 0.2 {
+		t.Fatalf("expected cached vector back, got %v, ok=%v", got, ok)
+	}
+
+	if n := cache.Size(); n != 1 {
+		t.Fatalf("expected Size() == 1, got %d", n)
+	}
+}
+
+func TestNewResultCacheFallsBackToMemoryWhenOffline(t *testing.T) {
+	server := newFakeRedisServer(t)
+	t.Setenv("CACHE_BACKEND", "redis")
+	t.Setenv("REDIS_ADDR", server.addr())
+	t.Setenv("OFFLINE_MODE", "true")
+
+	if _, ok := NewResultCache(10, time.Minute).(*QueryCache); !ok {
+		t.Fatal("expected OFFLINE_MODE to force the in-memory query cache even with CACHE_BACKEND=redis")
+	}
+}
+
+func TestNewEmbeddingCacheBackendFallsBackToMemoryWhenOffline(t *testing.T) {
+	server := newFakeRedisServer(t)
+	t.Setenv("CACHE_BACKEND", "redis")
+	t.Setenv("REDIS_ADDR", server.addr())
+	t.Setenv("OFFLINE_MODE", "true")
+
+	if _, ok := NewEmbeddingCacheBackend(10, time.Minute).(*EmbeddingCache); !ok {
+		t.Fatal("expected OFFLINE_MODE to force the in-memory embedding cache even with CACHE_BACKEND=redis")
+	}
+}
@@ -0,0 +1,158 @@
+package embeddings
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// redisClient is a minimal client for Redis's RESP protocol -- just enough
+// GET/SET/SCAN/DEL to back ResultCache and EmbeddingCacheBackend. It opens a
+// fresh connection per command rather than pooling one, which is plenty for
+// a cache that's hit at most a few times per search; nothing here needs
+// pub/sub, clustering, or pipelining, so pulling in a full client library
+// for it isn't worth the dependency.
+type redisClient struct {
+	addr     string
+	password string
+	timeout  time.Duration
+}
+
+func newRedisClient(addr, password string) *redisClient {
+	return &redisClient{addr: addr, password: password, timeout: 5 * time.Second}
+}
+
+// do sends one command and returns its parsed reply: a string (simple or
+// bulk), an int64, nil (a RESP nil bulk string/array), or []interface{} for
+// array replies such as SCAN's [cursor, keys] pair.
+func (c *redisClient) do(args ...string) (interface{}, error) {
+	conn, err := net.DialTimeout("tcp", c.addr, c.timeout)
+	if err != nil {
+		return nil, fmt.Errorf("redis: connecting to %s: %w", c.addr, err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(c.timeout))
+
+	reader := bufio.NewReader(conn)
+
+	if c.password != "" {
+		if err := writeRESPCommand(conn, "AUTH", c.password); err != nil {
+			return nil, err
+		}
+		if _, err := readRESPReply(reader); err != nil {
+			return nil, fmt.Errorf("redis: AUTH failed: %w", err)
+		}
+	}
+
+	if err := writeRESPCommand(conn, args...); err != nil {
+		return nil, err
+	}
+	return readRESPReply(reader)
+}
+
+// scanKeys returns every key matching prefix+"*", iterating Redis's SCAN
+// cursor until it returns to 0. Used by Clear/Size instead of FLUSHDB/DBSIZE
+// so this cache doesn't touch or miscount keys belonging to other tenants of
+// a shared Redis instance.
+func (c *redisClient) scanKeys(prefix string) ([]string, error) {
+	var keys []string
+	cursor := "0"
+	for {
+		reply, err := c.do("SCAN", cursor, "MATCH", prefix+"*", "COUNT", "200")
+		if err != nil {
+			return nil, err
+		}
+		pair, ok := reply.([]interface{})
+		if !ok || len(pair) != 2 {
+			return nil, fmt.Errorf("redis: unexpected SCAN reply %#v", reply)
+		}
+		cursor, _ = pair[0].(string)
+		matched, _ := pair[1].([]interface{})
+		for _, m := range matched {
+			if s, ok := m.(string); ok {
+				keys = append(keys, s)
+			}
+		}
+		if cursor == "" || cursor == "0" {
+			break
+		}
+	}
+	return keys, nil
+}
+
+// deleteKeys issues DEL for every key in keys, a no-op if keys is empty
+// (Redis's DEL requires at least one argument).
+func (c *redisClient) deleteKeys(keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	_, err := c.do(append([]string{"DEL"}, keys...)...)
+	return err
+}
+
+func writeRESPCommand(w io.Writer, args ...string) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, a := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(a), a)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// readRESPReply parses one RESP reply from r: +simple strings, -errors
+// (returned as a Go error), :integers, $bulk strings (nil for length -1),
+// and *arrays (recursing for each element).
+func readRESPReply(r *bufio.Reader) (interface{}, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimRight(line, "\r\n")
+	if line == "" {
+		return nil, fmt.Errorf("redis: empty reply line")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, fmt.Errorf("redis: %s", line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		buf := make([]byte, n+2) // payload plus trailing \r\n
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		return string(buf[:n]), nil
+	case '*':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		items := make([]interface{}, n)
+		for i := 0; i < n; i++ {
+			if items[i], err = readRESPReply(r); err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("redis: unexpected reply prefix %q", line[0])
+	}
+}
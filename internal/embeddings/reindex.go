@@ -0,0 +1,53 @@
+package embeddings
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+)
+
+// ContentHash returns a stable fingerprint of content, for callers tracking
+// whether a chapter's content has changed since it was last indexed (see
+// SemanticEngine.ChapterChanged).
+func ContentHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+func chapterHashKey(chapterID, locale string) string {
+	return chapterID + "|" + locale
+}
+
+// chapterHashes tracks the content hash each (chapterID, locale) pair had
+// the last time it was successfully indexed, so update_semantic_index can
+// tell which chapters actually need re-embedding. It's a separate mutex from
+// indexMutex since RemoveChapter/SetChapterHash calls happen around, not
+// during, an IndexChunksWithProgress call.
+type chapterHashes struct {
+	mu     sync.Mutex
+	hashes map[string]string
+}
+
+// ChapterChanged reports whether chapterID/locale's current content hash
+// differs from the one recorded at its last successful index (or has never
+// been indexed at all).
+func (e *SemanticEngine) ChapterChanged(chapterID, locale, hash string) bool {
+	e.chapterHashes.mu.Lock()
+	defer e.chapterHashes.mu.Unlock()
+	return e.chapterHashes.hashes[chapterHashKey(chapterID, locale)] != hash
+}
+
+// SetChapterHash records chapterID/locale's content hash after it's been
+// successfully (re)indexed.
+func (e *SemanticEngine) SetChapterHash(chapterID, locale, hash string) {
+	e.chapterHashes.mu.Lock()
+	defer e.chapterHashes.mu.Unlock()
+	e.chapterHashes.hashes[chapterHashKey(chapterID, locale)] = hash
+}
+
+// RemoveChapter drops every indexed chunk for chapterID/locale from the
+// underlying store, so update_semantic_index can clear a changed chapter's
+// stale chunks before adding its freshly embedded ones.
+func (e *SemanticEngine) RemoveChapter(chapterID, locale string) {
+	e.store.RemoveChapter(chapterID, locale)
+}
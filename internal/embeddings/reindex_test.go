@@ -0,0 +1,55 @@
+package embeddings
+
+import "testing"
+
+func TestContentHashIsStableAndSensitiveToChanges(t *testing.T) {
+	if ContentHash("hello") != ContentHash("hello") {
+		t.Fatal("expected identical content to produce identical hashes")
+	}
+	if ContentHash("hello") == ContentHash("hello!") {
+		t.Fatal("expected different content to produce different hashes")
+	}
+}
+
+func TestSemanticEngineChapterChanged(t *testing.T) {
+	engine, err := NewSemanticEngine(ProviderFake)
+	if err != nil {
+		t.Fatalf("NewSemanticEngine returned error: %v", err)
+	}
+
+	if !engine.ChapterChanged("ch1", "es", "hash1") {
+		t.Fatal("expected an unseen chapter to be reported as changed")
+	}
+
+	engine.SetChapterHash("ch1", "es", "hash1")
+	if engine.ChapterChanged("ch1", "es", "hash1") {
+		t.Fatal("expected a chapter with a matching recorded hash to be unchanged")
+	}
+	if !engine.ChapterChanged("ch1", "es", "hash2") {
+		t.Fatal("expected a chapter whose content hash changed to be reported as changed")
+	}
+	if !engine.ChapterChanged("ch1", "en", "hash1") {
+		t.Fatal("expected a different locale's hash to be tracked independently")
+	}
+}
+
+func TestVectorStoreRemoveChapter(t *testing.T) {
+	store := NewVectorStore()
+	store.AddBatch([]Chunk{
+		{ID: "a", ChapterID: "ch1", Locale: "es", Embedding: []float64{1, 0}},
+		{ID: "b", ChapterID: "ch2", Locale: "es", Embedding: []float64{0, 1}},
+		{ID: "c", ChapterID: "ch1", Locale: "en", Embedding: []float64{1, 0}},
+	})
+
+	store.RemoveChapter("ch1", "es")
+
+	remaining := store.Chunks()
+	if len(remaining) != 2 {
+		t.Fatalf("expected 2 chunks left after removing ch1/es, got %d", len(remaining))
+	}
+	for _, c := range remaining {
+		if c.ChapterID == "ch1" && c.Locale == "es" {
+			t.Fatalf("expected ch1/es chunks to be removed, found %v", c)
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package embeddings
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"text/template"
+	"time"
+)
+
+// RESTStoreConfig declares how to talk to a vector database through plain
+// HTTP, without a bespoke Go client for each one. upsertURL/queryURL are
+// text/template strings so an operator can template in per-request values;
+// queryBody is a text/template rendered against restQueryTemplateData to
+// build the request JSON. This is how less common stores (Milvus, Weaviate,
+// or an in-house index) get wired in through config -- typically by
+// pointing at a small proxy that speaks that store's native protocol and
+// returns/accepts our own chunk shape (the same one ExportChunks produces).
+type RESTStoreConfig struct {
+	UpsertURL    string            `json:"upsertUrl"`
+	UpsertMethod string            `json:"upsertMethod"` // defaults to POST
+	QueryURL     string            `json:"queryUrl"`
+	QueryMethod  string            `json:"queryMethod"` // defaults to POST
+	QueryBody    string            `json:"queryBody"`   // text/template; see restQueryTemplateData
+	Headers      map[string]string `json:"headers"`
+}
+
+// restQueryTemplateData is what RESTStoreConfig.QueryBody renders against.
+// BookID and Model let the template route to a namespace/collection
+// partitioned by book id + locale + embedding model, so one downstream
+// store can safely serve several books/servers/models at once.
+type restQueryTemplateData struct {
+	Embedding []float64
+	Locale    string
+	TopK      int
+	BookID    string
+	Model     string
+}
+
+// restQueryResult is one match in a query response. It's exportRecord plus
+// an optional score, since a query response (unlike an export) needs
+// similarity scores but not necessarily raw embeddings back.
+type restQueryResult struct {
+	exportRecord
+	Score float64 `json:"score"`
+}
+
+// RESTStore is a VectorBackend that upserts and queries chunks against any
+// HTTP endpoint configured by RESTStoreConfig. bookID/model are threaded
+// into the query template so the downstream store can partition by
+// namespace/collection per book and embedding model (locale is exposed the
+// same way, per query).
+type RESTStore struct {
+	config     RESTStoreConfig
+	bookID     string
+	model      string
+	httpClient *http.Client
+	queryBody  *template.Template
+}
+
+// NewRESTStore builds a RESTStore from config, parsing its query body
+// template up front so a bad template fails fast instead of on first search.
+func NewRESTStore(config RESTStoreConfig, bookID, model string) (*RESTStore, error) {
+	if config.UpsertURL == "" || config.QueryURL == "" {
+		return nil, fmt.Errorf("rest vector store config requires both upsertUrl and queryUrl")
+	}
+
+	tmpl, err := template.New("queryBody").Parse(config.QueryBody)
+	if err != nil {
+		return nil, fmt.Errorf("invalid queryBody template: %w", err)
+	}
+
+	return &RESTStore{
+		config:     config,
+		bookID:     bookID,
+		model:      model,
+		httpClient: newHTTPClientWithVCR("rest-vector-store", 30*time.Second),
+		queryBody:  tmpl,
+	}, nil
+}
+
+func (r *RESTStore) method(configured, fallback string) string {
+	if configured == "" {
+		return fallback
+	}
+	return configured
+}
+
+func (r *RESTStore) setHeaders(req *http.Request) {
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range r.config.Headers {
+		req.Header.Set(k, v)
+	}
+}
+
+// AddBatch upserts chunks as a JSON array (the same shape ExportChunks
+// produces) to config.UpsertURL.
+func (r *RESTStore) AddBatch(chunks []Chunk) {
+	records := make([]exportRecord, len(chunks))
+	for i, c := range chunks {
+		records[i] = exportRecord{
+			ID: c.ID, ChapterID: c.ChapterID, ChapterName: c.ChapterName,
+			Section: c.Section, Content: c.Content, Locale: c.Locale,
+			StartLine: c.StartLine, EndLine: c.EndLine, Embedding: c.Embedding,
+		}
+	}
+
+	body, err := json.Marshal(records)
+	if err != nil {
+		return
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), r.method(r.config.UpsertMethod, "POST"), r.config.UpsertURL, bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+	r.setHeaders(req)
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return
+	}
+	resp.Body.Close()
+}
+
+// SearchExplain renders config.QueryBody against queryEmbedding/locale/topK,
+// posts it to config.QueryURL, and expects back a JSON array of
+// restQueryResult.
+func (r *RESTStore) SearchExplain(queryEmbedding []float64, locale string, topK int, explain bool) []SemanticResult {
+	var bodyBuf bytes.Buffer
+	data := restQueryTemplateData{Embedding: queryEmbedding, Locale: locale, TopK: topK, BookID: r.bookID, Model: r.model}
+	if err := r.queryBody.Execute(&bodyBuf, data); err != nil {
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), r.method(r.config.QueryMethod, "POST"), r.config.QueryURL, bytes.NewReader(bodyBuf.Bytes()))
+	if err != nil {
+		return nil
+	}
+	r.setHeaders(req)
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil
+	}
+	defer resp.Body.Close()
+
+	var matches []restQueryResult
+	if err := json.NewDecoder(resp.Body).Decode(&matches); err != nil {
+		return nil
+	}
+
+	var results []SemanticResult
+	for _, m := range matches {
+		if locale != "" && m.Locale != "" && m.Locale != locale {
+			continue
+		}
+		result := SemanticResult{
+			ChapterID: m.ChapterID, ChapterName: m.ChapterName, Section: m.Section,
+			Content: m.Content, Score: m.Score, Locale: m.Locale,
+			StartLine: m.StartLine, EndLine: m.EndLine,
+		}
+		if explain {
+			result.Explain = &ScoreExplain{CosineScore: m.Score}
+		}
+		results = append(results, result)
+		if len(results) == topK {
+			break
+		}
+	}
+	return results
+}
+
+// Count isn't meaningful without a dedicated endpoint in RESTStoreConfig, so
+// a REST-backed store reports 0 -- callers should rely on the downstream
+// store's own tooling for capacity diagnostics instead.
+func (r *RESTStore) Count() int {
+	return 0
+}
+
+// Chunks isn't implemented for a REST-backed store -- there's no generic way
+// to "list everything" across arbitrary vector databases through a single
+// upsert/query template pair, so diagnostics relying on it (export_index,
+// index_quality_report) simply see an empty index.
+func (r *RESTStore) Chunks() []Chunk {
+	return nil
+}
+
+// RemoveChapter isn't implemented for a REST-backed store -- RESTStoreConfig
+// has no delete endpoint to template, so update_semantic_index falls back to
+// upserting that chapter's new chunks without clearing its old ones; an
+// operator relying on incremental updates against a REST store should prune
+// stale documents on the downstream side instead.
+func (r *RESTStore) RemoveChapter(chapterID, locale string) {}
+
+// loadRESTStoreConfig reads a RESTStoreConfig from a JSON file at path.
+func loadRESTStoreConfig(path string) (RESTStoreConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return RESTStoreConfig{}, err
+	}
+	var config RESTStoreConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return RESTStoreConfig{}, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return config, nil
+}
@@ -0,0 +1,68 @@
+package embeddings
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRESTStoreUpsertAndQuery(t *testing.T) {
+	var upserted []exportRecord
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/upsert":
+			_ = json.NewDecoder(r.Body).Decode(&upserted)
+		case "/query":
+			var req restQueryTemplateData
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			results := make([]restQueryResult, 0, len(upserted))
+			for _, rec := range upserted {
+				if req.Locale != "" && rec.Locale != req.Locale {
+					continue
+				}
+				results = append(results, restQueryResult{exportRecord: rec, Score: 0.9})
+			}
+			_ = json.NewEncoder(w).Encode(results)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	store, err := NewRESTStore(RESTStoreConfig{
+		UpsertURL: server.URL + "/upsert",
+		QueryURL:  server.URL + "/query",
+		QueryBody: `{"embedding":[{{range $i, $v := .Embedding}}{{if $i}},{{end}}{{$v}}{{end}}],"locale":"{{.Locale}}","topK":{{.TopK}},"bookId":"{{.BookID}}","model":"{{.Model}}"}`,
+	}, "default-book", "fake")
+	if err != nil {
+		t.Fatalf("NewRESTStore failed: %v", err)
+	}
+
+	store.AddBatch([]Chunk{
+		{ID: "c1", ChapterID: "ch1", Locale: "en", Content: "A true gentleman ships working code.", Embedding: []float64{0.1, 0.2}},
+		{ID: "c2", ChapterID: "ch1", Locale: "es", Content: "Un verdadero caballero revisa su código.", Embedding: []float64{0.3, 0.4}},
+	})
+
+	results := store.SearchExplain([]float64{0.1, 0.2}, "en", 5, true)
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result for locale en, got %d", len(results))
+	}
+	if results[0].ChapterID != "ch1" || results[0].Explain == nil {
+		t.Fatalf("unexpected result: %+v", results[0])
+	}
+
+	if store.Count() != 0 {
+		t.Fatalf("expected RESTStore.Count() to be 0 (not implemented generically), got %d", store.Count())
+	}
+	if store.Chunks() != nil {
+		t.Fatalf("expected RESTStore.Chunks() to be nil (not implemented generically)")
+	}
+}
+
+func TestNewRESTStoreRejectsMissingURLs(t *testing.T) {
+	if _, err := NewRESTStore(RESTStoreConfig{}, "default-book", "fake"); err == nil {
+		t.Fatal("expected an error for a config with no upsertUrl/queryUrl")
+	}
+}
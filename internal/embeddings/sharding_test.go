@@ -0,0 +1,31 @@
+package embeddings
+
+import "testing"
+
+// TestVectorStoreShardsByLocale verifies that chunks land in separate
+// per-locale shards, and that an unscoped search merges results across all
+// of them.
+func TestVectorStoreShardsByLocale(t *testing.T) {
+	store := NewVectorStore()
+	store.AddBatch([]Chunk{
+		{ID: "en-1", Locale: "en", Embedding: []float64{1, 0, 0}},
+		{ID: "es-1", Locale: "es", Embedding: []float64{0, 1, 0}},
+	})
+
+	if len(store.shards) != 2 {
+		t.Fatalf("expected 2 shards, got %d", len(store.shards))
+	}
+	if len(store.shards["en"].chunks) != 1 || len(store.shards["es"].chunks) != 1 {
+		t.Fatalf("expected 1 chunk per locale shard, got en=%d es=%d", len(store.shards["en"].chunks), len(store.shards["es"].chunks))
+	}
+
+	enOnly := store.Search([]float64{1, 0, 0}, "en", 10)
+	if len(enOnly) != 1 || enOnly[0].ChapterID != "" || enOnly[0].Locale != "en" {
+		t.Fatalf("expected only the en shard to be searched, got %+v", enOnly)
+	}
+
+	all := store.Search([]float64{1, 0, 0}, "", 10)
+	if len(all) != 2 {
+		t.Fatalf("expected unscoped search to merge both shards, got %d results", len(all))
+	}
+}
@@ -0,0 +1,140 @@
+package embeddings
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// vcrCassette is one recorded request/response pair, serialized to its own
+// fixture file so individual interactions can be inspected or edited by
+// hand (e.g. to simulate a provider error offline).
+type vcrCassette struct {
+	Method         string            `json:"method"`
+	URL            string            `json:"url"`
+	RequestBody    string            `json:"requestBody"`
+	StatusCode     int               `json:"statusCode"`
+	ResponseBody   string            `json:"responseBody"`
+	ResponseHeader map[string]string `json:"responseHeader,omitempty"`
+}
+
+// vcrTransport is an http.RoundTripper that records real HTTP interactions
+// to fixture files ("record" mode) or serves them back without touching the
+// network ("replay" mode), VCR-style. This is how the embeddings package
+// gets deterministic integration tests and lets users debug provider issues
+// offline, without committing to a specific test framework's cassette format.
+type vcrTransport struct {
+	mode string // "record" or "replay"
+	dir  string
+	next http.RoundTripper
+}
+
+// newHTTPClientWithVCR builds an *http.Client for provider clientName,
+// honoring VCR_MODE ("record"/"replay") and VCR_CASSETTE_DIR. With VCR_MODE
+// unset, this is a plain client with no recording overhead.
+func newHTTPClientWithVCR(clientName string, timeout time.Duration) *http.Client {
+	mode := os.Getenv("VCR_MODE")
+	if mode != "record" && mode != "replay" {
+		return &http.Client{Timeout: timeout}
+	}
+
+	dir := os.Getenv("VCR_CASSETTE_DIR")
+	if dir == "" {
+		dir = "vcr_cassettes"
+	}
+	dir = filepath.Join(dir, clientName)
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &vcrTransport{
+			mode: mode,
+			dir:  dir,
+			next: http.DefaultTransport,
+		},
+	}
+}
+
+func (t *vcrTransport) cassettePath(method, url, body string) string {
+	h := sha256.Sum256([]byte(method + "|" + url + "|" + body))
+	return filepath.Join(t.dir, hex.EncodeToString(h[:])+".json")
+}
+
+func (t *vcrTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var bodyBytes []byte
+	if req.Body != nil {
+		var err error
+		bodyBytes, err = io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(bodyBytes))
+	}
+
+	path := t.cassettePath(req.Method, req.URL.String(), string(bodyBytes))
+
+	if t.mode == "replay" {
+		return t.replay(path)
+	}
+	return t.record(req, path, bodyBytes)
+}
+
+func (t *vcrTransport) record(req *http.Request, path string, bodyBytes []byte) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	cassette := vcrCassette{
+		Method:         req.Method,
+		URL:            req.URL.String(),
+		RequestBody:    string(bodyBytes),
+		StatusCode:     resp.StatusCode,
+		ResponseBody:   string(respBody),
+		ResponseHeader: map[string]string{"Content-Type": resp.Header.Get("Content-Type")},
+	}
+
+	if err := os.MkdirAll(t.dir, 0o755); err == nil {
+		if data, err := json.MarshalIndent(cassette, "", "  "); err == nil {
+			_ = os.WriteFile(path, data, 0o644)
+		}
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	return resp, nil
+}
+
+func (t *vcrTransport) replay(path string) (*http.Response, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("no recorded cassette for this request (looked for %s): %w", path, err)
+	}
+
+	var cassette vcrCassette
+	if err := json.Unmarshal(data, &cassette); err != nil {
+		return nil, fmt.Errorf("corrupt cassette %s: %w", path, err)
+	}
+
+	header := make(http.Header)
+	for k, v := range cassette.ResponseHeader {
+		header.Set(k, v)
+	}
+
+	return &http.Response{
+		StatusCode: cassette.StatusCode,
+		Header:     header,
+		Body:       io.NopCloser(bytes.NewReader([]byte(cassette.ResponseBody))),
+	}, nil
+}
@@ -0,0 +1,64 @@
+package embeddings
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+// TestVCRRecordThenReplay verifies that a recorded interaction can be
+// served back byte-for-byte in replay mode without hitting the network.
+func TestVCRRecordThenReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	t.Setenv("VCR_CASSETTE_DIR", dir)
+	t.Setenv("VCR_MODE", "record")
+
+	client := newHTTPClientWithVCR("testclient", 0)
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("record request failed: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != `{"ok":true}` {
+		t.Fatalf("unexpected recorded body: %s", body)
+	}
+
+	matches, _ := filepath.Glob(filepath.Join(dir, "testclient", "*.json"))
+	if len(matches) != 1 {
+		t.Fatalf("expected exactly one cassette file, got %d", len(matches))
+	}
+
+	server.Close() // prove replay doesn't touch the network
+
+	t.Setenv("VCR_MODE", "replay")
+	replayClient := newHTTPClientWithVCR("testclient", 0)
+	replayResp, err := replayClient.Get(server.URL)
+	if err != nil {
+		t.Fatalf("replay request failed: %v", err)
+	}
+	replayBody, _ := io.ReadAll(replayResp.Body)
+	replayResp.Body.Close()
+	if string(replayBody) != `{"ok":true}` {
+		t.Fatalf("unexpected replayed body: %s", replayBody)
+	}
+}
+
+func TestVCRReplayMissingCassette(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("VCR_CASSETTE_DIR", dir)
+	t.Setenv("VCR_MODE", "replay")
+
+	client := newHTTPClientWithVCR("testclient", 0)
+	if _, err := client.Get("http://example.invalid/nothing-recorded"); err == nil {
+		t.Error("expected error for a request with no recorded cassette")
+	}
+}
@@ -0,0 +1,86 @@
+// Package i18n provides message catalogs for the MCP server's own
+// user-facing strings (tool/prompt descriptions, error messages) -- not to
+// be confused with the book content's locale directories (es/, en/), which
+// are selected per-call via the existing `locale` tool parameter. This
+// package selects once, at startup, which language the server speaks to
+// whoever is reading its tool descriptions and error messages, since the
+// primary audience is Spanish-speaking.
+package i18n
+
+import "os"
+
+// Lang is a supported server display language.
+type Lang string
+
+const (
+	En Lang = "en"
+	Es Lang = "es"
+)
+
+// serverLangEnvVar selects the server's display language. Defaults to "en"
+// to match the language every doc comment and log line in this codebase is
+// already written in; set SERVER_LANG=es for a Spanish-speaking deployment.
+const serverLangEnvVar = "SERVER_LANG"
+
+// CurrentLang reads SERVER_LANG and returns the matching Lang, defaulting to
+// En for an unset or unrecognized value.
+func CurrentLang() Lang {
+	switch os.Getenv(serverLangEnvVar) {
+	case "es":
+		return Es
+	default:
+		return En
+	}
+}
+
+// catalog maps a message ID to its translation for each supported language.
+// Coverage is intentionally incremental: only IDs actually looked up via T
+// need an entry, and T falls back to the caller-supplied English text for
+// anything not yet cataloged, so partial coverage never breaks a build or
+// shows a blank string.
+var catalog = map[string]map[Lang]string{
+	"tool.list_chapters.description": {
+		Es: "Lista todos los capítulos del libro con sus metadatos (título, ruta, orden).",
+	},
+	"tool.read_chapter.description": {
+		Es: "Lee el contenido completo de un capítulo por su ID.",
+	},
+	"tool.search_book.description": {
+		Es: "Busca un término de texto en todos los capítulos del libro y devuelve las coincidencias.",
+	},
+	"tool.server_info.description": {
+		Es: "Informa la versión del servidor, el commit de git, el commit del contenido del libro, los proveedores de embeddings configurados y las funciones habilitadas. Datos útiles para reportar errores.",
+	},
+	"tool.setup_status.description": {
+		Es: "Informa si el servidor está en modo degradado (ruta del libro inválida) y qué hacer al respecto.",
+	},
+	"error.degraded_mode": {
+		Es: "El servidor está en modo degradado: %s. Llamá a set_book_path o reiniciá con un BOOK_PATH válido.",
+	},
+	"error.book_path_not_exist": {
+		Es: "La ruta del libro no existe: %s",
+	},
+	"error.chapter_not_found": {
+		Es: "capítulo no encontrado: %s",
+	},
+	"error.section_not_found": {
+		Es: "sección no encontrada: %s",
+	},
+}
+
+// T returns the translation of id for the current SERVER_LANG, falling back
+// to fallback (the English text, written inline at the call site so the
+// code stays readable even where no translation exists yet) when id has no
+// entry for the current language.
+func T(id string, fallback string) string {
+	lang := CurrentLang()
+	if lang == En {
+		return fallback
+	}
+	if translations, ok := catalog[id]; ok {
+		if translated, ok := translations[lang]; ok {
+			return translated
+		}
+	}
+	return fallback
+}
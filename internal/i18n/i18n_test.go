@@ -0,0 +1,25 @@
+package i18n
+
+import "testing"
+
+func TestTFallsBackToEnglishByDefault(t *testing.T) {
+	t.Setenv("SERVER_LANG", "")
+	if got := T("tool.list_chapters.description", "List all chapters."); got != "List all chapters." {
+		t.Fatalf("expected English fallback, got %q", got)
+	}
+}
+
+func TestTReturnsSpanishWhenConfigured(t *testing.T) {
+	t.Setenv("SERVER_LANG", "es")
+	got := T("tool.list_chapters.description", "List all chapters.")
+	if got == "List all chapters." {
+		t.Fatal("expected a Spanish translation, got the English fallback")
+	}
+}
+
+func TestTFallsBackForUncataloguedID(t *testing.T) {
+	t.Setenv("SERVER_LANG", "es")
+	if got := T("tool.does_not_exist.description", "fallback text"); got != "fallback text" {
+		t.Fatalf("expected fallback text for uncatalogued ID, got %q", got)
+	}
+}
@@ -0,0 +1,26 @@
+// Package pluginapi defines the hook interfaces a private fork can implement
+// to customize server behavior without diverging from upstream. Go plugins
+// (.so files built with `go build -buildmode=plugin`) export a package-level
+// variable implementing one or more of these interfaces; the server loads
+// them by path and wires them into the matching hook point.
+package pluginapi
+
+import "github.com/Alan-TheGentleman/gentleman-book-mcp/internal/book"
+
+// ContentNormalizer rewrites chapter content before it's served, e.g. to
+// strip a fork-specific boilerplate block or rewrite internal links.
+type ContentNormalizer interface {
+	NormalizeContent(content string) string
+}
+
+// RankingBooster adjusts a search result's relevance score, e.g. to weigh
+// certain chapters or sections higher for a specific deployment.
+type RankingBooster interface {
+	BoostRelevance(query string, result book.SearchResult) float64
+}
+
+// ResultPostProcessor transforms a full result set after ranking, e.g. to
+// filter out chapters a fork doesn't want to expose, or to re-order ties.
+type ResultPostProcessor interface {
+	PostProcessResults(results []book.SearchResult) []book.SearchResult
+}